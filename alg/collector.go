@@ -0,0 +1,143 @@
+package alg
+
+import (
+	"log"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_security_alg_"
+
+var (
+	enabledDesc        *prometheus.Desc
+	sessionsDesc       *prometheus.Desc
+	failedSessionsDesc *prometheus.Desc
+	packetsInDesc      *prometheus.Desc
+	droppedDesc        *prometheus.Desc
+	transactionErrDesc *prometheus.Desc
+	callErrDesc        *prometheus.Desc
+)
+
+// sessionAlgs are the ALGs session counts are collected for (if enabled on the device).
+// Junos only provides error and drop counters for the voice ALGs, so there are none for FTP and DNS.
+var sessionAlgs = []string{"sip", "ftp", "dns"}
+
+func init() {
+	l := []string{"target", "alg"}
+
+	enabledDesc = prometheus.NewDesc(prefix+"enabled", "ALG is enabled (1 = enabled)", l, nil)
+	sessionsDesc = prometheus.NewDesc(prefix+"sessions", "Number of active flow sessions handled by the ALG", l, nil)
+	failedSessionsDesc = prometheus.NewDesc(prefix+"failed_sessions", "Number of failed flow sessions handled by the ALG", l, nil)
+	packetsInDesc = prometheus.NewDesc(prefix+"packets_in_total", "Number of packets received by the ALG", l, nil)
+	droppedDesc = prometheus.NewDesc(prefix+"packets_dropped_total", "Number of packets dropped by the ALG due to errors", l, nil)
+	transactionErrDesc = prometheus.NewDesc(prefix+"transaction_errors_total", "Number of transaction errors of the ALG", l, nil)
+	callErrDesc = prometheus.NewDesc(prefix+"call_errors_total", "Number of call errors of the ALG", l, nil)
+}
+
+type algCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &algCollector{}
+}
+
+// Name returns the name of the collector
+func (*algCollector) Name() string {
+	return "ALG"
+}
+
+// Describe describes the metrics
+func (*algCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- enabledDesc
+	ch <- sessionsDesc
+	ch <- failedSessionsDesc
+	ch <- packetsInDesc
+	ch <- droppedDesc
+	ch <- transactionErrDesc
+	ch <- callErrDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *algCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = statusRpc{}
+	err := client.RunCommandAndParse("show security alg status", &x)
+	if err != nil {
+		return err
+	}
+
+	enabled := make(map[string]bool)
+	for _, e := range x.Information.Entries {
+		enabled[e.alg()] = e.enabled()
+		ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, boolToFloat(e.enabled()), append(labelValues, e.alg())...)
+	}
+
+	// a failing command only affects the metrics of one ALG, the error is returned after collecting the others
+	var firstErr error
+	for _, alg := range sessionAlgs {
+		if !enabled[alg] {
+			continue
+		}
+
+		err = c.collectSessions(client, ch, labelValues, alg)
+		if err != nil {
+			log.Printf("could not get %s sessions of %s: %s", alg, client.Device().Host, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if enabled["sip"] {
+		err = c.collectSIPCounters(client, ch, labelValues)
+		if err != nil {
+			log.Printf("could not get SIP counters of %s: %s", client.Device().Host, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+func (c *algCollector) collectSessions(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string, alg string) error {
+	var x = sessionSummaryRpc{}
+	err := client.RunCommandAndParse("show security flow session application "+alg+" summary", &x)
+	if err != nil {
+		return err
+	}
+
+	l := append(labelValues, alg)
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(x.Information.ActiveSessions), l...)
+	ch <- prometheus.MustNewConstMetric(failedSessionsDesc, prometheus.GaugeValue, float64(x.Information.FailedSessions), l...)
+
+	return nil
+}
+
+func (c *algCollector) collectSIPCounters(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = sipCountersRpc{}
+	err := client.RunCommandAndParse("show security alg sip counters", &x)
+	if err != nil {
+		return err
+	}
+
+	l := append(labelValues, "sip")
+	e := x.Information.ErrorCounters
+	ch <- prometheus.MustNewConstMetric(packetsInDesc, prometheus.CounterValue, float64(e.TotalPacketsIn), l...)
+	ch <- prometheus.MustNewConstMetric(droppedDesc, prometheus.CounterValue, float64(e.TotalPacketsDropped), l...)
+	ch <- prometheus.MustNewConstMetric(transactionErrDesc, prometheus.CounterValue, float64(e.TransactionErrors), l...)
+	ch <- prometheus.MustNewConstMetric(callErrDesc, prometheus.CounterValue, float64(e.CallErrors), l...)
+
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
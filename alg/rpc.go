@@ -0,0 +1,44 @@
+package alg
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+type statusRpc struct {
+	Information struct {
+		Entries []statusEntry `xml:",any"`
+	} `xml:"alg-status"`
+}
+
+type statusEntry struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// alg returns the name of the ALG (e.g. alg-status-sip -> sip)
+func (e statusEntry) alg() string {
+	return strings.TrimPrefix(e.XMLName.Local, "alg-status-")
+}
+
+func (e statusEntry) enabled() bool {
+	return strings.EqualFold(strings.TrimSpace(e.Value), "enabled")
+}
+
+type sessionSummaryRpc struct {
+	Information struct {
+		ActiveSessions int64 `xml:"active-sessions"`
+		FailedSessions int64 `xml:"failed-sessions"`
+	} `xml:"flow-session-summary-information"`
+}
+
+type sipCountersRpc struct {
+	Information struct {
+		ErrorCounters struct {
+			TotalPacketsIn      int64 `xml:"sip-error-total-pkt-in"`
+			TotalPacketsDropped int64 `xml:"sip-error-total-pkt-dropped-on-error"`
+			TransactionErrors   int64 `xml:"sip-error-transaction-error"`
+			CallErrors          int64 `xml:"sip-error-call-error"`
+		} `xml:"sip-error-counters"`
+	} `xml:"sip-counters-information"`
+}
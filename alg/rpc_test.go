@@ -0,0 +1,34 @@
+package alg
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatus(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <alg-status>
+        <alg-status-dns>Enabled</alg-status-dns>
+        <alg-status-ftp>Enabled</alg-status-ftp>
+        <alg-status-sip>Disabled</alg-status-sip>
+    </alg-status>
+</rpc-reply>`
+
+	x := statusRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.Information.Entries, 3)
+
+	e := x.Information.Entries[0]
+	assert.Equal(t, "dns", e.alg())
+	assert.True(t, e.enabled())
+
+	e = x.Information.Entries[2]
+	assert.Equal(t, "sip", e.alg())
+	assert.False(t, e.enabled())
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+var auditLog *auditWriter
+
+// auditRecord is written for every scrape of a target to allow analysis of missing metrics without access to Prometheus
+type auditRecord struct {
+	Time     time.Time    `json:"time"`
+	Target   string       `json:"target"`
+	Duration float64      `json:"duration_seconds"`
+	Up       bool         `json:"up"`
+	Skipped  string       `json:"skipped,omitempty"`
+	Commands []string     `json:"commands,omitempty"`
+	Samples  int          `json:"samples"`
+	Errors   []auditError `json:"errors,omitempty"`
+	mu       sync.Mutex
+}
+
+type auditError struct {
+	Collector string `json:"collector,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (r *auditRecord) skip(reason string) {
+	if r != nil {
+		r.Skipped = reason
+	}
+}
+
+func (r *auditRecord) result(up bool, samples int, client *rpc.Client) {
+	if r == nil {
+		return
+	}
+
+	r.Up = up
+	r.Samples = samples
+	if client != nil {
+		r.Commands = client.Commands()
+	}
+}
+
+func (r *auditRecord) recordError(collector string, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Errors = append(r.Errors, auditError{Collector: collector, Message: err.Error()})
+}
+
+// scrapeAudits holds the audit records of the targets of a scrape (nil if the audit log is disabled)
+type scrapeAudits map[*connector.Device]*auditRecord
+
+func newScrapeAudits(devices []*connector.Device) scrapeAudits {
+	if auditLog == nil {
+		return nil
+	}
+
+	a := make(scrapeAudits)
+	for _, d := range devices {
+		a[d] = &auditRecord{Time: time.Now(), Target: d.Host}
+	}
+
+	return a
+}
+
+func (a scrapeAudits) recordError(device *connector.Device, collector string, err error) {
+	a[device].recordError(collector, err)
+}
+
+// finish writes the record of the target to the audit log
+func (a scrapeAudits) finish(device *connector.Device, duration time.Duration) {
+	if r, found := a[device]; found {
+		r.Duration = duration.Seconds()
+		auditLog.write(r)
+	}
+}
+
+// auditWriter writes audit records as JSON lines
+type auditWriter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func initAuditLog() error {
+	if *auditFile != "" && *auditSyslog {
+		return fmt.Errorf("-audit.file and -audit.syslog are mutually exclusive")
+	}
+
+	if *auditFile != "" {
+		f, err := newRotatingFile(*auditFile, *auditFileMaxSize*1024*1024, *auditFileBackups)
+		if err != nil {
+			return err
+		}
+
+		auditLog = &auditWriter{w: f}
+	}
+
+	if *auditSyslog {
+		w, err := newAuditSyslog()
+		if err != nil {
+			return err
+		}
+
+		auditLog = &auditWriter{w: w}
+	}
+
+	return nil
+}
+
+func (a *auditWriter) write(r *auditRecord) {
+	r.mu.Lock()
+	b, err := json.Marshal(r)
+	r.mu.Unlock()
+	if err != nil {
+		log.Errorf("could not encode audit record: %v", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, err = a.w.Write(append(b, '\n'))
+	if err != nil {
+		log.Errorf("could not write audit record: %v", err)
+	}
+}
+
+// rotatingFile is a file renamed to <path>.1 (older ones to <path>.2 ...) when exceeding max. size
+type rotatingFile struct {
+	path    string
+	maxSize int64
+	backups int
+	f       *os.File
+	size    int64
+}
+
+func newRotatingFile(path string, maxSize int64, backups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, backups: backups}
+
+	err := r.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	r.f = f
+	r.size = info.Size()
+
+	return nil
+}
+
+func (r *rotatingFile) Write(b []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(b)) > r.maxSize {
+		err := r.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.f.Write(b)
+	r.size += int64(n)
+
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	err := r.f.Close()
+	if err != nil {
+		return err
+	}
+
+	if r.backups < 1 {
+		os.Remove(r.path)
+	} else {
+		for i := r.backups - 1; i > 0; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+		}
+
+		err = os.Rename(r.path, r.path+".1")
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.open()
+}
@@ -0,0 +1,12 @@
+//go:build windows || plan9
+
+package main
+
+import (
+	"errors"
+	"io"
+)
+
+func newAuditSyslog() (io.Writer, error) {
+	return nil, errors.New("syslog is not supported on this platform, use -audit.file instead")
+}
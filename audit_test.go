@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRecord(t *testing.T) {
+	buf := &bytes.Buffer{}
+	auditLog = &auditWriter{w: buf}
+	defer func() { auditLog = nil }()
+
+	d := &connector.Device{Host: "router1"}
+	a := newScrapeAudits([]*connector.Device{d})
+	a.recordError(d, "BGP", errors.New("timeout"))
+	a[d].result(true, 42, nil)
+	a.finish(d, 2*time.Second)
+
+	r := map[string]interface{}{}
+	err := json.Unmarshal(buf.Bytes(), &r)
+	assert.NoError(t, err)
+	assert.Equal(t, "router1", r["target"])
+	assert.Equal(t, 2.0, r["duration_seconds"])
+	assert.Equal(t, true, r["up"])
+	assert.Equal(t, 42.0, r["samples"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"collector": "BGP", "message": "timeout"}}, r["errors"])
+}
+
+func TestAuditDisabled(t *testing.T) {
+	d := &connector.Device{Host: "router1"}
+	a := newScrapeAudits([]*connector.Device{d})
+	assert.Nil(t, a)
+
+	a.recordError(d, "BGP", errors.New("timeout"))
+	a[d].skip("paused")
+	a[d].result(false, 0, nil)
+	a.finish(d, time.Second)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	f, err := newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err = f.Write([]byte(s))
+		assert.NoError(t, err)
+	}
+
+	b, _ := os.ReadFile(path)
+	assert.Equal(t, "dddddddd\n", string(b))
+	b, _ = os.ReadFile(path + ".1")
+	assert.Equal(t, "cccccccc\n", string(b))
+	b, _ = os.ReadFile(path + ".2")
+	assert.Equal(t, "bbbbbbbb\n", string(b))
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "only 2 backups are kept")
+}
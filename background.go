@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpm"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	dataAgeDesc *prometheus.Desc
+)
+
+func init() {
+	dataAgeDesc = prometheus.NewDesc(prefix+"data_age_seconds", "Age of the served data in seconds (background collection only)", []string{"target"}, nil)
+}
+
+type cacheEntry struct {
+	metrics   []prometheus.Metric
+	timestamp time.Time
+}
+
+// metricCache holds the results of the latest background collection per target
+type metricCache struct {
+	entries map[string]*cacheEntry
+	mu      sync.RWMutex
+}
+
+func newMetricCache() *metricCache {
+	return &metricCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (m *metricCache) get(host string) (*cacheEntry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, found := m.entries[host]
+	return e, found
+}
+
+func (m *metricCache) set(host string, e *cacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[host] = e
+}
+
+// retain removes all entries for hosts not in the given device list
+func (m *metricCache) retain(devices []*connector.Device) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hosts := make(map[string]struct{})
+	for _, d := range devices {
+		hosts[d.Host] = struct{}{}
+	}
+
+	for h := range m.entries {
+		if _, found := hosts[h]; !found {
+			delete(m.entries, h)
+		}
+	}
+}
+
+func startBackgroundCollection(interval time.Duration) {
+	log.Infof("Starting background collection (interval: %v)", interval)
+
+	go func() {
+		for {
+			collectInBackground()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func collectInBackground() {
+	if election != nil && !election.isLeader() {
+		return
+	}
+
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	// only the background collection of the active instance changes the configuration of the devices
+	ctx := rpm.WithProvisioning(context.Background())
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(devices))
+	for _, d := range devices {
+		go func(d *connector.Device) {
+			defer wg.Done()
+
+			cache.set(d.Host, &cacheEntry{
+				metrics:   collectDevice(ctx, d, ""),
+				timestamp: time.Now(),
+			})
+		}(d)
+	}
+	wg.Wait()
+
+	cache.retain(devices)
+
+	if len(outputs) > 0 {
+		pushResults(devices, outputs)
+	}
+}
+
+func collectMetrics(c prometheus.Collector) []prometheus.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	metrics := make([]prometheus.Metric, 0)
+	for m := range ch {
+		metrics = append(metrics, m)
+	}
+
+	return metrics
+}
+
+// cachedCollector serves the results of the background collection.
+// Devices without any cached results are collected on demand.
+type cachedCollector struct {
+	ctx     context.Context
+	devices []*connector.Device
+}
+
+func newCachedCollector(ctx context.Context, devices []*connector.Device) *cachedCollector {
+	return &cachedCollector{
+		ctx:     ctx,
+		devices: devices,
+	}
+}
+
+// Describe implements prometheus.Collector interface
+func (c *cachedCollector) Describe(ch chan<- *prometheus.Desc) {
+	// no descriptors are sent since the metrics served depend on the last collection (unchecked collector)
+}
+
+// Collect implements prometheus.Collector interface
+func (c *cachedCollector) Collect(ch chan<- prometheus.Metric) {
+	missing := make([]*connector.Device, 0)
+
+	for _, d := range c.devices {
+		e, found := cache.get(d.Host)
+		if !found {
+			missing = append(missing, d)
+			continue
+		}
+
+		for _, m := range e.metrics {
+			if *backgroundTimestamps {
+				m = prometheus.NewMetricWithTimestamp(e.timestamp, m)
+			}
+
+			ch <- m
+		}
+
+		ch <- prometheus.MustNewConstMetric(dataAgeDesc, prometheus.GaugeValue, time.Since(e.timestamp).Seconds(), d.Host)
+	}
+
+	if len(missing) > 0 {
+		newCoalescingCollector(c.ctx, missing, "").Collect(ch)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/czerwonk/junos_exporter/connector"
+)
+
+func TestCachedCollector(t *testing.T) {
+	d1 := &connector.Device{Host: "router1"}
+	d2 := &connector.Device{Host: "router2"}
+
+	cache.set(d1.Host, &cacheEntry{
+		metrics: []prometheus.Metric{
+			prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, d1.Host),
+		},
+		timestamp: time.Now().Add(-30 * time.Second),
+	})
+	cache.set(d2.Host, &cacheEntry{timestamp: time.Now()})
+	defer cache.retain(nil)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(newCachedCollector(context.Background(), []*connector.Device{d1}))
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(mfs), "metric families")
+	assert.Equal(t, "junos_data_age_seconds", mfs[0].GetName())
+	assert.InDelta(t, 30, mfs[0].Metric[0].GetGauge().GetValue(), 5, "data age")
+	assert.Equal(t, "junos_up", mfs[1].GetName())
+}
+
+func TestMetricCacheRetain(t *testing.T) {
+	cache := newMetricCache()
+	cache.set("router1", &cacheEntry{})
+	cache.set("router2", &cacheEntry{})
+
+	cache.retain([]*connector.Device{{Host: "router2"}})
+
+	_, found := cache.get("router1")
+	assert.False(t, found, "router1 should have been removed")
+
+	_, found = cache.get("router2")
+	assert.True(t, found, "router2 should have been retained")
+}
+
+func TestCachedCollectorTimestamps(t *testing.T) {
+	*backgroundTimestamps = true
+	defer func() { *backgroundTimestamps = false }()
+
+	ts := time.Now().Add(-30 * time.Second)
+	d := &connector.Device{Host: "router1"}
+	cache.set(d.Host, &cacheEntry{
+		metrics: []prometheus.Metric{
+			prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, d.Host),
+		},
+		timestamp: ts,
+	})
+	defer cache.retain(nil)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(newCachedCollector(context.Background(), []*connector.Device{d}))
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(mfs), "metric families")
+	assert.Nil(t, mfs[0].Metric[0].TimestampMs, "data age without timestamp")
+	assert.Equal(t, ts.UnixMilli(), mfs[1].Metric[0].GetTimestampMs(), "collection timestamp")
+}
@@ -19,6 +19,7 @@ var (
 func init() {
 	l := []string{"target", "neighbor", "interface", "client"}
 	bfdState = prometheus.NewDesc(prefix+"state", "bfd state (0: down, 1:up)", l, nil)
+	collector.RegisterStates(prefix+"state", bfdStateMap)
 }
 
 type bfdCollector struct {
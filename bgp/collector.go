@@ -1,6 +1,8 @@
 package bgp
 
 import (
+	"log"
+
 	"github.com/czerwonk/junos_exporter/collector"
 	"github.com/czerwonk/junos_exporter/rpc"
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,8 +12,12 @@ import (
 
 const prefix string = "junos_bgp_session_"
 
+// states are the BGP FSM states (RFC 4271) exported as state label
+var states = []string{"Idle", "Connect", "Active", "OpenSent", "OpenConfirm", "Established"}
+
 var (
 	upDesc                 *prometheus.Desc
+	stateDesc              *prometheus.Desc
 	receivedPrefixesDesc   *prometheus.Desc
 	acceptedPrefixesDesc   *prometheus.Desc
 	rejectedPrefixesDesc   *prometheus.Desc
@@ -20,14 +26,20 @@ var (
 	inputMessagesDesc      *prometheus.Desc
 	outputMessagesDesc     *prometheus.Desc
 	flapsDesc              *prometheus.Desc
+	prefixLimitDesc        *prometheus.Desc
+	prefixLimitUsageDesc   *prometheus.Desc
+	peersScrapedDesc       *prometheus.Desc
 )
 
 func init() {
-	l := []string{"target", "asn", "ip", "description", "group"}
+	peersScrapedDesc = prometheus.NewDesc("junos_bgp_peers_scraped", "Number of BGP peers found in the output of the device", []string{"target"}, nil)
+
+	l := []string{"target", "asn", "ip", "description", "group", "routing_instance"}
 	upDesc = prometheus.NewDesc(prefix+"up", "Session is up (1 = Established)", l, nil)
 	inputMessagesDesc = prometheus.NewDesc(prefix+"messages_input_count", "Number of received messages", l, nil)
 	outputMessagesDesc = prometheus.NewDesc(prefix+"messages_output_count", "Number of transmitted messages", l, nil)
 	flapsDesc = prometheus.NewDesc(prefix+"flap_count", "Number of session flaps", l, nil)
+	stateDesc = prometheus.NewDesc(prefix+"state", "Session state (1 for the current state of the session, 0 for all other states)", append(l, "state"), nil)
 
 	l = append(l, "table")
 	receivedPrefixesDesc = prometheus.NewDesc(prefix+"prefixes_received_count", "Number of received prefixes", l, nil)
@@ -35,6 +47,8 @@ func init() {
 	rejectedPrefixesDesc = prometheus.NewDesc(prefix+"prefixes_rejected_count", "Number of rejected prefixes", l, nil)
 	activePrefixesDesc = prometheus.NewDesc(prefix+"prefixes_active_count", "Number of active prefixes (best route in RIB)", l, nil)
 	advertisedPrefixesDesc = prometheus.NewDesc(prefix+"prefixes_advertised_count", "Number of prefixes announced to peer", l, nil)
+	prefixLimitDesc = prometheus.NewDesc(prefix+"prefix_limit_count", "Configured max. number of prefixes (prefix-limit or accepted-prefix-limit)", l, nil)
+	prefixLimitUsageDesc = prometheus.NewDesc(prefix+"prefix_limit_usage_ratio", "Ratio of received (accepted for accepted-prefix-limit) prefixes to the configured prefix limit", l, nil)
 }
 
 type bgpCollector struct {
@@ -54,6 +68,7 @@ func (*bgpCollector) Name() string {
 // Describe describes the metrics
 func (*bgpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- upDesc
+	ch <- stateDesc
 	ch <- receivedPrefixesDesc
 	ch <- acceptedPrefixesDesc
 	ch <- rejectedPrefixesDesc
@@ -62,6 +77,10 @@ func (*bgpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- inputMessagesDesc
 	ch <- outputMessagesDesc
 	ch <- flapsDesc
+	ch <- prefixLimitDesc
+	ch <- prefixLimitUsageDesc
+	ch <- peersScrapedDesc
+	ch <- collector.ComponentHealthyDesc
 }
 
 // Collect collects metrics from JunOS
@@ -87,16 +106,57 @@ func (c *bgpCollector) collect(client *rpc.Client, ch chan<- prometheus.Metric,
 		return err
 	}
 
+	// peers are still collected if the configuration can not be read (e.g. missing permissions), just without limits
+	limits, err := c.prefixLimits(client)
+	if err != nil {
+		log.Printf("could not get BGP prefix limits of %s: %s", client.Device().Host, err)
+	}
+
 	for _, peer := range x.Information.Peers {
-		c.collectForPeer(peer, ch, labelValues)
+		c.collectForPeer(peer, limits, ch, labelValues)
 	}
 
+	ch <- prometheus.MustNewConstMetric(peersScrapedDesc, prometheus.GaugeValue, float64(len(x.Information.Peers)), labelValues...)
+
 	return nil
 }
 
-func (c *bgpCollector) collectForPeer(p BGPPeer, ch chan<- prometheus.Metric, labelValues []string) {
+func (c *bgpCollector) prefixLimits(client *rpc.Client) (*prefixLimits, error) {
+	var x = bgpConfigRpc{}
+	cmd := "show configuration protocols bgp | display inheritance"
+	if c.LogicalSystem != "" {
+		cmd = "show configuration logical-systems " + c.LogicalSystem + " protocols bgp | display inheritance"
+	}
+
+	err := client.RunCommandAndParse(cmd, &x)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := prefixLimitsFromConfig(append(x.Groups, x.LSGroups...))
+
+	var y = bgpInstanceConfigRpc{}
+	cmd = "show configuration routing-instances | display inheritance"
+	if c.LogicalSystem != "" {
+		cmd = "show configuration logical-systems " + c.LogicalSystem + " routing-instances | display inheritance"
+	}
+
+	err = client.RunCommandAndParse(cmd, &y)
+	if err != nil {
+		log.Printf("could not get BGP prefix limits of routing instances of %s: %s", client.Device().Host, err)
+		return limits, nil
+	}
+
+	for _, i := range append(y.Instances, y.LSInstances...) {
+		limits.add(i.Name, i.Groups)
+	}
+
+	return limits, nil
+}
+
+func (c *bgpCollector) collectForPeer(p BGPPeer, limits *prefixLimits, ch chan<- prometheus.Metric, labelValues []string) {
 	ip := strings.Split(p.IP, "+")
-	l := append(labelValues, []string{p.ASN, ip[0], p.Description, p.Group}...)
+	l := append(labelValues, []string{p.ASN, ip[0], p.Description, p.Group, instanceForPeer(p)}...)
 
 	up := 0
 	if p.State == "Established" {
@@ -104,14 +164,44 @@ func (c *bgpCollector) collectForPeer(p BGPPeer, ch chan<- prometheus.Metric, la
 	}
 
 	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, float64(up), l...)
+	ch <- collector.ComponentHealthy(labelValues[0], collector.ComponentBGPPeer, peerName(ip[0], instanceForPeer(p)), up == 1)
 	ch <- prometheus.MustNewConstMetric(inputMessagesDesc, prometheus.GaugeValue, float64(p.InputMessages), l...)
 	ch <- prometheus.MustNewConstMetric(outputMessagesDesc, prometheus.GaugeValue, float64(p.OutputMessages), l...)
 	ch <- prometheus.MustNewConstMetric(flapsDesc, prometheus.GaugeValue, float64(p.Flaps), l...)
 
-	c.collectRIBForPeer(p, ch, l)
+	for _, s := range states {
+		v := 0
+		if p.State == s {
+			v = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, float64(v), append(l, s)...)
+	}
+
+	c.collectRIBForPeer(p, limits, ch, l)
 }
 
-func (*bgpCollector) collectRIBForPeer(p BGPPeer, ch chan<- prometheus.Metric, labelValues []string) {
+// peerName returns the name of the peer for the normalized health metric (instance qualified outside of the master instance)
+func peerName(ip, instance string) string {
+	if instance == collector.MasterInstance {
+		return ip
+	}
+
+	return instance + "/" + ip
+}
+
+func instanceForPeer(p BGPPeer) string {
+	if p.Instance == "" {
+		return collector.MasterInstance
+	}
+
+	return p.Instance
+}
+
+func (*bgpCollector) collectRIBForPeer(p BGPPeer, limits *prefixLimits, ch chan<- prometheus.Metric, labelValues []string) {
+	ip := strings.Split(p.IP, "+")[0]
+	instance := instanceForPeer(p)
+
 	for _, rib := range p.RIBs {
 		l := append(labelValues, rib.Name)
 		ch <- prometheus.MustNewConstMetric(receivedPrefixesDesc, prometheus.GaugeValue, float64(rib.ReceivedPrefixes), l...)
@@ -119,5 +209,15 @@ func (*bgpCollector) collectRIBForPeer(p BGPPeer, ch chan<- prometheus.Metric, l
 		ch <- prometheus.MustNewConstMetric(rejectedPrefixesDesc, prometheus.GaugeValue, float64(rib.RejectedPrefixes), l...)
 		ch <- prometheus.MustNewConstMetric(activePrefixesDesc, prometheus.GaugeValue, float64(rib.ActivePrefixes), l...)
 		ch <- prometheus.MustNewConstMetric(advertisedPrefixesDesc, prometheus.GaugeValue, float64(rib.AdvertisedPrefixes), l...)
+
+		if lim, found := limits.forPeer(instance, ip, p.Group, rib.Name); found {
+			prefixes := rib.ReceivedPrefixes
+			if lim.accepted {
+				prefixes = rib.AcceptedPrefixes
+			}
+
+			ch <- prometheus.MustNewConstMetric(prefixLimitDesc, prometheus.GaugeValue, float64(lim.maximum), l...)
+			ch <- prometheus.MustNewConstMetric(prefixLimitUsageDesc, prometheus.GaugeValue, float64(prefixes)/float64(lim.maximum), l...)
+		}
 	}
 }
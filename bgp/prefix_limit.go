@@ -0,0 +1,102 @@
+package bgp
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+)
+
+// tables maps address families as named in the config to the RIB a peer's prefixes are counted in
+var tables = map[string]string{
+	"inet/unicast":           "inet.0",
+	"inet/labeled-unicast":   "inet.0",
+	"inet/multicast":         "inet.2",
+	"inet6/unicast":          "inet6.0",
+	"inet6/labeled-unicast":  "inet6.0",
+	"inet6/multicast":        "inet6.2",
+	"inet-vpn/unicast":       "bgp.l3vpn.0",
+	"inet6-vpn/unicast":      "bgp.l3vpn-inet6.0",
+	"l2vpn/signaling":        "bgp.l2vpn.0",
+	"evpn/signaling":         "bgp.evpn.0",
+	"inet-mvpn/signaling":    "bgp.mvpn.0",
+	"route-target/signaling": "bgp.rtarget.0",
+}
+
+// limit is a configured prefix limit of a peer for a table
+type limit struct {
+	maximum  int64
+	accepted bool
+}
+
+// prefixLimits holds the configured limits by routing instance and group or neighbor and table
+type prefixLimits struct {
+	groups    map[string]map[string]limit
+	neighbors map[string]map[string]limit
+}
+
+func newPrefixLimits() *prefixLimits {
+	return &prefixLimits{
+		groups:    make(map[string]map[string]limit),
+		neighbors: make(map[string]map[string]limit),
+	}
+}
+
+func prefixLimitsFromConfig(groups []bgpGroupConfig) *prefixLimits {
+	p := newPrefixLimits()
+	p.add(collector.MasterInstance, groups)
+
+	return p
+}
+
+func (p *prefixLimits) add(instance string, groups []bgpGroupConfig) {
+	for _, g := range groups {
+		p.groups[instance+"/"+g.Name] = limitsForFamily(g.Family)
+
+		for _, n := range g.Neighbors {
+			p.neighbors[instance+"/"+n.Name] = limitsForFamily(n.Family)
+		}
+	}
+}
+
+func limitsForFamily(f bgpFamilyConfig) map[string]limit {
+	limits := make(map[string]limit)
+
+	for _, afi := range f.AFIs {
+		for _, safi := range afi.SAFIs {
+			table, found := tables[afi.XMLName.Local+"/"+safi.XMLName.Local]
+			if !found {
+				continue
+			}
+
+			if safi.PrefixLimit != nil && safi.PrefixLimit.Maximum > 0 {
+				limits[table] = limit{maximum: safi.PrefixLimit.Maximum}
+			}
+
+			if safi.AcceptedPrefixLimit != nil && safi.AcceptedPrefixLimit.Maximum > 0 {
+				limits[table] = limit{maximum: safi.AcceptedPrefixLimit.Maximum, accepted: true}
+			}
+		}
+	}
+
+	return limits
+}
+
+// forPeer returns the limit configured for a peer and table. Group limits apply to peers not configured explicitly (e.g. dynamic peers).
+func (p *prefixLimits) forPeer(instance, ip, group, table string) (limit, bool) {
+	if p == nil {
+		return limit{}, false
+	}
+
+	limits, found := p.neighbors[instance+"/"+ip]
+	if !found {
+		limits = p.groups[instance+"/"+group]
+	}
+
+	// tables of routing instances are prefixed by the instance name (e.g. customer.inet.0)
+	if instance != collector.MasterInstance {
+		table = strings.TrimPrefix(table, instance+".")
+	}
+
+	l, found := limits[table]
+	return l, found
+}
@@ -0,0 +1,77 @@
+package bgp
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixLimitsFromConfig(t *testing.T) {
+	body := `<rpc-reply>
+<configuration>
+<protocols>
+<bgp>
+<group>
+<name>transit</name>
+<family>
+<inet>
+<unicast>
+<prefix-limit>
+<maximum>1000000</maximum>
+</prefix-limit>
+</unicast>
+</inet>
+</family>
+<neighbor>
+<name>192.0.2.1</name>
+<family>
+<inet>
+<unicast>
+<prefix-limit>
+<maximum>900000</maximum>
+</prefix-limit>
+</unicast>
+</inet>
+<inet6>
+<unicast>
+<accepted-prefix-limit>
+<maximum>200000</maximum>
+</accepted-prefix-limit>
+</unicast>
+</inet6>
+</family>
+</neighbor>
+</group>
+</bgp>
+</protocols>
+</configuration>
+</rpc-reply>`
+
+	rpc := bgpConfigRpc{}
+	err := xml.Unmarshal([]byte(body), &rpc)
+	assert.NoError(t, err)
+
+	limits := prefixLimitsFromConfig(rpc.Groups)
+
+	l, found := limits.forPeer(collector.MasterInstance, "192.0.2.1", "transit", "inet.0")
+	assert.True(t, found)
+	assert.Equal(t, limit{maximum: 900000}, l)
+
+	l, found = limits.forPeer(collector.MasterInstance, "192.0.2.1", "transit", "inet6.0")
+	assert.True(t, found)
+	assert.Equal(t, limit{maximum: 200000, accepted: true}, l)
+
+	l, found = limits.forPeer(collector.MasterInstance, "192.0.2.2", "transit", "inet.0")
+	assert.True(t, found, "group limit applies to peers without neighbor config")
+	assert.Equal(t, int64(1000000), l.maximum)
+
+	limits.add("customer", []bgpGroupConfig{rpc.Groups[0]})
+	l, found = limits.forPeer("customer", "192.0.2.1", "transit", "customer.inet.0")
+	assert.True(t, found)
+	assert.Equal(t, limit{maximum: 900000}, l)
+
+	_, found = limits.forPeer(collector.MasterInstance, "192.0.2.3", "peering", "inet.0")
+	assert.False(t, found)
+}
@@ -1,5 +1,7 @@
 package bgp
 
+import "encoding/xml"
+
 type BGPRPC struct {
 	Information struct {
 		Peers []BGPPeer `xml:"bgp-peer"`
@@ -11,6 +13,7 @@ type BGPPeer struct {
 	ASN            string `xml:"peer-as"`
 	State          string `xml:"peer-state"`
 	Group          string `xml:"peer-group"`
+	Instance       string `xml:"peer-cfg-rti"`
 	Description    string `xml:"description"`
 	Flaps          int64  `xml:"flap-count"`
 	InputMessages  int64  `xml:"input-messages"`
@@ -26,3 +29,42 @@ type RIB struct {
 	RejectedPrefixes   int64  `xml:"suppressed-prefix-count"`
 	AdvertisedPrefixes int64  `xml:"advertised-prefix-count"`
 }
+
+type bgpConfigRpc struct {
+	Groups   []bgpGroupConfig `xml:"configuration>protocols>bgp>group"`
+	LSGroups []bgpGroupConfig `xml:"configuration>logical-systems>protocols>bgp>group"`
+}
+
+type bgpInstanceConfigRpc struct {
+	Instances   []bgpInstanceConfig `xml:"configuration>routing-instances>instance"`
+	LSInstances []bgpInstanceConfig `xml:"configuration>logical-systems>routing-instances>instance"`
+}
+
+type bgpInstanceConfig struct {
+	Name   string           `xml:"name"`
+	Groups []bgpGroupConfig `xml:"protocols>bgp>group"`
+}
+
+type bgpGroupConfig struct {
+	Name      string          `xml:"name"`
+	Family    bgpFamilyConfig `xml:"family"`
+	Neighbors []struct {
+		Name   string          `xml:"name"`
+		Family bgpFamilyConfig `xml:"family"`
+	} `xml:"neighbor"`
+}
+
+type bgpFamilyConfig struct {
+	AFIs []struct {
+		XMLName xml.Name
+		SAFIs   []struct {
+			XMLName             xml.Name
+			PrefixLimit         *prefixLimit `xml:"prefix-limit"`
+			AcceptedPrefixLimit *prefixLimit `xml:"accepted-prefix-limit"`
+		} `xml:",any"`
+	} `xml:",any"`
+}
+
+type prefixLimit struct {
+	Maximum int64 `xml:"maximum"`
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	flights       singleflight.Group
+	responseCache = newMetricCache()
+)
+
+// coalescingCollector collects each device on its own. Concurrent collections of the same target
+// (e.g. by redundant Prometheus servers) share one collection, so the device is only queried once.
+type coalescingCollector struct {
+	ctx           context.Context
+	devices       []*connector.Device
+	logicalSystem string
+}
+
+func newCoalescingCollector(ctx context.Context, devices []*connector.Device, logicalSystem string) *coalescingCollector {
+	return &coalescingCollector{
+		ctx:           ctx,
+		devices:       devices,
+		logicalSystem: logicalSystem,
+	}
+}
+
+// Describe implements prometheus.Collector interface
+func (c *coalescingCollector) Describe(ch chan<- *prometheus.Desc) {
+	// no descriptors are sent since the metrics are collected by separate collectors per device (unchecked collector)
+}
+
+// Collect implements prometheus.Collector interface
+func (c *coalescingCollector) Collect(ch chan<- prometheus.Metric) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(c.devices))
+
+	for _, d := range c.devices {
+		go func(d *connector.Device) {
+			defer wg.Done()
+
+			for _, m := range collectDevice(c.ctx, d, c.logicalSystem) {
+				ch <- m
+			}
+		}(d)
+	}
+
+	wg.Wait()
+}
+
+// collectDevice collects all metrics of a device. Callers requesting the same device while a collection is running get its result.
+// If the response cache is enabled, results younger than the cache TTL are served without querying the device.
+func collectDevice(ctx context.Context, device *connector.Device, logicalSystem string) []prometheus.Metric {
+	key := device.Host + "/" + logicalSystem
+
+	if *cacheTTL > 0 {
+		if e, found := responseCache.get(key); found && time.Since(e.timestamp) < *cacheTTL {
+			return e.metrics
+		}
+	}
+
+	res, _, _ := flights.Do(key, func() (interface{}, error) {
+		c := newJunosCollector(ctx, []*connector.Device{device}, connManager, logicalSystem)
+		metrics := collectMetrics(c)
+
+		if logicalSystem == "" {
+			metrics = append(metrics, collectLogicalSystems(c, device)...)
+		}
+
+		if *cacheTTL > 0 {
+			responseCache.set(key, &cacheEntry{
+				metrics:   metrics,
+				timestamp: time.Now(),
+			})
+		}
+
+		return metrics, nil
+	})
+
+	return res.([]prometheus.Metric)
+}
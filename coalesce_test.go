@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/czerwonk/junos_exporter/connector"
+)
+
+func TestCollectDeviceServesFromResponseCache(t *testing.T) {
+	ttl := *cacheTTL
+	*cacheTTL = time.Minute
+	defer func() {
+		*cacheTTL = ttl
+		responseCache = newMetricCache()
+	}()
+
+	m := prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, "router1")
+	responseCache.set("router1/", &cacheEntry{
+		metrics:   []prometheus.Metric{m},
+		timestamp: time.Now(),
+	})
+
+	// no connection manager is initialized, so a cache miss would fail
+	metrics := collectDevice(context.Background(), &connector.Device{Host: "router1"}, "")
+	assert.Equal(t, []prometheus.Metric{m}, metrics)
+}
@@ -0,0 +1,99 @@
+// Package collectortest provides golden file tests for collectors.
+//
+// A collector is run against command outputs recorded by -record.dir (testdata/<name>/<command>.xml) and its exposition
+// is compared to testdata/<name>.prom. Run the tests with -update to write the golden files after intended changes.
+package collectortest
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/dump"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+const testdataDir = "testdata"
+
+var update = flag.Bool("update", false, "Write the golden files instead of comparing the output of the collectors")
+
+// adapter runs a collector for a target (Describe is not implemented to allow descs created during collection)
+type adapter struct {
+	col    collector.RPCCollector
+	client *rpc.Client
+	target string
+	err    error
+}
+
+func (a *adapter) Describe(ch chan<- *prometheus.Desc) {
+}
+
+func (a *adapter) Collect(ch chan<- prometheus.Metric) {
+	a.err = a.col.Collect(a.client, ch, []string{a.target})
+}
+
+// Exposition runs the collector against the outputs recorded for the target name and returns the exposition in text format
+func Exposition(c collector.RPCCollector, name string) ([]byte, error) {
+	device := &connector.Device{Host: name}
+	a := &adapter{
+		col:    c,
+		client: rpc.NewClient(dump.NewReplay(device, testdataDir)),
+		target: name,
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(a)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	buf := &bytes.Buffer{}
+	for _, mf := range mfs {
+		_, err = expfmt.MetricFamilyToText(buf, mf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AssertGolden runs the collector against the outputs recorded in testdata/<name> and compares the exposition to testdata/<name>.prom
+func AssertGolden(t *testing.T, c collector.RPCCollector, name string) {
+	t.Helper()
+
+	b, err := Exposition(c, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(testdataDir, name+".prom")
+	if *update {
+		err = ioutil.WriteFile(file, b, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("could not read golden file (run with -update to create it): %v", err)
+	}
+
+	assert.Equal(t, string(expected), string(b), "exposition differs from %s (run with -update if the change is intended)", file)
+}
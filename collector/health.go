@@ -0,0 +1,26 @@
+package collector
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Component types of the normalized health metric
+const (
+	ComponentPSU           = "psu"
+	ComponentFan           = "fan"
+	ComponentRoutingEngine = "re"
+	ComponentOptic         = "optic"
+	ComponentBGPPeer       = "bgp_peer"
+)
+
+// ComponentHealthyDesc describes the normalized health metric emitted by the detailed collectors.
+// It allows fleet-wide alert rules regardless of platform specific raw metrics.
+var ComponentHealthyDesc = prometheus.NewDesc("junos_component_healthy", "Component is healthy (1 = healthy, 0 = unhealthy)", []string{"target", "type", "name"}, nil)
+
+// ComponentHealthy returns the normalized health metric for a component of a target
+func ComponentHealthy(target, componentType, name string, healthy bool) prometheus.Metric {
+	v := 0.0
+	if healthy {
+		v = 1
+	}
+
+	return prometheus.MustNewConstMetric(ComponentHealthyDesc, prometheus.GaugeValue, v, target, componentType, name)
+}
@@ -0,0 +1,27 @@
+package collector
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentHealthy(t *testing.T) {
+	tests := []struct {
+		healthy  bool
+		expected float64
+	}{
+		{healthy: true, expected: 1},
+		{healthy: false, expected: 0},
+	}
+
+	for _, test := range tests {
+		m := ComponentHealthy("router1", ComponentPSU, "PEM 0", test.healthy)
+
+		out := &dto.Metric{}
+		assert.NoError(t, m.Write(out))
+		assert.Equal(t, test.expected, out.GetGauge().GetValue())
+		assert.Len(t, out.GetLabel(), 3)
+	}
+}
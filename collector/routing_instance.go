@@ -0,0 +1,21 @@
+package collector
+
+import "strings"
+
+// MasterInstance is the name of the default routing instance
+const MasterInstance = "master"
+
+// RoutingInstanceForTable returns the routing instance a table belongs to (e.g. customer for customer.inet.0).
+// Tables without instance prefix (e.g. inet.0, bgp.l3vpn.0) belong to the master instance.
+func RoutingInstanceForTable(table string) string {
+	if strings.HasPrefix(table, "bgp.") {
+		return MasterInstance
+	}
+
+	parts := strings.Split(table, ".")
+	if len(parts) < 3 {
+		return MasterInstance
+	}
+
+	return strings.Join(parts[:len(parts)-2], ".")
+}
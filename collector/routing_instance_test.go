@@ -0,0 +1,22 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutingInstanceForTable(t *testing.T) {
+	tests := map[string]string{
+		"inet.0":                      MasterInstance,
+		"inet6.3":                     MasterInstance,
+		"bgp.l3vpn.0":                 MasterInstance,
+		"customer.inet.0":             "customer",
+		"customer.inet6.0":            "customer",
+		"__juniper_private1__.inet.0": "__juniper_private1__",
+	}
+
+	for table, expected := range tests {
+		assert.Equal(t, expected, RoutingInstanceForTable(table), table)
+	}
+}
@@ -0,0 +1,40 @@
+package collector
+
+import "sync"
+
+var (
+	stateNames   = make(map[string]map[float64]string)
+	stateNamesMu sync.RWMutex
+)
+
+// RegisterStates registers the mapping of state names to the numeric values a state metric is emitted with.
+// It allows emitting the name of the state along with the value (see StateName).
+func RegisterStates(metric string, states map[string]int) {
+	stateNamesMu.Lock()
+	defer stateNamesMu.Unlock()
+
+	names := make(map[float64]string, len(states))
+	for name, v := range states {
+		names[float64(v)] = name
+	}
+
+	stateNames[metric] = names
+}
+
+// HasStates returns whether states are registered for the metric
+func HasStates(metric string) bool {
+	stateNamesMu.RLock()
+	defer stateNamesMu.RUnlock()
+
+	_, found := stateNames[metric]
+	return found
+}
+
+// StateName returns the name of the state represented by the value of a state metric
+func StateName(metric string, v float64) (string, bool) {
+	stateNamesMu.RLock()
+	defer stateNamesMu.RUnlock()
+
+	name, found := stateNames[metric][v]
+	return name, found
+}
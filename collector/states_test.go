@@ -0,0 +1,21 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateName(t *testing.T) {
+	RegisterStates("junos_test_state", map[string]int{"Down": 0, "Up": 1})
+
+	assert.True(t, HasStates("junos_test_state"))
+	assert.False(t, HasStates("junos_test_other"))
+
+	name, found := StateName("junos_test_state", 1)
+	assert.True(t, found)
+	assert.Equal(t, "Up", name)
+
+	_, found = StateName("junos_test_state", 2)
+	assert.False(t, found)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/interfaces"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var intervalResults = newCollectorResults()
+
+func init() {
+	// results referring to interfaces are outdated as soon as the interfaces of a device were renumbered
+	interfaces.SetIndexShiftHandler(intervalResults.invalidate)
+}
+
+type collectorResultKey struct {
+	host          string
+	logicalSystem string
+	tenantSystem  string
+	collector     string
+}
+
+type collectorResult struct {
+	metrics   []prometheus.Metric
+	timestamp time.Time
+}
+
+// collectorResults holds the latest results of collectors not run on every scrape (see collector_intervals)
+type collectorResults struct {
+	entries map[collectorResultKey]*collectorResult
+	mu      sync.Mutex
+}
+
+func newCollectorResults() *collectorResults {
+	return &collectorResults{
+		entries: make(map[collectorResultKey]*collectorResult),
+	}
+}
+
+// get returns the result of the collector for the host if it is not older than the interval
+func (r *collectorResults) get(key collectorResultKey, interval time.Duration, now time.Time) ([]prometheus.Metric, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, found := r.entries[key]
+	if !found || now.Sub(e.timestamp) >= interval {
+		return nil, false
+	}
+
+	return e.metrics, true
+}
+
+func (r *collectorResults) set(key collectorResultKey, metrics []prometheus.Metric, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[key] = &collectorResult{
+		metrics:   metrics,
+		timestamp: now,
+	}
+}
+
+// invalidate removes the results of all collectors of the host (including its logical systems and tenant systems), so they are run on the next scrape
+func (r *collectorResults) invalidate(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.entries {
+		if key.host == host {
+			delete(r.entries, key)
+		}
+	}
+
+	log.Infof("%s: SNMP indexes of interfaces changed, results of collectors with interval are invalidated", host)
+}
+
+// recordingChannel forwards all metrics sent to the returned channel to ch and returns them after the channel was closed
+func recordingChannel(ch chan<- prometheus.Metric) (chan<- prometheus.Metric, func() []prometheus.Metric) {
+	rec := make(chan prometheus.Metric)
+	metrics := make([]prometheus.Metric, 0)
+	done := make(chan struct{})
+
+	go func() {
+		for m := range rec {
+			metrics = append(metrics, m)
+			ch <- m
+		}
+		close(done)
+	}()
+
+	return rec, func() []prometheus.Metric {
+		close(rec)
+		<-done
+		return metrics
+	}
+}
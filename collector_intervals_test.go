@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorResults(t *testing.T) {
+	r := newCollectorResults()
+	now := time.Now()
+	key := collectorResultKey{host: "router1", collector: "Environment"}
+	metrics := []prometheus.Metric{prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, "router1")}
+
+	_, found := r.get(key, 5*time.Minute, now)
+	assert.False(t, found, "no result yet")
+
+	r.set(key, metrics, now)
+
+	cached, found := r.get(key, 5*time.Minute, now.Add(time.Minute))
+	assert.True(t, found, "within interval")
+	assert.Equal(t, metrics, cached)
+
+	_, found = r.get(key, 5*time.Minute, now.Add(5*time.Minute))
+	assert.False(t, found, "interval elapsed")
+
+	_, found = r.get(collectorResultKey{host: "router1", logicalSystem: "ls1", collector: "Environment"}, 5*time.Minute, now)
+	assert.False(t, found, "other logical system")
+}
+
+func TestCollectorResultsInvalidate(t *testing.T) {
+	r := newCollectorResults()
+	now := time.Now()
+	metrics := []prometheus.Metric{prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, "router1")}
+
+	r.set(collectorResultKey{host: "router1", collector: "Interface Queues"}, metrics, now)
+	r.set(collectorResultKey{host: "router1", logicalSystem: "ls1", collector: "Interface Queues"}, metrics, now)
+	r.set(collectorResultKey{host: "router2", collector: "Interface Queues"}, metrics, now)
+
+	r.invalidate("router1")
+
+	_, found := r.get(collectorResultKey{host: "router1", collector: "Interface Queues"}, 5*time.Minute, now)
+	assert.False(t, found, "invalidated")
+
+	_, found = r.get(collectorResultKey{host: "router1", logicalSystem: "ls1", collector: "Interface Queues"}, 5*time.Minute, now)
+	assert.False(t, found, "logical system invalidated")
+
+	_, found = r.get(collectorResultKey{host: "router2", collector: "Interface Queues"}, 5*time.Minute, now)
+	assert.True(t, found, "other host kept")
+}
+
+func TestRecordingChannel(t *testing.T) {
+	ch := make(chan prometheus.Metric, 2)
+	rec, metrics := recordingChannel(ch)
+
+	m := prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, "router1")
+	rec <- m
+	rec <- m
+
+	assert.Len(t, metrics(), 2, "recorded")
+	assert.Len(t, ch, 2, "forwarded")
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"time"
+
 	"github.com/czerwonk/junos_exporter/accounting"
 	"github.com/czerwonk/junos_exporter/alarm"
+	"github.com/czerwonk/junos_exporter/alg"
 	"github.com/czerwonk/junos_exporter/bfd"
 	"github.com/czerwonk/junos_exporter/bgp"
 	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/commit"
 	"github.com/czerwonk/junos_exporter/config"
 	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/components"
+	"github.com/czerwonk/junos_exporter/craft"
+	"github.com/czerwonk/junos_exporter/custom"
 	"github.com/czerwonk/junos_exporter/environment"
+	"github.com/czerwonk/junos_exporter/fabric"
 	"github.com/czerwonk/junos_exporter/firewall"
 	"github.com/czerwonk/junos_exporter/fpc"
+	"github.com/czerwonk/junos_exporter/hostresources"
 	"github.com/czerwonk/junos_exporter/interfacediagnostics"
 	"github.com/czerwonk/junos_exporter/interfacelabels"
 	"github.com/czerwonk/junos_exporter/interfacequeue"
@@ -18,31 +27,73 @@ import (
 	"github.com/czerwonk/junos_exporter/ipsec"
 	"github.com/czerwonk/junos_exporter/isis"
 	"github.com/czerwonk/junos_exporter/l2circuit"
+	"github.com/czerwonk/junos_exporter/l2events"
 	"github.com/czerwonk/junos_exporter/lacp"
 	"github.com/czerwonk/junos_exporter/ldp"
+	"github.com/czerwonk/junos_exporter/license"
 	"github.com/czerwonk/junos_exporter/mac"
+	"github.com/czerwonk/junos_exporter/mclag"
 	"github.com/czerwonk/junos_exporter/mpls_lsp"
 	"github.com/czerwonk/junos_exporter/nat"
 	"github.com/czerwonk/junos_exporter/nat2"
 	"github.com/czerwonk/junos_exporter/ospf"
+	"github.com/czerwonk/junos_exporter/policer"
 	"github.com/czerwonk/junos_exporter/power"
 	"github.com/czerwonk/junos_exporter/route"
 	"github.com/czerwonk/junos_exporter/routingengine"
+	"github.com/czerwonk/junos_exporter/rpd"
 	"github.com/czerwonk/junos_exporter/rpki"
 	"github.com/czerwonk/junos_exporter/rpm"
 	"github.com/czerwonk/junos_exporter/security"
+	"github.com/czerwonk/junos_exporter/securityservices"
+	"github.com/czerwonk/junos_exporter/sflow"
+	"github.com/czerwonk/junos_exporter/snmp"
 	"github.com/czerwonk/junos_exporter/storage"
 	"github.com/czerwonk/junos_exporter/system"
+	"github.com/czerwonk/junos_exporter/transceiver"
+	"github.com/czerwonk/junos_exporter/tunnel"
 	"github.com/czerwonk/junos_exporter/virtualchassis"
-	"github.com/czerwonk/junos_exporter/vrrp"
+	"github.com/czerwonk/junos_exporter/vpn"
 	"github.com/czerwonk/junos_exporter/vpws"
+	"github.com/czerwonk/junos_exporter/vrrp"
 )
 
+// featureNames maps collector keys differing from the name used in the features section
+var featureNames = map[string]string{
+	"routingengine":    "routing_engine",
+	"env":              "environment",
+	"hostresources":    "host_resources",
+	"ifacediag":        "interface_diagnostic",
+	"ifacequeue":       "interface_queue",
+	"iface":            "interfaces",
+	"l2c":              "l2circuit",
+	"l2events":         "l2_events",
+	"securityservices": "security_services",
+}
+
+// logicalSystemCollectors are the collectors supporting logical systems
+var logicalSystemCollectors = map[string]bool{
+	"bgp":   true,
+	"ospf":  true,
+	"vpn":   true,
+	"iface": true,
+}
+
+// tenantSystemCollectors are the collectors supporting tenant systems
+var tenantSystemCollectors = map[string]bool{
+	"iface": true,
+}
+
 type collectors struct {
 	logicalSystem string
+	// logicalSystemOnly limits the collectors to those supporting logical systems (see logicalSystemCollectors)
+	logicalSystemOnly bool
+	// tenantSystem limits the collectors to those supporting tenant systems (see tenantSystemCollectors)
+	tenantSystem  string
 	dynamicLabels *interfacelabels.DynamicLabels
 	collectors    map[string]collector.RPCCollector
 	devices       map[string][]collector.RPCCollector
+	intervals     map[collector.RPCCollector]time.Duration
 	cfg           *config.Config
 }
 
@@ -52,6 +103,7 @@ func collectorsForDevices(devices []*connector.Device, cfg *config.Config, logic
 		dynamicLabels: dynamicLabels,
 		collectors:    make(map[string]collector.RPCCollector),
 		devices:       make(map[string][]collector.RPCCollector),
+		intervals:     make(map[collector.RPCCollector]time.Duration),
 		cfg:           cfg,
 	}
 
@@ -62,6 +114,46 @@ func collectorsForDevices(devices []*connector.Device, cfg *config.Config, logic
 	return c
 }
 
+func logicalSystemCollectorsForDevices(devices []*connector.Device, cfg *config.Config, logicalSystem string) *collectors {
+	c := &collectors{
+		logicalSystem:     logicalSystem,
+		logicalSystemOnly: true,
+		dynamicLabels:     interfacelabels.NewDynamicLabels(),
+		collectors:        make(map[string]collector.RPCCollector),
+		devices:           make(map[string][]collector.RPCCollector),
+		intervals:         make(map[collector.RPCCollector]time.Duration),
+		cfg:               cfg,
+	}
+
+	for _, d := range devices {
+		c.initCollectorsForDevices(d)
+	}
+
+	return c
+}
+
+func tenantSystemCollectorsForDevices(devices []*connector.Device, cfg *config.Config, tenantSystem string) *collectors {
+	c := &collectors{
+		tenantSystem:  tenantSystem,
+		dynamicLabels: interfacelabels.NewDynamicLabels(),
+		collectors:    make(map[string]collector.RPCCollector),
+		devices:       make(map[string][]collector.RPCCollector),
+		intervals:     make(map[collector.RPCCollector]time.Duration),
+		cfg:           cfg,
+	}
+
+	for _, d := range devices {
+		c.initCollectorsForDevices(d)
+	}
+
+	return c
+}
+
+// master returns whether the collectors collect the master (neither a logical system nor a tenant system)
+func (c *collectors) master() bool {
+	return c.logicalSystem == "" && c.tenantSystem == ""
+}
+
 func (c *collectors) initCollectorsForDevices(device *connector.Device) {
 	f := c.cfg.FeaturesForDevice(device.Host)
 
@@ -72,48 +164,89 @@ func (c *collectors) initCollectorsForDevices(device *connector.Device) {
 	c.addCollectorIfEnabledForDevice(device, "alarm", f.Alarm, func() collector.RPCCollector {
 		return alarm.NewCollector(*alarmFilter)
 	})
+	c.addCollectorIfEnabledForDevice(device, "alg", f.ALG, alg.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "bfd", f.BFD, bfd.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "bgp", f.BGP, func() collector.RPCCollector {
 		return bgp.NewCollector(c.logicalSystem)
 	})
+	c.addCollectorIfEnabledForDevice(device, "commit", f.Commit, commit.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "components", f.Components, components.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "craft", f.Craft, craft.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "env", f.Environment, environment.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "fabric", f.Fabric, fabric.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "firewall", f.Firewall, firewall.NewCollector)
-	c.addCollectorIfEnabledForDevice(device, "fpc", f.FPC, fpc.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "fpc", f.FPC, func() collector.RPCCollector {
+		return fpc.NewCollector(*fpcPFEStatistics)
+	})
+	c.addCollectorIfEnabledForDevice(device, "hostresources", f.HostResources, hostresources.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "ifacediag", f.InterfaceDiagnostic, func() collector.RPCCollector {
 		return interfacediagnostics.NewCollector(c.dynamicLabels)
 	})
 	c.addCollectorIfEnabledForDevice(device, "ifacequeue", f.InterfaceQueue, func() collector.RPCCollector {
-		return interfacequeue.NewCollector(c.dynamicLabels)
+		return interfacequeue.NewCollector(c.dynamicLabels, *queueBufferOccupancy)
 	})
 	c.addCollectorIfEnabledForDevice(device, "iface", f.Interfaces, func() collector.RPCCollector {
-		return interfaces.NewCollector(c.dynamicLabels)
+		switch {
+		case c.tenantSystem != "":
+			return interfaces.NewSystemCollector(c.dynamicLabels, interfaces.TenantSystemHierarchy(c.tenantSystem))
+		case c.logicalSystemOnly:
+			return interfaces.NewSystemCollector(c.dynamicLabels, interfaces.LogicalSystemHierarchy(c.logicalSystem))
+		default:
+			return interfaces.NewCollector(c.dynamicLabels, *interfaceUtilization, *interfaceTopN)
+		}
 	})
 	c.addCollectorIfEnabledForDevice(device, "ipsec", f.IPSec, ipsec.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "isis", f.ISIS, isis.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "l2c", f.L2Circuit, l2circuit.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "l2events", f.L2Events, l2events.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "lacp", f.LACP, lacp.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "ldp", f.LDP, ldp.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "license", f.License, license.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "nat", f.NAT, nat.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "nat2", f.NAT2, nat2.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "ospf", f.OSPF, func() collector.RPCCollector {
 		return ospf.NewCollector(c.logicalSystem)
 	})
 	c.addCollectorIfEnabledForDevice(device, "routes", f.Routes, route.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "rpd", f.RPD, rpd.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "rpki", f.RPKI, rpki.NewCollector)
-	c.addCollectorIfEnabledForDevice(device, "rpm", f.RPM, rpm.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "rpm", f.RPM, func() collector.RPCCollector {
+		if !*rpmProvisioning {
+			return rpm.NewCollector(nil)
+		}
+
+		return rpm.NewCollector(c.cfg.RPMTestsForDevice)
+	})
 	c.addCollectorIfEnabledForDevice(device, "security", f.Security, security.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "securityservices", f.SecurityServices, securityservices.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "sflow", f.SFlow, sflow.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "snmp", f.SNMP, snmp.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "storage", f.Storage, storage.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "system", f.System, system.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "transceiver", f.Transceiver, transceiver.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "tunnel", f.Tunnel, tunnel.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "power", f.Power, power.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "policer", f.Policer, policer.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "mac", f.MAC, mac.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "mclag", f.MCLAG, mclag.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "virtualchassis", f.VirtualChassis, virtualchassis.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "vrrp", f.VRRP, vrrp.NewCollector)
 	c.addCollectorIfEnabledForDevice(device, "vpws", f.VPWS, vpws.NewCollector)
+	c.addCollectorIfEnabledForDevice(device, "vpn", f.VPN, func() collector.RPCCollector {
+		return vpn.NewCollector(c.logicalSystem)
+	})
 	c.addCollectorIfEnabledForDevice(device, "mpls_lsp", f.MPLS_LSP, mpls_lsp.NewCollector)
+
+	for _, cc := range c.cfg.CustomCollectors {
+		cc := cc
+		c.addCollectorIfEnabledForDevice(device, "custom_"+cc.Name, true, func() collector.RPCCollector {
+			return custom.NewCollector(cc)
+		})
+	}
 }
 
 func (c *collectors) addCollectorIfEnabledForDevice(device *connector.Device, key string, enabled bool, newCollector func() collector.RPCCollector) {
-	if !enabled {
+	if !enabled || (c.logicalSystemOnly && !logicalSystemCollectors[key]) || (c.tenantSystem != "" && !tenantSystemCollectors[key]) {
 		return
 	}
 
@@ -121,11 +254,28 @@ func (c *collectors) addCollectorIfEnabledForDevice(device *connector.Device, ke
 	if !found {
 		col = newCollector()
 		c.collectors[key] = col
+
+		if interval := c.cfg.CollectorInterval(featureName(key)); interval > 0 {
+			c.intervals[col] = interval
+		}
 	}
 
 	c.devices[device.Host] = append(c.devices[device.Host], col)
 }
 
+func featureName(key string) string {
+	if name, found := featureNames[key]; found {
+		return name
+	}
+
+	return key
+}
+
+// interval returns the interval the collector is run in (0 = on every scrape)
+func (c *collectors) interval(col collector.RPCCollector) time.Duration {
+	return c.intervals[col]
+}
+
 func (c *collectors) allEnabledCollectors() []collector.RPCCollector {
 	collectors := make([]collector.RPCCollector, len(c.collectors))
 
@@ -138,6 +288,29 @@ func (c *collectors) allEnabledCollectors() []collector.RPCCollector {
 	return collectors
 }
 
+// restrict removes all collectors not contained in features (names as used in the features section)
+func (c *collectors) restrict(features map[string]bool) {
+	for key, col := range c.collectors {
+		if features[featureName(key)] {
+			continue
+		}
+
+		delete(c.collectors, key)
+		delete(c.intervals, col)
+
+		for host, cols := range c.devices {
+			remaining := make([]collector.RPCCollector, 0, len(cols))
+			for _, cl := range cols {
+				if cl != col {
+					remaining = append(remaining, cl)
+				}
+			}
+
+			c.devices[host] = remaining
+		}
+	}
+}
+
 func (c *collectors) collectorsForDevice(device *connector.Device) []collector.RPCCollector {
 	cols, found := c.devices[device.Host]
 	if !found {
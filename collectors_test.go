@@ -1,6 +1,9 @@
 package main
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +11,7 @@ import (
 	"github.com/czerwonk/junos_exporter/config"
 	"github.com/czerwonk/junos_exporter/connector"
 	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestCollectorsRegistered(t *testing.T) {
@@ -94,3 +98,36 @@ func TestCollectorsForDevices(t *testing.T) {
 	assert.Equal(t, 1, len(cd2), "device 2 collector count")
 	assert.Equal(t, "Interfaces", cd2[0].Name(), "device 2 collector name")
 }
+
+func TestCollectorLabelsReserved(t *testing.T) {
+	c := &config.Config{}
+	f := reflect.ValueOf(&c.Features).Elem()
+	for i := 0; i < f.NumField(); i++ {
+		if f.Field(i).Kind() == reflect.Bool {
+			f.Field(i).SetBool(true)
+		}
+	}
+
+	col := &junosCollector{
+		collectors: collectorsForDevices([]*connector.Device{{Host: "::1"}}, c, "", interfacelabels.NewDynamicLabels()),
+	}
+
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		col.Describe(ch)
+		close(ch)
+	}()
+
+	labels := make(map[string]bool)
+	re := regexp.MustCompile(`variableLabels: \[(.*)\]}$`)
+	for d := range ch {
+		for _, l := range strings.Fields(re.FindStringSubmatch(d.String())[1]) {
+			labels[l] = true
+		}
+	}
+
+	for l := range labels {
+		_, err := config.Load(strings.NewReader("devices:\n  - host: router1\n    labels:\n      " + l + ": x\n"))
+		assert.Error(t, err, "label %s used by a collector must be reserved", l)
+	}
+}
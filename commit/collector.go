@@ -0,0 +1,117 @@
+package commit
+
+import (
+	"log"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_commit_"
+
+var (
+	lastCommitDesc    *prometheus.Desc
+	lastCommitInfo    *prometheus.Desc
+	historyCountDesc  *prometheus.Desc
+	rescuePresentDesc *prometheus.Desc
+	uncommittedDesc   *prometheus.Desc
+)
+
+// compareCandidateRPC returns the differences between the candidate and the active configuration (there is no CLI equivalent in operational mode)
+const compareCandidateRPC = `<get-configuration database="candidate" compare="rollback" rollback="0" format="text"/>`
+
+func init() {
+	l := []string{"target"}
+	lastCommitDesc = prometheus.NewDesc(prefix+"last_timestamp_seconds", "Timestamp of the last commit", l, nil)
+	historyCountDesc = prometheus.NewDesc(prefix+"history_count", "Number of commits in the commit history", l, nil)
+	rescuePresentDesc = prometheus.NewDesc(prefix+"rescue_config_present", "A rescue configuration is saved on the device (1 = present)", l, nil)
+	uncommittedDesc = prometheus.NewDesc(prefix+"uncommitted_changes", "Number of lines added or removed in the candidate configuration but not committed yet (requires NETCONF)", l, nil)
+	lastCommitInfo = prometheus.NewDesc(prefix+"last_info", "Information about the last commit", append(l, "user", "client"), nil)
+}
+
+type commitCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &commitCollector{}
+}
+
+// Name returns the name of the collector
+func (*commitCollector) Name() string {
+	return "Commit"
+}
+
+// Describe describes the metrics
+func (*commitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastCommitDesc
+	ch <- lastCommitInfo
+	ch <- historyCountDesc
+	ch <- rescuePresentDesc
+	ch <- uncommittedDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *commitCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = commitRpc{}
+	err := client.RunCommandAndParse("show system commit", &x)
+	if err != nil {
+		return err
+	}
+
+	h := x.Information.History
+	ch <- prometheus.MustNewConstMetric(historyCountDesc, prometheus.GaugeValue, float64(len(h)), labelValues...)
+
+	if last := lastCommit(h); last != nil {
+		ch <- prometheus.MustNewConstMetric(lastCommitDesc, prometheus.GaugeValue, float64(last.DateTime.Seconds), labelValues...)
+		ch <- prometheus.MustNewConstMetric(lastCommitInfo, prometheus.GaugeValue, 1, append(labelValues, last.User, last.Client)...)
+	}
+
+	var r = rescueRpc{}
+	err = client.RunCommandAndParse("show system configuration rescue", &r)
+	if err != nil {
+		return err
+	}
+
+	rescue := 0
+	if r.Configuration != nil {
+		rescue = 1
+	}
+	ch <- prometheus.MustNewConstMetric(rescuePresentDesc, prometheus.GaugeValue, float64(rescue), labelValues...)
+
+	// NETCONF might not be enabled on the device, the other metrics are exported anyway
+	var d = compareRpc{}
+	err = client.RunNetconfRPCAndParse(compareCandidateRPC, &d)
+	if err != nil {
+		log.Printf("could not compare candidate configuration of %s: %s", client.Device().Host, err)
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(uncommittedDesc, prometheus.GaugeValue, float64(changedLines(d.Information.Output)), labelValues...)
+
+	return nil
+}
+
+// changedLines counts the added and removed lines of the output of a configuration compare
+func changedLines(diff string) int {
+	n := 0
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			n++
+		}
+	}
+
+	return n
+}
+
+func lastCommit(history []commitHistory) *commitHistory {
+	var last *commitHistory
+	for i := range history {
+		if last == nil || history[i].SequenceNumber < last.SequenceNumber {
+			last = &history[i]
+		}
+	}
+
+	return last
+}
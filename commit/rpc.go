@@ -0,0 +1,27 @@
+package commit
+
+type commitRpc struct {
+	Information struct {
+		History []commitHistory `xml:"commit-history"`
+	} `xml:"commit-information"`
+}
+
+type commitHistory struct {
+	SequenceNumber int64  `xml:"sequence-number"`
+	User           string `xml:"user"`
+	Client         string `xml:"client"`
+	DateTime       struct {
+		Seconds int64 `xml:"seconds,attr"`
+	} `xml:"date-time"`
+	Comment string `xml:"comment"`
+}
+
+type rescueRpc struct {
+	Configuration *struct{} `xml:"configuration"`
+}
+
+type compareRpc struct {
+	Information struct {
+		Output string `xml:"configuration-output"`
+	} `xml:"configuration-information"`
+}
@@ -0,0 +1,31 @@
+package commit
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedLines(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R0/junos">
+<configuration-information>
+<configuration-output>
+[edit system]
+-  host-name router1;
++  host-name router2;
+[edit interfaces ge-0/0/0]
++   description "uplink - core";
+</configuration-output>
+</configuration-information>
+</rpc-reply>`
+
+	var x = compareRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 3, changedLines(x.Information.Output))
+	assert.Equal(t, 0, changedLines("\n"), "no uncommitted changes")
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/mibwalk"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var valueDesc *prometheus.Desc
+
+func init() {
+	l := []string{"target", "table", "type", "index", "name"}
+	valueDesc = prometheus.NewDesc("junos_component_value", "Value of a numeric column of jnxOperatingTable or jnxFruTable (type is the column name without table prefix in snake case)", l, nil)
+}
+
+type componentsCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &componentsCollector{}
+}
+
+// Name returns the name of the collector
+func (*componentsCollector) Name() string {
+	return "Components"
+}
+
+// Describe describes the metrics
+func (*componentsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- valueDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *componentsCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	for _, t := range tables {
+		var x = mibwalk.Result{}
+		err := client.RunCommandAndParse("show snmp mib walk "+t.prefix+"Table", &x)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range t.values(x.Information.Objects) {
+			ch <- prometheus.MustNewConstMetric(valueDesc, prometheus.GaugeValue, v.value, append(labelValues, t.name, v.valueType, v.index, v.name)...)
+		}
+	}
+
+	return nil
+}
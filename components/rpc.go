@@ -0,0 +1,83 @@
+package components
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/czerwonk/junos_exporter/mibwalk"
+)
+
+// index is the index shared by the tables of the JUNIPER-MIB chassis contents (jnxContentsContainerIndex.L1.L2.L3)
+var index = mibwalk.Index{
+	{Label: "container", Type: mibwalk.Integer},
+	{Label: "l1", Type: mibwalk.Integer},
+	{Label: "l2", Type: mibwalk.Integer},
+	{Label: "l3", Type: mibwalk.Integer},
+}
+
+// table describes a walked table of the JUNIPER-MIB
+type table struct {
+	name       string
+	prefix     string
+	nameColumn string
+}
+
+var tables = []*table{
+	{name: "operating", prefix: "jnxOperating", nameColumn: "jnxOperatingDescr"},
+	{name: "fru", prefix: "jnxFru", nameColumn: "jnxFruName"},
+}
+
+type value struct {
+	index     string
+	name      string
+	valueType string
+	value     float64
+}
+
+// values returns the numeric columns of all rows except the columns identifying the row
+func (t *table) values(objects []mibwalk.Object) []*value {
+	names := make(map[string]string)
+	for _, r := range index.Rows(objects) {
+		names[r.Index] = r.String(t.nameColumn)
+	}
+
+	values := make([]*value, 0)
+	for i := range objects {
+		o := &objects[i]
+		col, idx := o.Column()
+
+		name, found := names[idx]
+		if !found || o.ValueType != "number" || !strings.HasPrefix(col, t.prefix) || strings.HasSuffix(col, "Index") {
+			continue
+		}
+
+		values = append(values, &value{
+			index:     idx,
+			name:      name,
+			valueType: snakeCase(strings.TrimPrefix(col, t.prefix)),
+			value:     o.Float(),
+		})
+	}
+
+	return values
+}
+
+// snakeCase converts the remainder of a column name (e.g. DRAMSize) to snake case (e.g. dram_size)
+func snakeCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Temp":          "temp",
+		"CPU":           "cpu",
+		"DRAMSize":      "dram_size",
+		"1MinLoadAvg":   "1min_load_avg",
+		"TempThreshold": "temp_threshold",
+		"PowerUpTime":   "power_up_time",
+	}
+
+	for s, expected := range tests {
+		assert.Equal(t, expected, snakeCase(s), s)
+	}
+}
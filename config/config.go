@@ -1,51 +1,188 @@
 package config
 
 import (
+	"crypto/subtle"
 	"io"
 	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
 	"regexp"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
 )
 
+var (
+	envRefRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	// reservedLabels are added by the exporter itself or by its collectors and can not be configured per device
+	// (a device label colliding with a label of a metric would make the metric fail to be gathered)
+	reservedLabels = labelSet(
+		"target", "target_name", "logical_system", "tenant_system", "discovery",
+		"address", "afi", "aggregate", "alg", "alias", "area", "asn", "cable_type", "category", "client",
+		"collector", "color", "counter", "description", "device", "direction", "duplicate_of", "esi",
+		"family", "fan_name", "feature", "fiber_mode", "filter", "flag", "fpc", "fpc_slot", "fpcslot",
+		"group", "hostname", "icl", "id", "index", "interface", "interval", "ip", "item", "lane", "led",
+		"local_interface_address", "lspdst", "lspname", "lspsrc", "mac", "mastership", "memory_type",
+		"mode", "model", "module", "mountpoint", "name", "neighbor", "operation", "os", "os_version",
+		"owner", "page_size", "parent", "part_number", "peer", "pfe", "pic", "pic_slot", "pic_type",
+		"plane", "policer", "port", "protocol", "queue", "queue_number", "rd", "re_name", "reason",
+		"redundancy_group", "relay", "result", "role", "routing_instance", "serial", "sid", "sidorigin",
+		"slot", "slot_id", "source", "state", "status", "table", "type", "unit", "user", "vcid", "vendor",
+		"virtual_ip_address", "vlan", "vpwsinstance", "wavelength", "zone",
+	)
+
+	// targetLabelNames are added to all metrics of a target, so custom collectors can not use them
+	// (discovery is set for targets found by the subnet sweep)
+	targetLabelNames = labelSet("target", "target_name", "logical_system", "tenant_system", "discovery")
+)
+
+func labelSet(names ...string) map[string]bool {
+	s := make(map[string]bool, len(names))
+	for _, n := range names {
+		s[n] = true
+	}
+
+	return s
+}
+
 // Config represents the configuration for the exporter
 type Config struct {
-	Password  string          `yaml:"password"`
-	Targets   []string        `yaml:"targets,omitempty"`
-	Devices   []*DeviceConfig `yaml:"devices,omitempty"`
-	Features  FeatureConfig   `yaml:"features,omitempty"`
-	LSEnabled bool            `yaml:"logical_systems,omitempty"`
-	IfDescReg string          `yaml:"interface_description_regex,omitempty"`
+	Password     string           `yaml:"password"`
+	PasswordFile string           `yaml:"password_file,omitempty"`
+	Passwords    []string         `yaml:"passwords,omitempty"`
+	Targets      []string         `yaml:"targets,omitempty"`
+	Devices      []*DeviceConfig  `yaml:"devices,omitempty"`
+	Defaults     *DeviceConfig    `yaml:"defaults,omitempty"`
+	Features     FeatureConfig    `yaml:"features,omitempty"`
+	Collectors   []string         `yaml:"collectors,omitempty"`
+	LSEnabled    bool             `yaml:"logical_systems,omitempty"`
+	IfDescReg    string           `yaml:"interface_description_regex,omitempty"`
+	Vault        *VaultConfig     `yaml:"vault,omitempty"`
+	Metrics      *MetricFilter    `yaml:"metrics,omitempty"`
+	MetricNames  *MetricNames     `yaml:"metric_names,omitempty"`
+	GNMI         *GNMIConfig      `yaml:"gnmi,omitempty"`
+	Discovery    *DiscoveryConfig `yaml:"discovery,omitempty"`
+
+	SyslogEvents []*SyslogEventConfig `yaml:"syslog_events,omitempty"`
+	// Transformations modify the values of the matching metrics (the first matching transformation is applied)
+	Transformations []*Transformation `yaml:"transformations,omitempty"`
+
+	CustomCollectors []*CustomCollectorConfig `yaml:"custom_collectors,omitempty"`
+	Tenants          []*TenantConfig          `yaml:"tenants,omitempty"`
+	// CollectorIntervals are the intervals collectors of slowly changing data are run in instead of on every scrape (names as used in the features section)
+	CollectorIntervals map[string]time.Duration `yaml:"collector_intervals,omitempty"`
+}
+
+// TenantConfig restricts the targets a tenant (identified by its bearer token) is allowed to scrape
+type TenantConfig struct {
+	Name      string `yaml:"name"`
+	Token     string `yaml:"token,omitempty"`
+	TokenFile string `yaml:"token_file,omitempty"`
+	// Subnets are the prefixes allowed targets specified by IP address have to be in
+	Subnets []string `yaml:"subnets,omitempty"`
+	// Targets are regular expressions matching allowed targets (anchored, they have to match the whole target)
+	Targets []string `yaml:"targets,omitempty"`
+
+	subnets []*net.IPNet
+	targets []*regexp.Regexp
+}
+
+// CustomCollectorConfig defines a collector emitting metrics from the XML output of an arbitrary command
+type CustomCollectorConfig struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`
+	// Items is the path of the repeated element each set of metrics is collected from (e.g. route-engine-information/route-engine)
+	Items string `yaml:"items"`
+	// Labels maps label names to paths relative to the item
+	Labels  map[string]string     `yaml:"labels,omitempty"`
+	Metrics []*CustomMetricConfig `yaml:"metrics"`
+}
+
+// CustomMetricConfig defines a metric of a custom collector
+type CustomMetricConfig struct {
+	Name string `yaml:"name"`
+	Help string `yaml:"help,omitempty"`
+	// Value is the path of the value relative to the item
+	Value string `yaml:"value"`
+	// Type is either gauge (default) or counter
+	Type string `yaml:"type,omitempty"`
+	// Transform converts the value, e.g. mapping string values to numbers
+	Transform *Transformation `yaml:"transform,omitempty"`
+}
+
+// MetricFilter defines which metric families collectors emit (regular expressions matching the metric name)
+type MetricFilter struct {
+	Allow []string `yaml:"allow,omitempty"`
+	Deny  []string `yaml:"deny,omitempty"`
+	allow []*regexp.Regexp
+	deny  []*regexp.Regexp
+}
+
+// VaultConfig is the config for retrieving device credentials from HashiCorp Vault (KV secrets engine v2)
+type VaultConfig struct {
+	Address   string        `yaml:"address"`
+	Token     string        `yaml:"token,omitempty"`
+	TokenFile string        `yaml:"token_file,omitempty"`
+	Mount     string        `yaml:"mount,omitempty"`
+	Path      string        `yaml:"path"`
+	CacheTTL  time.Duration `yaml:"cache_ttl,omitempty"`
 }
 
 // DeviceConfig is the config representation of 1 device
 type DeviceConfig struct {
-	Host          string         `yaml:"host"`
-	Username      string         `yaml:"username,omitempty"`
-	Password      string         `yaml:"password,omitempty"`
-	KeyFile       string         `yaml:"key_file,omitempty"`
-	Features      *FeatureConfig `yaml:"features,omitempty"`
-	IfDescReg     string         `yaml:"interface_description_regex,omitempty"`
-	IsHostPattern bool           `yaml:"host_pattern,omitempty"`
+	Host          string               `yaml:"host"`
+	Username      string               `yaml:"username,omitempty"`
+	Password      string               `yaml:"password,omitempty"`
+	PasswordFile  string               `yaml:"password_file,omitempty"`
+	Passwords     []string             `yaml:"passwords,omitempty"`
+	KeyFile       string               `yaml:"key_file,omitempty"`
+	Features      *FeatureConfig       `yaml:"features,omitempty"`
+	Collectors    []string             `yaml:"collectors,omitempty"`
+	IfDescReg     string               `yaml:"interface_description_regex,omitempty"`
+	VaultPath     string               `yaml:"vault_path,omitempty"`
+	LSNames       []string             `yaml:"logical_systems,omitempty"`
+	TenantSystems []string             `yaml:"tenant_systems,omitempty"`
+	Labels        map[string]string    `yaml:"labels,omitempty"`
+	Metrics       *MetricFilter        `yaml:"metrics,omitempty"`
+	Maintenance   []*MaintenanceWindow `yaml:"maintenance,omitempty"`
+	RateLimit     float64              `yaml:"rate_limit,omitempty"`
+	GNMI          string               `yaml:"gnmi,omitempty"`
+	RPMTests      []*RPMTestConfig     `yaml:"rpm_tests,omitempty"`
+	NextHopProbes []*NextHopProbe      `yaml:"next_hop_probes,omitempty"`
+	IsHostPattern bool                 `yaml:"host_pattern,omitempty"`
 	HostPattern   *regexp.Regexp
 }
 
 // FeatureConfig is the list of collectors enabled or disabled
 type FeatureConfig struct {
 	Alarm               bool `yaml:"alarm,omitempty"`
+	ALG                 bool `yaml:"alg,omitempty"`
 	Environment         bool `yaml:"environment,omitempty"`
 	BFD                 bool `yaml:"bfd,omitempty"`
 	BGP                 bool `yaml:"bgp,omitempty"`
+	Commit              bool `yaml:"commit,omitempty"`
+	Components          bool `yaml:"components,omitempty"`
+	Craft               bool `yaml:"craft,omitempty"`
+	Fabric              bool `yaml:"fabric,omitempty"`
+	MCLAG               bool `yaml:"mclag,omitempty"`
 	OSPF                bool `yaml:"ospf,omitempty"`
 	ISIS                bool `yaml:"isis,omitempty"`
 	NAT                 bool `yaml:"nat,omitempty"`
 	NAT2                bool `yaml:"nat2,omitempty"`
 	L2Circuit           bool `yaml:"l2circuit,omitempty"`
+	L2Events            bool `yaml:"l2_events,omitempty"`
 	LACP                bool `yaml:"lacp,omitempty"`
 	LDP                 bool `yaml:"ldp,omitempty"`
+	License             bool `yaml:"license,omitempty"`
 	Routes              bool `yaml:"routes,omitempty"`
 	RoutingEngine       bool `yaml:"routing_engine,omitempty"`
 	Firewall            bool `yaml:"firewall,omitempty"`
+	HostResources       bool `yaml:"host_resources,omitempty"`
 	Interfaces          bool `yaml:"interfaces,omitempty"`
 	InterfaceDiagnostic bool `yaml:"interface_diagnostic,omitempty"`
 	InterfaceQueue      bool `yaml:"interface_queue,omitempty"`
@@ -53,15 +190,23 @@ type FeatureConfig struct {
 	Accounting          bool `yaml:"accounting,omitempty"`
 	IPSec               bool `yaml:"ipsec,omitempty"`
 	Security            bool `yaml:"security,omitempty"`
+	SecurityServices    bool `yaml:"security_services,omitempty"`
 	FPC                 bool `yaml:"fpc,omitempty"`
 	RPKI                bool `yaml:"rpki,omitempty"`
+	RPD                 bool `yaml:"rpd,omitempty"`
 	RPM                 bool `yaml:"rpm,omitempty"`
 	Satellite           bool `yaml:"satellite,omitempty"`
+	SFlow               bool `yaml:"sflow,omitempty"`
+	SNMP                bool `yaml:"snmp,omitempty"`
 	System              bool `yaml:"system,omitempty"`
+	Transceiver         bool `yaml:"transceiver,omitempty"`
+	Tunnel              bool `yaml:"tunnel,omitempty"`
 	Power               bool `yaml:"power,omitempty"`
+	Policer             bool `yaml:"policer,omitempty"`
 	MAC                 bool `yaml:"mac,omitempty"`
 	MPLS_LSP            bool `yaml:"mpls_lsp,omitempty"`
 	VirtualChassis      bool `yaml:"virtualchassis,omitempty"`
+	VPN                 bool `yaml:"vpn,omitempty"`
 	VPWS                bool `yaml:"vpws,omitempty"`
 	VRRP                bool `yaml:"vrrp,omitempty"`
 }
@@ -69,7 +214,8 @@ type FeatureConfig struct {
 // New creates a new config
 func New() *Config {
 	c := &Config{
-		Targets: make([]string, 0),
+		Targets:      make([]string, 0),
+		SyslogEvents: defaultSyslogEvents(),
 	}
 	setDefaultValues(c)
 
@@ -89,7 +235,125 @@ func Load(reader io.Reader) (*Config, error) {
 		return nil, err
 	}
 
+	err = c.resolveSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.compileMetricFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.compileMaintenanceWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.MetricNames != nil {
+		err = c.MetricNames.init()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid metric names")
+		}
+	}
+
+	if c.GNMI == nil {
+		c.GNMI = &GNMIConfig{}
+	}
+
+	err = c.GNMI.init()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid gnmi config")
+	}
+
+	err = c.initSyslogEvents()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid syslog events")
+	}
+
+	err = c.initTransformations()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid transformation")
+	}
+
+	for _, t := range c.Tenants {
+		err = t.init()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tenant %s", t.Name)
+		}
+	}
+
+	for _, cc := range c.CustomCollectors {
+		err = cc.validate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid custom collector %s", cc.Name)
+		}
+	}
+
+	err = c.validateDiscovery()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid discovery labels")
+	}
+
+	if c.Defaults != nil {
+		err = c.validateLabels(c.Defaults.Labels)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid labels in defaults")
+		}
+
+		err = validateRPMTests(c.Defaults.RPMTests)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RPM tests in defaults")
+		}
+
+		err = validateNextHopProbes(c.Defaults.NextHopProbes)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid next hop probes in defaults")
+		}
+	}
+
+	for _, d := range c.Devices {
+		err = c.ApplyDefaults(d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not apply defaults to device %s", d.Host)
+		}
+	}
+
+	err = c.applyCollectorLists()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.validateCollectorIntervals()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, device := range c.Devices {
+		err = c.validateLabels(device.Labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid labels for device %s", device.Host)
+		}
+
+		if device.RateLimit < 0 {
+			return nil, errors.Errorf("invalid rate limit for device %s: must not be negative", device.Host)
+		}
+
+		err = validateRPMTests(device.RPMTests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid RPM tests for device %s", device.Host)
+		}
+
+		err = validateNextHopProbes(device.NextHopProbes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid next hop probes for device %s", device.Host)
+		}
+
+		err = validateGNMIMode(device.GNMI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid config for device %s", device.Host)
+		}
+
 		if device.IsHostPattern {
 			hostPattern, err := regexp.Compile(device.Host)
 			if err != nil {
@@ -102,6 +366,232 @@ func Load(reader io.Reader) (*Config, error) {
 	return c, nil
 }
 
+func (d *DeviceConfig) resolveSecrets() error {
+	var err error
+
+	d.Username, err = expandEnvRefs(d.Username)
+	if err != nil {
+		return err
+	}
+
+	d.KeyFile, err = expandEnvRefs(d.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	d.Password, err = resolveSecret(d.Password, d.PasswordFile)
+	if err != nil {
+		return err
+	}
+
+	return expandEnvRefsInList(d.Passwords)
+}
+
+// ApplyDefaults sets all values of the device not configured explicitly to the values of the defaults section
+func (c *Config) ApplyDefaults(d *DeviceConfig) error {
+	def := c.Defaults
+	if def == nil {
+		return nil
+	}
+
+	if d.Username == "" {
+		d.Username = def.Username
+	}
+
+	if d.Password == "" && len(d.Passwords) == 0 && d.KeyFile == "" {
+		d.Password = def.Password
+		d.Passwords = def.Passwords
+		d.KeyFile = def.KeyFile
+	}
+
+	if d.IfDescReg == "" {
+		d.IfDescReg = def.IfDescReg
+	}
+
+	if d.VaultPath == "" {
+		d.VaultPath = def.VaultPath
+	}
+
+	if d.Metrics == nil {
+		d.Metrics = def.Metrics
+	}
+
+	if len(d.LSNames) == 0 {
+		d.LSNames = def.LSNames
+	}
+
+	if len(d.TenantSystems) == 0 {
+		d.TenantSystems = def.TenantSystems
+	}
+
+	if len(d.Maintenance) == 0 {
+		d.Maintenance = def.Maintenance
+	}
+
+	if d.RateLimit == 0 {
+		d.RateLimit = def.RateLimit
+	}
+
+	if d.GNMI == "" {
+		d.GNMI = def.GNMI
+	}
+
+	if len(d.RPMTests) == 0 {
+		d.RPMTests = def.RPMTests
+	}
+
+	if len(d.NextHopProbes) == 0 {
+		d.NextHopProbes = def.NextHopProbes
+	}
+
+	if len(def.Labels) > 0 {
+		labels := make(map[string]string)
+		for k, v := range def.Labels {
+			labels[k] = v
+		}
+		for k, v := range d.Labels {
+			labels[k] = v
+		}
+		d.Labels = labels
+	}
+
+	if d.Features == nil && len(d.Collectors) == 0 {
+		d.Features = def.Features
+		d.Collectors = def.Collectors
+
+		if len(def.Collectors) > 0 {
+			f, err := FeaturesFromList(def.Collectors)
+			if err != nil {
+				return errors.Wrap(err, "invalid collector list in defaults")
+			}
+
+			d.Features = f
+		}
+	}
+
+	return nil
+}
+
+// resolveSecrets replaces ${ENV_VAR} references and loads passwords from files
+func (c *Config) resolveSecrets() error {
+	var err error
+
+	c.Password, err = resolveSecret(c.Password, c.PasswordFile)
+	if err != nil {
+		return err
+	}
+
+	err = expandEnvRefsInList(c.Passwords)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range c.Devices {
+		err = d.resolveSecrets()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Defaults != nil {
+		err = c.Defaults.resolveSecrets()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Vault != nil {
+		return c.Vault.resolveSecrets()
+	}
+
+	return nil
+}
+
+func (v *VaultConfig) resolveSecrets() error {
+	var err error
+
+	v.Address, err = expandEnvRefs(v.Address)
+	if err != nil {
+		return err
+	}
+
+	v.Token, err = resolveSecret(v.Token, v.TokenFile)
+	if err != nil {
+		return err
+	}
+
+	if v.Address == "" {
+		v.Address = os.Getenv("VAULT_ADDR")
+	}
+
+	if v.Token == "" {
+		v.Token = os.Getenv("VAULT_TOKEN")
+	}
+
+	if v.Mount == "" {
+		v.Mount = "secret"
+	}
+
+	if v.CacheTTL == 0 {
+		v.CacheTTL = 5 * time.Minute
+	}
+
+	if v.Address == "" || v.Token == "" {
+		return errors.New("vault address and token are required to retrieve credentials from vault")
+	}
+
+	return nil
+}
+
+func resolveSecret(value, file string) (string, error) {
+	if file == "" {
+		return expandEnvRefs(value)
+	}
+
+	path, err := expandEnvRefs(file)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read secret from file %s", path)
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// expandEnvRefs replaces ${ENV_VAR} references (only this notation to not break values containing $)
+func expandEnvRefs(value string) (string, error) {
+	var err error
+
+	res := envRefRe.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRefRe.FindStringSubmatch(ref)[1]
+
+		v, found := os.LookupEnv(name)
+		if !found {
+			err = errors.Errorf("environment variable %s referenced in config is not set", name)
+		}
+
+		return v
+	})
+
+	return res, err
+}
+
+func expandEnvRefsInList(values []string) error {
+	var err error
+
+	for i, v := range values {
+		values[i], err = expandEnvRefs(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func setDefaultValues(c *Config) {
 	c.Password = ""
 	c.LSEnabled = false
@@ -119,22 +609,111 @@ func setDefaultValues(c *Config) {
 	f.LDP = true
 	f.Routes = true
 	f.Firewall = true
+	f.HostResources = false
 	f.RoutingEngine = true
 	f.Security = false
+	f.ALG = false
+	f.SecurityServices = false
 	f.Storage = false
 	f.Accounting = false
 	f.FPC = false
 	f.L2Circuit = false
+	f.L2Events = false
+	f.License = false
 	f.RPKI = false
+	f.RPD = false
 	f.RPM = false
 	f.Satellite = false
+	f.SFlow = false
+	f.SNMP = false
+	f.Transceiver = false
+	f.Tunnel = false
+	f.VPN = false
 	f.Power = false
+	f.Policer = false
 	f.MAC = false
 	f.MPLS_LSP = false
 	f.VirtualChassis = false
 	f.VPWS = false
 	f.VRRP = false
 	f.BFD = false
+	f.Commit = false
+	f.Craft = false
+	f.Fabric = false
+	f.MCLAG = false
+	f.Components = false
+}
+
+// applyCollectorLists replaces the feature sets by the explicitly listed collectors
+func (c *Config) applyCollectorLists() error {
+	if len(c.Collectors) > 0 {
+		f, err := FeaturesFromList(c.Collectors)
+		if err != nil {
+			return err
+		}
+
+		c.Features = *f
+	}
+
+	for _, d := range c.Devices {
+		if len(d.Collectors) == 0 {
+			continue
+		}
+
+		f, err := FeaturesFromList(d.Collectors)
+		if err != nil {
+			return errors.Wrapf(err, "invalid collector list for device %s", d.Host)
+		}
+
+		d.Features = f
+	}
+
+	return nil
+}
+
+func (c *Config) validateCollectorIntervals() error {
+	for name, interval := range c.CollectorIntervals {
+		_, err := FeaturesFromList([]string{name})
+		if err != nil {
+			return errors.Wrap(err, "invalid collector interval")
+		}
+
+		if interval < 0 {
+			return errors.Errorf("invalid interval for collector %s: must not be negative", name)
+		}
+	}
+
+	return nil
+}
+
+// CollectorInterval gets the interval the collector is run in (0 = on every scrape)
+func (c *Config) CollectorInterval(name string) time.Duration {
+	return c.CollectorIntervals[name]
+}
+
+// FeaturesFromList creates a feature set only enabling the given collectors (names as used in the features section)
+func FeaturesFromList(collectors []string) (*FeatureConfig, error) {
+	f := &FeatureConfig{}
+	v := reflect.ValueOf(f).Elem()
+	t := v.Type()
+
+	for _, name := range collectors {
+		found := false
+
+		for i := 0; i < t.NumField(); i++ {
+			if strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0] == name {
+				v.Field(i).SetBool(true)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.Errorf("unknown collector %s", name)
+		}
+	}
+
+	return f, nil
 }
 
 // FeaturesForDevice gets the feature set configured for a device
@@ -148,6 +727,276 @@ func (c *Config) FeaturesForDevice(host string) *FeatureConfig {
 	return &c.Features
 }
 
+func (c *CustomCollectorConfig) validate() error {
+	if c.Name == "" || c.Command == "" || c.Items == "" {
+		return errors.New("name, command and items are required")
+	}
+
+	if len(c.Metrics) == 0 {
+		return errors.New("no metrics defined")
+	}
+
+	for _, m := range c.Metrics {
+		if !model.IsValidMetricName(model.LabelValue(m.Name)) {
+			return errors.Errorf("invalid metric name %s", m.Name)
+		}
+
+		if m.Type != "" && m.Type != "gauge" && m.Type != "counter" {
+			return errors.Errorf("invalid type %s for metric %s", m.Type, m.Name)
+		}
+	}
+
+	for name := range c.Labels {
+		if !model.LabelName(name).IsValid() {
+			return errors.Errorf("invalid label name %s", name)
+		}
+
+		if targetLabelNames[name] {
+			return errors.Errorf("label %s is reserved", name)
+		}
+	}
+
+	return nil
+}
+
+func (t *TenantConfig) init() error {
+	var err error
+
+	t.Token, err = resolveSecret(t.Token, t.TokenFile)
+	if err != nil {
+		return err
+	}
+
+	if t.Token == "" {
+		return errors.New("no token defined")
+	}
+
+	for _, s := range t.Subnets {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return err
+		}
+
+		t.subnets = append(t.subnets, n)
+	}
+
+	// the expressions have to match the whole target, otherwise e.g. router1 would allow scraping xrouter1y
+	anchored := make([]string, len(t.Targets))
+	for i, e := range t.Targets {
+		anchored[i] = "^(?:" + e + ")$"
+	}
+
+	t.targets, err = compileAll(anchored)
+	return err
+}
+
+// TenantForToken returns the tenant the token belongs to (nil if the token is unknown)
+func (c *Config) TenantForToken(token string) *TenantConfig {
+	for _, t := range c.Tenants {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// Allowed returns if the tenant is allowed to scrape the target
+func (t *TenantConfig) Allowed(host string) bool {
+	if matchesAny(t.targets, host) {
+		return true
+	}
+
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		h = host
+	}
+
+	ip := net.ParseIP(strings.Trim(h, "[]"))
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range t.subnets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Config) compileMetricFilters() error {
+	filters := []*MetricFilter{c.Metrics}
+	if c.Defaults != nil {
+		filters = append(filters, c.Defaults.Metrics)
+	}
+	for _, d := range c.Devices {
+		filters = append(filters, d.Metrics)
+	}
+
+	for _, f := range filters {
+		if f == nil {
+			continue
+		}
+
+		err := f.compile()
+		if err != nil {
+			return errors.Wrap(err, "invalid metric filter")
+		}
+	}
+
+	return nil
+}
+
+func (f *MetricFilter) compile() error {
+	var err error
+
+	f.allow, err = compileAll(f.Allow)
+	if err != nil {
+		return err
+	}
+
+	f.deny, err = compileAll(f.Deny)
+	return err
+}
+
+func compileAll(expressions []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(expressions))
+
+	for i, e := range expressions {
+		re, err := regexp.Compile(e)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = re
+	}
+
+	return res, nil
+}
+
+// Allowed returns if a metric should be emitted. If an allow list is defined the name has to match at least one expression of it.
+// Names matching the deny list are never emitted.
+func (f *MetricFilter) Allowed(name string) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.allow) > 0 && !matchesAny(f.allow, name) {
+		return false
+	}
+
+	return !matchesAny(f.deny, name)
+}
+
+func matchesAny(expressions []*regexp.Regexp, name string) bool {
+	for _, re := range expressions {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MetricFilterForDevice gets the metric filter for a device (nil if all metrics are emitted)
+func (c *Config) MetricFilterForDevice(host string) *MetricFilter {
+	d := c.findDeviceConfig(host)
+
+	if d != nil && d.Metrics != nil {
+		return d.Metrics
+	}
+
+	return c.Metrics
+}
+
+// LabelsForDevice gets the labels added to all metrics of a device
+func (c *Config) LabelsForDevice(host string) map[string]string {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.Labels
+	}
+
+	return nil
+}
+
+// HasDeviceLabels returns if labels are configured for any device
+func (c *Config) HasDeviceLabels() bool {
+	for _, d := range c.Devices {
+		if len(d.Labels) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Config) validateLabels(labels map[string]string) error {
+	for name := range labels {
+		if !model.LabelName(name).IsValid() || strings.HasPrefix(name, "__") {
+			return errors.Errorf("invalid label name %s", name)
+		}
+
+		if reservedLabels[name] {
+			return errors.Errorf("label %s is reserved", name)
+		}
+
+		for _, cc := range c.CustomCollectors {
+			if _, found := cc.Labels[name]; found {
+				return errors.Errorf("label %s is used by custom collector %s", name, cc.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RateLimitForDevice gets the max. number of commands per second run on a device (0 if not configured)
+func (c *Config) RateLimitForDevice(host string) float64 {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.RateLimit
+	}
+
+	return 0
+}
+
+// LogicalSystemsForDevice gets the logical systems to collect for a device in addition to the master
+func (c *Config) LogicalSystemsForDevice(host string) []string {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.LSNames
+	}
+
+	return nil
+}
+
+// TenantSystemsForDevice gets the tenant systems to collect for a device in addition to the master
+func (c *Config) TenantSystemsForDevice(host string) []string {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.TenantSystems
+	}
+
+	return nil
+}
+
+// IfDescRegForDevice gets the regex for dynamic interface labels configured for a device (empty if not configured)
+func (c *Config) IfDescRegForDevice(host string) string {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.IfDescReg
+	}
+
+	return ""
+}
+
 func (c *Config) findDeviceConfig(host string) *DeviceConfig {
 	for _, dc := range c.Devices {
 		if dc.HostPattern != nil {
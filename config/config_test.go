@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -208,3 +209,469 @@ func TestFindDeviceConfig(t *testing.T) {
 		t.Fatal("Unexpected device for switch-oob")
 	}
 }
+
+func TestShouldResolveSecrets(t *testing.T) {
+	t.Setenv("JUNOS_EXPORTER_TEST_PASSWORD", "env-secret")
+	t.Setenv("JUNOS_EXPORTER_TEST_USER", "env-user")
+	t.Setenv("JUNOS_EXPORTER_TEST_KEY_DIR", "/etc/keys")
+
+	b, err := ioutil.ReadFile("tests/config6.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "env-secret", c.Password, "Password")
+
+	d1 := c.Devices[0]
+	assert.Equal(t, "env-user", d1.Username, "Device 1: Username")
+	assert.Equal(t, "file-secret", d1.Password, "Device 1: Password")
+
+	d2 := c.Devices[1]
+	assert.Equal(t, "pa$$word", d2.Password, "Device 2: Password")
+	assert.Equal(t, "/etc/keys/id_rsa", d2.KeyFile, "Device 2: Keyfile")
+}
+
+func TestShouldFailOnUndefinedEnvRef(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config7.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(bytes.NewReader(b))
+	assert.EqualError(t, err, "environment variable JUNOS_EXPORTER_TEST_UNDEFINED referenced in config is not set")
+}
+
+func TestShouldApplyCollectorLists(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config8.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, FeatureConfig{Interfaces: true, BGP: true}, *c.FeaturesForDevice("router2"), "global")
+	assert.Equal(t, FeatureConfig{Interfaces: true, InterfaceDiagnostic: true}, *c.FeaturesForDevice("router1"), "router1")
+}
+
+func TestShouldFailOnUnknownCollector(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config9.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(bytes.NewReader(b))
+	assert.EqualError(t, err, "invalid collector list for device router1: unknown collector chassis")
+}
+
+func TestShouldParseLogicalSystemsPerDevice(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config10.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"customer1", "customer2"}, c.LogicalSystemsForDevice("router1"), "router1")
+	assert.Empty(t, c.LogicalSystemsForDevice("router2"), "router2")
+	assert.Empty(t, c.LogicalSystemsForDevice("router3"), "unknown device")
+
+	assert.Equal(t, []string{"tenant1"}, c.TenantSystemsForDevice("srx1"), "srx1")
+	assert.Empty(t, c.TenantSystemsForDevice("router1"), "router1")
+}
+
+func TestShouldParseDeviceLabels(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config11.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, c.HasDeviceLabels())
+	assert.Equal(t, map[string]string{"datacenter": "fra1", "tier": "edge"}, c.LabelsForDevice("router1"), "router1")
+	assert.Empty(t, c.LabelsForDevice("router2"), "router2")
+}
+
+func TestShouldFailOnReservedDeviceLabel(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config12.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(bytes.NewReader(b))
+	assert.EqualError(t, err, "invalid labels for device router1: label target is reserved")
+}
+
+func TestShouldParseDiscoveryLabels(t *testing.T) {
+	c, err := Load(bytes.NewReader([]byte("discovery:\n  labels:\n    platform: sysObjectName\n    mgmt_subnet: subnet\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]string{"platform": "sysObjectName", "mgmt_subnet": "subnet"}, c.DiscoveryLabels())
+	assert.Nil(t, New().DiscoveryLabels(), "not configured")
+}
+
+func TestShouldRejectInvalidDiscoveryLabels(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("discovery:\n  labels:\n    platform: vendor\n")))
+	assert.EqualError(t, err, "invalid discovery labels: unknown metadata vendor for label platform", "unknown metadata")
+
+	_, err = Load(bytes.NewReader([]byte("discovery:\n  labels:\n    discovery: subnet\n")))
+	assert.EqualError(t, err, "invalid discovery labels: label discovery is reserved", "reserved")
+}
+
+func TestShouldApplyDefaults(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config13.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d1 := c.findDeviceConfig("router1")
+	assert.Equal(t, "exporter", d1.Username, "router1 username")
+	assert.Equal(t, "secret", d1.Password, "router1 password")
+	assert.Equal(t, FeatureConfig{Interfaces: true, BGP: true}, *c.FeaturesForDevice("router1"), "router1 features")
+	assert.Equal(t, map[string]string{"datacenter": "fra1"}, c.LabelsForDevice("router1"), "router1 labels")
+
+	d2 := c.findDeviceConfig("router2")
+	assert.Equal(t, "admin", d2.Username, "router2 username")
+	assert.Equal(t, "", d2.Password, "router2 password")
+	assert.Equal(t, "/etc/junos_exporter/router2.key", d2.KeyFile, "router2 key file")
+	assert.Equal(t, FeatureConfig{Interfaces: true}, *c.FeaturesForDevice("router2"), "router2 features")
+	assert.Equal(t, map[string]string{"datacenter": "ams1", "tier": "edge"}, c.LabelsForDevice("router2"), "router2 labels")
+}
+
+func TestShouldFilterMetrics(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config14.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := c.MetricFilterForDevice("router1")
+	assert.True(t, f.Allowed("junos_interface_receive_bytes"), "allowed")
+	assert.False(t, f.Allowed("junos_interface_receive_packets_total"), "not in allow list")
+	assert.False(t, f.Allowed("junos_interface_transmit_errors"), "denied")
+
+	f = c.MetricFilterForDevice("router2")
+	assert.True(t, f.Allowed("junos_interface_receive_packets_total"), "global")
+	assert.False(t, f.Allowed("junos_interface_receive_drops"), "global deny")
+
+	var none *MetricFilter
+	assert.True(t, none.Allowed("junos_interface_receive_drops"), "no filter")
+}
+
+func TestShouldParseCustomCollectors(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config15.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []*CustomCollectorConfig{
+		{
+			Name:    "re",
+			Command: "show chassis routing-engine",
+			Items:   "route-engine-information/route-engine",
+			Labels:  map[string]string{"slot": "slot"},
+			Metrics: []*CustomMetricConfig{
+				{Name: "junos_re_cpu_idle_percent", Help: "CPU idle of the routing engine", Value: "cpu-idle"},
+				{Name: "junos_re_interrupts_total", Value: "interrupts", Type: "counter"},
+			},
+		},
+	}, c.CustomCollectors)
+}
+
+func TestShouldRejectInvalidCustomCollector(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("custom_collectors:\n  - name: re\n    command: show chassis routing-engine\n    items: route-engine\n    metrics:\n      - name: junos-re\n        value: cpu-idle\n")))
+	assert.Error(t, err)
+}
+
+func TestShouldRejectReservedCustomCollectorLabel(t *testing.T) {
+	for _, name := range []string{"target", "target_name", "logical_system", "tenant_system", "discovery"} {
+		_, err := Load(bytes.NewReader([]byte("custom_collectors:\n  - name: re\n    command: show chassis routing-engine\n    items: route-engine\n    labels:\n      " + name + ": slot\n    metrics:\n      - name: junos_re_cpu_idle_percent\n        value: cpu-idle\n")))
+		assert.EqualError(t, err, "invalid custom collector re: label "+name+" is reserved", name)
+	}
+}
+
+func TestShouldRejectDeviceLabelUsedByCustomCollector(t *testing.T) {
+	collectors := "custom_collectors:\n  - name: re\n    command: show chassis routing-engine\n    items: route-engine\n    labels:\n      re_slot: slot\n    metrics:\n      - name: junos_re_cpu_idle_percent\n        value: cpu-idle\n"
+
+	_, err := Load(bytes.NewReader([]byte(collectors + "devices:\n  - host: router1\n    labels:\n      re_slot: \"0\"\n")))
+	assert.EqualError(t, err, "invalid labels for device router1: label re_slot is used by custom collector re", "device")
+
+	_, err = Load(bytes.NewReader([]byte(collectors + "defaults:\n  labels:\n    re_slot: \"0\"\n")))
+	assert.EqualError(t, err, "invalid labels in defaults: label re_slot is used by custom collector re", "defaults")
+}
+
+func TestShouldRestrictTargetsOfTenants(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config16.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JUNOS_EXPORTER_TEST_TOKEN", "secret-b")
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, c.TenantForToken("unknown"), "unknown token")
+	assert.Nil(t, c.TenantForToken(""), "empty token")
+
+	a := c.TenantForToken("secret-a")
+	assert.Equal(t, "team-a", a.Name)
+	assert.True(t, a.Allowed("192.0.2.1"), "IPv4 in subnet")
+	assert.True(t, a.Allowed("192.0.2.1:2222"), "IPv4 with port")
+	assert.True(t, a.Allowed("[2001:db8::1]:22"), "IPv6 with port")
+	assert.False(t, a.Allowed("198.51.100.1"), "IPv4 not in subnet")
+	assert.False(t, a.Allowed("edge1.example.com"), "name")
+
+	tb := c.TenantForToken("secret-b")
+	assert.Equal(t, "team-b", tb.Name)
+	assert.True(t, tb.Allowed("edge1.example.com"), "matching name")
+	assert.False(t, tb.Allowed("core1.example.com"), "other name")
+	assert.False(t, tb.Allowed("xedge1.example.com.evil"), "partial match")
+	assert.False(t, tb.Allowed("192.0.2.1"), "IP")
+}
+
+func TestShouldParseRateLimits(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config19.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0.5, c.RateLimitForDevice("ex2200"), "device specific")
+	assert.Equal(t, float64(5), c.RateLimitForDevice("router1"), "defaults")
+	assert.Equal(t, float64(0), c.RateLimitForDevice("router2"), "unknown device")
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    rate_limit: -1\n")))
+	assert.Error(t, err, "negative rate limit")
+}
+
+func TestShouldParseCollectorIntervals(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config20.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 5*time.Minute, c.CollectorInterval("environment"))
+	assert.Equal(t, time.Hour, c.CollectorInterval("license"))
+	assert.Equal(t, time.Duration(0), c.CollectorInterval("interfaces"), "every scrape")
+
+	_, err = Load(bytes.NewReader([]byte("collector_intervals:\n  foo: 5m\n")))
+	assert.Error(t, err, "unknown collector")
+}
+
+func TestShouldParseGNMIConfig(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config21.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 32767, c.GNMI.Port, "default port")
+	assert.True(t, c.GNMI.TLS)
+	assert.Equal(t, []*GNMISubscription{
+		{Path: "/interfaces/interface/state/counters", Mode: GNMIModeSample, SampleInterval: 30 * time.Second},
+		{Path: "/interfaces/interface/state/oper-status", Mode: GNMIModeOnChange},
+	}, c.GNMI.Subscriptions)
+
+	assert.Equal(t, GNMIEnabled, c.GNMIModeForDevice("router1"))
+	assert.Equal(t, GNMIOnly, c.GNMIModeForDevice("router2"))
+	assert.Equal(t, "", c.GNMIModeForDevice("router3"))
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    gnmi: yes-please\n")))
+	assert.Error(t, err, "invalid mode")
+}
+
+func TestShouldParseSyslogEvents(t *testing.T) {
+	c, err := Load(bytes.NewReader([]byte("devices:\n  - host: router1\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(defaultSyslogEvents()), len(c.SyslogEvents), "defaults")
+
+	b, err := ioutil.ReadFile("tests/config22.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(c.SyslogEvents))
+	assert.Equal(t, "lacp_timeout", c.SyslogEvents[1].Name)
+	assert.True(t, c.SyslogEvents[1].Matches("Mar  3 10:15:01 router1 lacpd[1234]: LACPD_TIMEOUT: ae0: lacp current while timer expired"))
+	assert.False(t, c.SyslogEvents[1].Matches("Mar  3 10:15:01 router1 mib2d[1234]: SNMP_TRAP_LINK_DOWN: ifName xe-0/0/1"))
+
+	_, err = Load(bytes.NewReader([]byte("syslog_events:\n  - name: link-down\n    pattern: LINK_DOWN\n")))
+	assert.Error(t, err, "invalid name")
+
+	_, err = Load(bytes.NewReader([]byte("syslog_events:\n  - name: link_down\n    pattern: \"(\"\n")))
+	assert.Error(t, err, "invalid pattern")
+}
+
+func TestShouldParseTransformations(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config23.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := c.TransformationFor("junos_interface_diagnostics_laser_output_power")
+	if assert.NotNil(t, tr) {
+		v, ok := tr.Apply(1234)
+		assert.True(t, ok)
+		assert.InDelta(t, 1.234, v, 0.0001)
+	}
+	assert.Nil(t, c.TransformationFor("junos_interface_diagnostics_laser_output_power_dbm"), "anchored expression")
+
+	tr = c.TransformationFor("junos_environment_item_temp")
+	if assert.NotNil(t, tr) {
+		v, ok := tr.Apply(100)
+		assert.True(t, ok)
+		assert.Equal(t, 212.0, v)
+	}
+
+	tr = c.CustomCollectors[0].Metrics[0].Transform
+	v, ok := tr.Parse("master")
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, v)
+	_, ok = tr.Parse("unknown")
+	assert.False(t, ok)
+
+	_, err = Load(bytes.NewReader([]byte("transformations:\n  - metric: junos_test\n    conversion: furlongs_to_meters\n")))
+	assert.Error(t, err, "unknown conversion")
+
+	_, err = Load(bytes.NewReader([]byte("transformations:\n  - scale: 2\n")))
+	assert.Error(t, err, "missing metric")
+}
+
+func TestShouldParseRPMTests(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config24.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := c.RPMTestsForDevice("router1")
+	if assert.Len(t, tests, 1, "inherited from defaults") {
+		assert.Equal(t, &RPMTestConfig{
+			Name:          "core1",
+			Target:        "192.0.2.1",
+			ProbeType:     "icmp-ping",
+			ProbeCount:    5,
+			ProbeInterval: time.Second,
+			TestInterval:  time.Minute,
+		}, tests[0])
+	}
+
+	tests = c.RPMTestsForDevice("router2")
+	if assert.Len(t, tests, 1) {
+		assert.Equal(t, "dns", tests[0].Name)
+		assert.Equal(t, 53, tests[0].DestinationPort)
+	}
+
+	assert.Nil(t, c.RPMTestsForDevice("router3"))
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    rpm_tests:\n      - name: core1\n        target: \"192.0.2.1; delete\"\n")))
+	assert.Error(t, err, "injected statement")
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    rpm_tests:\n      - name: core1\n        target: 192.0.2.1\n      - name: core1\n        target: 192.0.2.2\n")))
+	assert.Error(t, err, "duplicate test")
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    rpm_tests:\n      - name: dns\n        target: 192.0.2.53\n        probe_type: udp-ping\n")))
+	assert.Error(t, err, "missing destination port")
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    rpm_tests:\n      - name: core1\n        target: 192.0.2.1\n        probe_interval: 1500ms\n")))
+	assert.Error(t, err, "fractional interval")
+}
+
+func TestShouldParseNextHopProbes(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config25.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []*NextHopProbe{{Address: "192.0.2.1", Module: "icmp"}}, c.NextHopProbesForDevice("router1"), "inherited from defaults")
+	assert.Equal(t, []*NextHopProbe{
+		{Address: "198.51.100.1", Module: "icmp"},
+		{Address: "198.51.100.2", Module: "tcp", Port: 179},
+	}, c.NextHopProbesForDevice("router2"))
+	assert.Nil(t, c.NextHopProbesForDevice("router3"))
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    next_hop_probes:\n      - address: 192.0.2.1\n        module: tcp\n")))
+	assert.Error(t, err, "missing port")
+
+	_, err = Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    next_hop_probes:\n      - address: 192.0.2.1\n        module: http\n")))
+	assert.Error(t, err, "invalid module")
+}
+
+func TestIfDescRegForDevice(t *testing.T) {
+	c, err := Load(bytes.NewReader([]byte("devices:\n  - host: router1\n    interface_description_regex: '\\[(\\w+)\\]'\n  - host: router2\n    interface_description_regex: '\\{(\\w+)\\}'\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `\[(\w+)\]`, c.IfDescRegForDevice("router1"))
+	assert.Equal(t, `\{(\w+)\}`, c.IfDescRegForDevice("router2"))
+	assert.Empty(t, c.IfDescRegForDevice("router3"))
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Metadata of targets found by the subnet sweep which can be mapped to labels
+const (
+	// DiscoverySysObjectID is the numeric sysObjectID of the device (e.g. 1.3.6.1.4.1.2636.1.1.1.2.29)
+	DiscoverySysObjectID = "sysObjectID"
+	// DiscoverySysObjectName is the sysObjectID resolved by the loaded MIBs (e.g. jnxProductNameMX960)
+	DiscoverySysObjectName = "sysObjectName"
+	// DiscoverySubnet is the swept subnet the device was found in
+	DiscoverySubnet = "subnet"
+)
+
+var discoveryMetadata = labelSet(DiscoverySysObjectID, DiscoverySysObjectName, DiscoverySubnet)
+
+// DiscoveryConfig is the config of targets found by the subnet sweep
+type DiscoveryConfig struct {
+	// Labels maps label names to metadata of the discovered targets (sysObjectID, sysObjectName or subnet)
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+func (c *Config) validateDiscovery() error {
+	if c.Discovery == nil {
+		return nil
+	}
+
+	err := c.validateLabels(c.Discovery.Labels)
+	if err != nil {
+		return err
+	}
+
+	for name, key := range c.Discovery.Labels {
+		if !discoveryMetadata[key] {
+			return errors.Errorf("unknown metadata %s for label %s", key, name)
+		}
+	}
+
+	return nil
+}
+
+// DiscoveryLabels returns the labels mapped to metadata of discovered targets
+func (c *Config) DiscoveryLabels() map[string]string {
+	if c.Discovery == nil {
+		return nil
+	}
+
+	return c.Discovery.Labels
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// GNMIEnabled subscribes to gNMI telemetry in addition to the collectors
+	GNMIEnabled = "enabled"
+	// GNMIOnly subscribes to gNMI telemetry without connecting via SSH (devices configured for gNMI only)
+	GNMIOnly = "only"
+
+	// GNMIModeSample samples values in the given interval
+	GNMIModeSample = "sample"
+	// GNMIModeOnChange sends values only if they changed
+	GNMIModeOnChange = "on_change"
+
+	defaultGNMIPort           = 32767
+	defaultGNMISampleInterval = 10 * time.Second
+)
+
+// GNMIConfig defines how devices with gNMI enabled are subscribed to
+type GNMIConfig struct {
+	Port               int                 `yaml:"port,omitempty"`
+	TLS                bool                `yaml:"tls,omitempty"`
+	CAFile             string              `yaml:"ca_file,omitempty"`
+	InsecureSkipVerify bool                `yaml:"insecure_skip_verify,omitempty"`
+	Subscriptions      []*GNMISubscription `yaml:"subscriptions,omitempty"`
+}
+
+// GNMISubscription is a subscription to an OpenConfig path
+type GNMISubscription struct {
+	Path string `yaml:"path"`
+	// Mode is either sample (default) or on_change
+	Mode           string        `yaml:"mode,omitempty"`
+	SampleInterval time.Duration `yaml:"sample_interval,omitempty"`
+}
+
+// defaultGNMISubscriptions cover the OpenConfig interface and platform paths
+func defaultGNMISubscriptions() []*GNMISubscription {
+	return []*GNMISubscription{
+		{Path: "/interfaces/interface/state/counters", Mode: GNMIModeSample, SampleInterval: defaultGNMISampleInterval},
+		{Path: "/interfaces/interface/state/oper-status", Mode: GNMIModeOnChange},
+		{Path: "/interfaces/interface/state/admin-status", Mode: GNMIModeOnChange},
+		{Path: "/components/component/state", Mode: GNMIModeSample, SampleInterval: time.Minute},
+	}
+}
+
+func (g *GNMIConfig) init() error {
+	if g.Port == 0 {
+		g.Port = defaultGNMIPort
+	}
+
+	if len(g.Subscriptions) == 0 {
+		g.Subscriptions = defaultGNMISubscriptions()
+	}
+
+	for _, s := range g.Subscriptions {
+		if s.Path == "" {
+			return errors.New("subscription without path")
+		}
+
+		switch s.Mode {
+		case "":
+			s.Mode = GNMIModeSample
+		case GNMIModeSample, GNMIModeOnChange:
+		default:
+			return errors.Errorf("invalid mode %s for subscription %s", s.Mode, s.Path)
+		}
+
+		if s.Mode == GNMIModeSample && s.SampleInterval == 0 {
+			s.SampleInterval = defaultGNMISampleInterval
+		}
+	}
+
+	return nil
+}
+
+func validateGNMIMode(mode string) error {
+	switch mode {
+	case "", GNMIEnabled, GNMIOnly:
+		return nil
+	default:
+		return errors.Errorf("invalid gnmi mode %s (allowed: %s, %s)", mode, GNMIEnabled, GNMIOnly)
+	}
+}
+
+// GNMIModeForDevice gets the gNMI mode of a device (empty if gNMI is disabled)
+func (c *Config) GNMIModeForDevice(host string) string {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.GNMI
+	}
+
+	return ""
+}
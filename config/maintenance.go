@@ -0,0 +1,205 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaintenanceWindow is a recurring period a device is under maintenance. The window starts at every minute matching
+// the cron expression (minute hour day-of-month month day-of-week) and lasts for the given duration.
+type MaintenanceWindow struct {
+	Schedule string        `yaml:"schedule"`
+	Duration time.Duration `yaml:"duration"`
+	// Scrape keeps scraping the device during the window (only junos_maintenance is set)
+	Scrape bool `yaml:"scrape,omitempty"`
+
+	schedule *cronSchedule
+}
+
+type cronSchedule struct {
+	minutes     []bool
+	hours       []bool
+	daysOfMonth []bool
+	months      []bool
+	daysOfWeek  []bool
+	anyDOM      bool
+	anyDOW      bool
+}
+
+func (w *MaintenanceWindow) compile() error {
+	if w.Duration <= 0 {
+		return errors.Errorf("invalid duration %s for schedule %s", w.Duration, w.Schedule)
+	}
+
+	s, err := parseCronSchedule(w.Schedule)
+	if err != nil {
+		return errors.Wrapf(err, "invalid schedule %s", w.Schedule)
+	}
+
+	w.schedule = s
+	return nil
+}
+
+// Active returns if t is within the window
+func (w *MaintenanceWindow) Active(t time.Time) bool {
+	if w.schedule == nil {
+		return false
+	}
+
+	start := t.Truncate(time.Minute)
+	for offset := time.Duration(0); offset < w.Duration; offset += time.Minute {
+		s := start.Add(-offset)
+		if w.schedule.matches(s) && t.Before(s.Add(w.Duration)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.New("expected 5 fields (minute hour day-of-month month day-of-week)")
+	}
+
+	s := &cronSchedule{
+		anyDOM: fields[2] == "*",
+		anyDOW: fields[4] == "*",
+	}
+
+	var err error
+	ranges := []struct {
+		target   *[]bool
+		min, max int
+	}{
+		{&s.minutes, 0, 59},
+		{&s.hours, 0, 23},
+		{&s.daysOfMonth, 1, 31},
+		{&s.months, 1, 12},
+		{&s.daysOfWeek, 0, 7},
+	}
+
+	for i, r := range ranges {
+		*r.target, err = parseCronField(fields[i], r.min, r.max)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 0 and 7 are both sunday
+	if s.daysOfWeek[7] {
+		s.daysOfWeek[0] = true
+	}
+
+	return s, nil
+}
+
+func parseCronField(field string, min, max int) ([]bool, error) {
+	values := make([]bool, max+1)
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, errors.Errorf("invalid step in %s", part)
+			}
+			part = part[:i]
+		}
+
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+
+			var err error
+			from, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, errors.Errorf("invalid value %s", part)
+			}
+
+			to = from
+			if len(bounds) == 2 {
+				to, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return nil, errors.Errorf("invalid value %s", part)
+				}
+			}
+		}
+
+		if from < min || to > max || from > to {
+			return nil, errors.Errorf("value %s out of range %d-%d", part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+func (s *cronSchedule) matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+
+	dom := s.daysOfMonth[t.Day()]
+	dow := s.daysOfWeek[int(t.Weekday())]
+
+	// like cron a day matches either field if both are restricted
+	if !s.anyDOM && !s.anyDOW {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+func (c *Config) compileMaintenanceWindows() error {
+	devices := c.Devices
+	if c.Defaults != nil {
+		devices = append([]*DeviceConfig{c.Defaults}, devices...)
+	}
+
+	for _, d := range devices {
+		for _, w := range d.Maintenance {
+			err := w.compile()
+			if err != nil {
+				return errors.Wrapf(err, "invalid maintenance window for %s", d.Host)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MaintenanceForDevice gets the maintenance window of the device active at t (nil if the device is not under maintenance)
+func (c *Config) MaintenanceForDevice(host string, t time.Time) *MaintenanceWindow {
+	d := c.findDeviceConfig(host)
+	if d == nil {
+		return nil
+	}
+
+	for _, w := range d.Maintenance {
+		if w.Active(t) {
+			return w
+		}
+	}
+
+	return nil
+}
+
+// HasMaintenanceWindows returns if maintenance windows are configured for any device
+func (c *Config) HasMaintenanceWindows() bool {
+	for _, d := range c.Devices {
+		if len(d.Maintenance) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
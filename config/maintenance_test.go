@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldParseMaintenanceWindows(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config17.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, c.HasMaintenanceWindows())
+
+	// sunday
+	w := c.MaintenanceForDevice("router1", time.Date(2023, 1, 1, 3, 59, 0, 0, time.UTC))
+	assert.NotNil(t, w, "router1 within window")
+	assert.False(t, w.Scrape, "router1 scrape")
+	assert.Nil(t, c.MaintenanceForDevice("router1", time.Date(2023, 1, 1, 4, 0, 0, 0, time.UTC)), "router1 after window")
+	assert.Nil(t, c.MaintenanceForDevice("router1", time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC)), "router1 monday")
+
+	w = c.MaintenanceForDevice("router2", time.Date(2023, 1, 3, 23, 30, 0, 0, time.UTC))
+	assert.NotNil(t, w, "router2 within window")
+	assert.True(t, w.Scrape, "router2 scrape")
+	assert.Nil(t, c.MaintenanceForDevice("router2", time.Date(2023, 1, 1, 3, 0, 0, 0, time.UTC)), "router2 overrides defaults")
+	assert.Nil(t, c.MaintenanceForDevice("router3", time.Date(2023, 1, 1, 3, 0, 0, 0, time.UTC)), "unknown device")
+}
+
+func TestShouldRejectInvalidMaintenanceWindows(t *testing.T) {
+	for _, s := range []string{
+		"devices:\n  - host: router1\n    maintenance:\n      - schedule: \"0 2 * *\"\n        duration: 1h\n",
+		"devices:\n  - host: router1\n    maintenance:\n      - schedule: \"0 24 * * *\"\n        duration: 1h\n",
+		"devices:\n  - host: router1\n    maintenance:\n      - schedule: \"0 2 * * *\"\n",
+	} {
+		_, err := Load(bytes.NewReader([]byte(s)))
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCronSchedule(t *testing.T) {
+	s, err := parseCronSchedule("*/15 8-18 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, s.matches(time.Date(2023, 1, 2, 8, 45, 0, 0, time.UTC)), "monday 08:45")
+	assert.False(t, s.matches(time.Date(2023, 1, 2, 8, 50, 0, 0, time.UTC)), "monday 08:50")
+	assert.False(t, s.matches(time.Date(2023, 1, 2, 19, 0, 0, 0, time.UTC)), "monday 19:00")
+	assert.False(t, s.matches(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)), "sunday 09:00")
+}
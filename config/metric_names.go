@@ -0,0 +1,68 @@
+package config
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultNamespace is the namespace (prefix) of all metrics emitted by the exporter
+const DefaultNamespace = "junos"
+
+var metricNamePartRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// MetricNames overrides the naming scheme of the metrics, e.g. for multi-vendor setups standardized on a different scheme
+type MetricNames struct {
+	// Namespace replaces the junos namespace (e.g. net results in net_bgp_up instead of junos_bgp_up)
+	Namespace string `yaml:"namespace,omitempty"`
+	// Subsystems replaces the subsystem of a module following the namespace (e.g. environment: chassis results in junos_chassis_item_temp)
+	Subsystems map[string]string `yaml:"subsystems,omitempty"`
+
+	subsystems []string
+}
+
+func (n *MetricNames) init() error {
+	if n.Namespace != "" && !metricNamePartRe.MatchString(n.Namespace) {
+		return errors.Errorf("invalid namespace %s", n.Namespace)
+	}
+
+	n.subsystems = make([]string, 0, len(n.Subsystems))
+	for from, to := range n.Subsystems {
+		if !metricNamePartRe.MatchString(from) || !metricNamePartRe.MatchString(to) {
+			return errors.Errorf("invalid subsystem mapping %s: %s", from, to)
+		}
+
+		n.subsystems = append(n.subsystems, from)
+	}
+
+	// the longest subsystem wins if multiple match (e.g. interface_diagnostics and interface)
+	sort.Slice(n.subsystems, func(i, j int) bool {
+		return len(n.subsystems[i]) > len(n.subsystems[j])
+	})
+
+	return nil
+}
+
+// Rename returns the name of a metric according to the naming scheme. Metrics not in the junos namespace are not renamed.
+func (n *MetricNames) Rename(name string) string {
+	if n == nil || !strings.HasPrefix(name, DefaultNamespace+"_") {
+		return name
+	}
+
+	name = strings.TrimPrefix(name, DefaultNamespace+"_")
+	for _, s := range n.subsystems {
+		if name == s || strings.HasPrefix(name, s+"_") {
+			name = n.Subsystems[s] + strings.TrimPrefix(name, s)
+			break
+		}
+	}
+
+	namespace := n.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	return namespace + "_" + name
+}
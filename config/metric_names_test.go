@@ -0,0 +1,52 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldParseMetricNames(t *testing.T) {
+	b, err := ioutil.ReadFile("tests/config18.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"junos_bgp_up":                             "net_bgp_up",
+		"junos_environment_item_temp":              "net_chassis_item_temp",
+		"junos_interface_receive_bytes":            "net_port_receive_bytes",
+		"junos_interface_diagnostics_laser_rx_dbm": "net_optics_laser_rx_dbm",
+		"junos_interfaces_scraped":                 "net_interfaces_scraped",
+		"junos_environment":                        "net_chassis",
+		"custom_metric_without_namespace":          "custom_metric_without_namespace",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, c.MetricNames.Rename(name), name)
+	}
+}
+
+func TestMetricNamesDefaults(t *testing.T) {
+	var n *MetricNames
+	assert.Equal(t, "junos_bgp_up", n.Rename("junos_bgp_up"), "not configured")
+
+	n = &MetricNames{Subsystems: map[string]string{"bgp": "routing_bgp"}}
+	assert.NoError(t, n.init())
+	assert.Equal(t, "junos_routing_bgp_up", n.Rename("junos_bgp_up"), "subsystem only")
+}
+
+func TestShouldNotParseInvalidMetricNames(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("metric_names:\n  namespace: net-ops\n")))
+	assert.Error(t, err)
+
+	_, err = Load(bytes.NewReader([]byte("metric_names:\n  subsystems:\n    bgp: 1bgp\n")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package config
+
+import "github.com/pkg/errors"
+
+// NextHopProbe is a reachability probe sent by the exporter to an address behind the device (e.g. a CE next hop of a PE)
+type NextHopProbe struct {
+	Address string `yaml:"address"`
+	// Module is the probe type: icmp (default) or tcp (connect to Port)
+	Module string `yaml:"module,omitempty"`
+	Port   int    `yaml:"port,omitempty"`
+}
+
+func (p *NextHopProbe) validate() error {
+	if p.Address == "" {
+		return errors.New("missing address of next hop probe")
+	}
+
+	if p.Module == "" {
+		p.Module = "icmp"
+	}
+
+	switch p.Module {
+	case "icmp":
+		if p.Port != 0 {
+			return errors.Errorf("port of next hop probe %s is only supported for module tcp", p.Address)
+		}
+	case "tcp":
+		if p.Port < 1 || p.Port > 65535 {
+			return errors.Errorf("invalid port %d of next hop probe %s", p.Port, p.Address)
+		}
+	default:
+		return errors.Errorf("invalid module %s of next hop probe %s: must be icmp or tcp", p.Module, p.Address)
+	}
+
+	return nil
+}
+
+func validateNextHopProbes(probes []*NextHopProbe) error {
+	seen := make(map[NextHopProbe]bool)
+
+	for _, p := range probes {
+		err := p.validate()
+		if err != nil {
+			return err
+		}
+
+		if seen[*p] {
+			return errors.Errorf("duplicate next hop probe %s (%s)", p.Address, p.Module)
+		}
+		seen[*p] = true
+	}
+
+	return nil
+}
+
+// NextHopProbesForDevice gets the reachability probes exported with the device as target
+func (c *Config) NextHopProbesForDevice(host string) []*NextHopProbe {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.NextHopProbes
+	}
+
+	return nil
+}
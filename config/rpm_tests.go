@@ -0,0 +1,106 @@
+package config
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	rpmNameRe  = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	rpmValueRe = regexp.MustCompile(`^[^\s{};"#]+$`)
+
+	rpmProbeTypes = map[string]bool{
+		"icmp-ping":           true,
+		"icmp-ping-timestamp": true,
+		"tcp-ping":            true,
+		"udp-ping":            true,
+		"udp-ping-timestamp":  true,
+		"http-get":            true,
+	}
+)
+
+// RPMTestConfig is a RPM test provisioned on the device by the exporter (requires -rpm.provisioning)
+type RPMTestConfig struct {
+	Name string `yaml:"name"`
+	// Target is the address probed (URL for http-get)
+	Target          string        `yaml:"target"`
+	ProbeType       string        `yaml:"probe_type,omitempty"`
+	ProbeCount      int           `yaml:"probe_count,omitempty"`
+	ProbeInterval   time.Duration `yaml:"probe_interval,omitempty"`
+	TestInterval    time.Duration `yaml:"test_interval,omitempty"`
+	DestinationPort int           `yaml:"destination_port,omitempty"`
+	SourceAddress   string        `yaml:"source_address,omitempty"`
+	RoutingInstance string        `yaml:"routing_instance,omitempty"`
+}
+
+// validate checks the test for values not accepted by the device and for values which could inject configuration statements
+func (t *RPMTestConfig) validate() error {
+	if !rpmNameRe.MatchString(t.Name) {
+		return errors.Errorf("invalid RPM test name %q", t.Name)
+	}
+
+	if t.ProbeType == "" {
+		t.ProbeType = "icmp-ping"
+	}
+
+	if !rpmProbeTypes[t.ProbeType] {
+		return errors.Errorf("invalid probe type %s of RPM test %s", t.ProbeType, t.Name)
+	}
+
+	for _, v := range []string{t.Target, t.SourceAddress, t.RoutingInstance} {
+		if v != "" && !rpmValueRe.MatchString(v) {
+			return errors.Errorf("invalid value %q in RPM test %s", v, t.Name)
+		}
+	}
+
+	if t.Target == "" {
+		return errors.Errorf("missing target of RPM test %s", t.Name)
+	}
+
+	if t.ProbeCount < 0 || t.ProbeCount > 15 {
+		return errors.Errorf("invalid probe count of RPM test %s: must be between 0 (default) and 15", t.Name)
+	}
+
+	for _, d := range []time.Duration{t.ProbeInterval, t.TestInterval} {
+		if d < 0 || d%time.Second != 0 {
+			return errors.Errorf("invalid interval %v of RPM test %s: must be whole seconds", d, t.Name)
+		}
+	}
+
+	if (t.ProbeType == "tcp-ping" || t.ProbeType == "udp-ping") && t.DestinationPort == 0 {
+		return errors.Errorf("missing destination port of RPM test %s", t.Name)
+	}
+
+	return nil
+}
+
+func validateRPMTests(tests []*RPMTestConfig) error {
+	names := make(map[string]bool)
+
+	for _, t := range tests {
+		err := t.validate()
+		if err != nil {
+			return err
+		}
+
+		if names[t.Name] {
+			return errors.Errorf("duplicate RPM test %s", t.Name)
+		}
+		names[t.Name] = true
+	}
+
+	return nil
+}
+
+// RPMTestsForDevice gets the RPM tests to provision on a device
+func (c *Config) RPMTestsForDevice(host string) []*RPMTestConfig {
+	d := c.findDeviceConfig(host)
+
+	if d != nil {
+		return d.RPMTests
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// SyslogEventConfig defines an event type counted when a syslog message matches the pattern
+type SyslogEventConfig struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// defaultSyslogEvents are used if no events are configured
+func defaultSyslogEvents() []*SyslogEventConfig {
+	events := []*SyslogEventConfig{
+		{Name: "link_down", Pattern: `SNMP_TRAP_LINK_DOWN`},
+		{Name: "link_up", Pattern: `SNMP_TRAP_LINK_UP`},
+		{Name: "fpc_offline", Pattern: `CHASSISD_FRU_OFFLINE_NOTICE.*FPC`},
+		{Name: "fpc_online", Pattern: `CHASSISD_FRU_ONLINE_NOTICE.*FPC`},
+		{Name: "alarm_set", Pattern: `(CHASSISD|ALARMD)_ALARM_SET|Alarm set`},
+		{Name: "alarm_cleared", Pattern: `(CHASSISD|ALARMD)_ALARM_CLEAR|Alarm cleared`},
+		{Name: "bgp_state_change", Pattern: `BGP_(PREFIX_THRESH_EXCEEDED|IO_ERROR|.*STATE_CHANGED)|bgp_.*state.*change`},
+		{Name: "commit", Pattern: `UI_COMMIT(_COMPLETED)?\b`},
+	}
+
+	for _, e := range events {
+		e.pattern = regexp.MustCompile(e.Pattern)
+	}
+
+	return events
+}
+
+func (e *SyslogEventConfig) init() error {
+	if !metricNamePartRe.MatchString(e.Name) {
+		return errors.Errorf("invalid event name %s", e.Name)
+	}
+
+	var err error
+	e.pattern, err = regexp.Compile(e.Pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid pattern for event %s", e.Name)
+	}
+
+	return nil
+}
+
+// Matches returns whether the message matches the pattern of the event
+func (e *SyslogEventConfig) Matches(msg string) bool {
+	return e.pattern.MatchString(msg)
+}
+
+func (c *Config) initSyslogEvents() error {
+	if len(c.SyslogEvents) == 0 {
+		c.SyslogEvents = defaultSyslogEvents()
+	}
+
+	for _, e := range c.SyslogEvents {
+		err := e.init()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
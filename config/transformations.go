@@ -0,0 +1,148 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// conversions are the predefined unit conversions of a transformation (factor applied to the value)
+var conversions = map[string]float64{
+	"bits_to_bytes":    1.0 / 8,
+	"bytes_to_bits":    8,
+	"centi_to_unit":    0.01,
+	"milli_to_unit":    0.001,
+	"micro_to_unit":    0.000001,
+	"kilo_to_unit":     1000,
+	"percent_to_ratio": 0.01,
+}
+
+// Transformation modifies the values of metrics, e.g. to fix the unit of a value reported by a device
+type Transformation struct {
+	// Metric is a regular expression matching the names of the metrics to transform (only used for transformations in the transformations section)
+	Metric string `yaml:"metric,omitempty"`
+	// Enum maps string (or numeric) values to numeric values, e.g. up: 1. Values not mapped are dropped.
+	Enum map[string]float64 `yaml:"enum,omitempty"`
+	// Conversion is a predefined unit conversion (e.g. bits_to_bytes or centi_to_unit)
+	Conversion string `yaml:"conversion,omitempty"`
+	// Scale is the factor the value is multiplied with
+	Scale *float64 `yaml:"scale,omitempty"`
+	// Offset is added to the value after scaling
+	Offset float64 `yaml:"offset,omitempty"`
+
+	metric *regexp.Regexp
+}
+
+func (t *Transformation) init() error {
+	if t.Conversion != "" {
+		if _, found := conversions[t.Conversion]; !found {
+			return errors.Errorf("unknown conversion %s", t.Conversion)
+		}
+	}
+
+	if t.Metric == "" {
+		return nil
+	}
+
+	var err error
+	t.metric, err = regexp.Compile("^(?:" + t.Metric + ")$")
+	if err != nil {
+		return errors.Wrapf(err, "invalid metric expression %s", t.Metric)
+	}
+
+	return nil
+}
+
+// MatchesMetric returns whether the transformation applies to the metric
+func (t *Transformation) MatchesMetric(name string) bool {
+	return t.metric != nil && t.metric.MatchString(name)
+}
+
+// Parse converts a raw value to a number and transforms it. Returns false if the value can not be converted.
+func (t *Transformation) Parse(s string) (float64, bool) {
+	if t != nil && t.Enum != nil {
+		v, found := t.Enum[s]
+		if !found {
+			return 0, false
+		}
+
+		return t.transform(v), true
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return t.Apply(v)
+}
+
+// Apply transforms a numeric value. Returns false if the value is not mapped by the enum mapping.
+func (t *Transformation) Apply(v float64) (float64, bool) {
+	if t == nil {
+		return v, true
+	}
+
+	if t.Enum != nil {
+		mapped, found := t.Enum[strconv.FormatFloat(v, 'f', -1, 64)]
+		if !found {
+			return 0, false
+		}
+
+		v = mapped
+	}
+
+	return t.transform(v), true
+}
+
+func (t *Transformation) transform(v float64) float64 {
+	if t.Conversion != "" {
+		v *= conversions[t.Conversion]
+	}
+
+	if t.Scale != nil {
+		v *= *t.Scale
+	}
+
+	return v + t.Offset
+}
+
+func (c *Config) initTransformations() error {
+	for _, t := range c.Transformations {
+		if t.Metric == "" {
+			return errors.New("metric is required")
+		}
+
+		err := t.init()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, cc := range c.CustomCollectors {
+		for _, m := range cc.Metrics {
+			if m.Transform == nil {
+				continue
+			}
+
+			err := m.Transform.init()
+			if err != nil {
+				return errors.Wrapf(err, "invalid transformation of metric %s", m.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TransformationFor returns the first transformation matching the metric
+func (c *Config) TransformationFor(name string) *Transformation {
+	for _, t := range c.Transformations {
+		if t.MatchesMetric(name) {
+			return t
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"reflect"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	configHashGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "junos_exporter_config_hash",
+		Help: "Hash of the loaded configuration (differs between instances with different configurations)",
+	})
+	configCollectorEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "junos_exporter_config_collector_enabled",
+		Help: "Collector is enabled in the features section of the loaded configuration (1 = enabled, 0 = disabled)",
+	}, []string{"collector"})
+	configCollectorInterval = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "junos_exporter_config_collector_interval_seconds",
+		Help: "Interval a collector is run in as configured in collector_intervals",
+	}, []string{"collector"})
+	configTimeout = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "junos_exporter_config_timeout_seconds",
+		Help: "Configured timeouts of the exporter (0 = no timeout)",
+	}, []string{"timeout"})
+)
+
+func init() {
+	exporterRegistry.MustRegister(configHashGauge, configCollectorEnabled, configCollectorInterval, configTimeout)
+}
+
+// configHash returns a hash of the configuration usable as metric value.
+// It has to be calculated before the devices of the target sources are merged into the config.
+func configHash(c *config.Config) (float64, error) {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return 0, err
+	}
+
+	// the first 6 bytes of the sum fit into the mantissa of a float64 without loss
+	sum := md5.Sum(b)
+	v := make([]byte, 8)
+	copy(v, sum[:6])
+
+	return float64(binary.LittleEndian.Uint64(v)), nil
+}
+
+// updateConfigMetrics exports the hash and the relevant settings of the loaded configuration,
+// so config drift between exporter instances can be detected in Prometheus
+func updateConfigMetrics(c *config.Config, hash float64) {
+	configHashGauge.Set(hash)
+
+	configCollectorEnabled.Reset()
+	v := reflect.ValueOf(c.Features)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		enabled := 0.0
+		if v.Field(i).Bool() {
+			enabled = 1
+		}
+
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		configCollectorEnabled.WithLabelValues(name).Set(enabled)
+	}
+
+	configCollectorInterval.Reset()
+	for name, interval := range c.CollectorIntervals {
+		configCollectorInterval.WithLabelValues(name).Set(interval.Seconds())
+	}
+
+	configTimeout.WithLabelValues("ssh_command").Set(sshCommandTimeout.Seconds())
+	configTimeout.WithLabelValues("ssh_keep_alive").Set(sshKeepAliveTimeout.Seconds())
+	configTimeout.WithLabelValues("ssh_idle").Set(sshIdleTimeout.Seconds())
+	configTimeout.WithLabelValues("push").Set(pushTimeout.Seconds())
+	configTimeout.WithLabelValues("discovery").Set(discoveryTimeout.Seconds())
+	configTimeout.WithLabelValues("icmp").Set(icmpTimeout.Seconds())
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigHash(t *testing.T) {
+	c := config.New()
+	h1, err := configHash(c)
+	assert.NoError(t, err)
+
+	h2, err := configHash(config.New())
+	assert.NoError(t, err)
+	assert.Equal(t, h1, h2, "same config")
+
+	c.Features.BGP = !c.Features.BGP
+	h3, err := configHash(c)
+	assert.NoError(t, err)
+	assert.NotEqual(t, h1, h3, "feature changed")
+	assert.Less(t, h3, float64(1<<48))
+}
+
+func TestUpdateConfigMetrics(t *testing.T) {
+	c := config.New()
+	c.Features.BGP = true
+	c.Features.Craft = false
+	c.CollectorIntervals = map[string]time.Duration{"license": time.Hour}
+
+	updateConfigMetrics(c, 42)
+
+	assert.Equal(t, float64(42), testutil.ToFloat64(configHashGauge))
+	assert.Equal(t, float64(1), testutil.ToFloat64(configCollectorEnabled.WithLabelValues("bgp")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(configCollectorEnabled.WithLabelValues("craft")))
+	assert.Equal(t, float64(3600), testutil.ToFloat64(configCollectorInterval.WithLabelValues("license")))
+}
@@ -2,27 +2,52 @@ package connector
 
 import (
 	"bytes"
+	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"golang.org/x/crypto/ssh"
 )
 
+const (
+	// AbortReasonTimeout is used when a command exceeded the command timeout
+	AbortReasonTimeout = "timeout"
+
+	// AbortReasonOutputSize is used when a command exceeded the max. output size
+	AbortReasonOutputSize = "output_size"
+)
+
 // SSHConnection encapsulates the connection to the device
 type SSHConnection struct {
-	device *Device
-	client *ssh.Client
-	conn   net.Conn
-	mu     sync.Mutex
-	done   chan struct{}
+	device         *Device
+	client         *ssh.Client
+	conn           net.Conn
+	mu             sync.RWMutex
+	done           chan struct{}
+	commandTimeout time.Duration
+	maxOutputSize  int
+	aborts         map[string]uint64
+	abortsMu       sync.Mutex
+	usageMu        sync.Mutex
+	running        int
+	lastUsed       time.Time
 }
 
-// RunCommand runs a command against the device
+// RunCommand runs a command against the device. Commands can run concurrently, each using its own SSH session
 func (c *SSHConnection) RunCommand(cmd string) ([]byte, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.RunCommandContext(context.Background(), cmd)
+}
+
+// RunCommandContext runs a command on the device. The command is aborted when the context is done (e.g. the timeout of an ad-hoc scrape).
+func (c *SSHConnection) RunCommandContext(ctx context.Context, cmd string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	c.startCommand()
+	defer c.finishCommand()
 
 	if c.client == nil {
 		return nil, errors.New("not connected")
@@ -34,10 +59,42 @@ func (c *SSHConnection) RunCommand(cmd string) ([]byte, error) {
 	}
 	defer session.Close()
 
-	var b = &bytes.Buffer{}
+	var b = newLimitedBuffer(c.maxOutputSize)
 	session.Stdout = b
 
-	err = session.Run(cmd)
+	err = session.Start(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not run command")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if c.commandTimeout > 0 {
+		t := time.NewTimer(c.commandTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	select {
+	case err = <-done:
+		if b.isExceeded() {
+			c.abort(AbortReasonOutputSize)
+			return nil, errors.Errorf("command aborted: output exceeded %d bytes", c.maxOutputSize)
+		}
+	case <-b.exceeded:
+		c.abort(AbortReasonOutputSize)
+		return nil, errors.Errorf("command aborted: output exceeded %d bytes", c.maxOutputSize)
+	case <-timeout:
+		c.abort(AbortReasonTimeout)
+		return nil, errors.Errorf("command aborted: no result after %v", c.commandTimeout)
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "command aborted")
+	}
+
 	if err != nil {
 		return nil, errors.Wrap(err, "could not run command")
 	}
@@ -45,6 +102,97 @@ func (c *SSHConnection) RunCommand(cmd string) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+func (c *SSHConnection) abort(reason string) {
+	c.abortsMu.Lock()
+	defer c.abortsMu.Unlock()
+
+	c.aborts[reason]++
+}
+
+// Aborts returns the number of aborted commands by reason
+func (c *SSHConnection) Aborts() map[string]uint64 {
+	c.abortsMu.Lock()
+	defer c.abortsMu.Unlock()
+
+	res := make(map[string]uint64, len(c.aborts))
+	for k, v := range c.aborts {
+		res[k] = v
+	}
+
+	return res
+}
+
+// limitedBuffer is a buffer signaling when more than limit bytes were written (limit <= 0 means no limit)
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	exceeded chan struct{}
+}
+
+func newLimitedBuffer(limit int) *limitedBuffer {
+	return &limitedBuffer{
+		limit:    limit,
+		exceeded: make(chan struct{}),
+	}
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.isExceeded() {
+		return 0, errors.New("output size limit exceeded")
+	}
+
+	if b.limit > 0 && b.Len()+len(p) > b.limit {
+		close(b.exceeded)
+		return 0, errors.New("output size limit exceeded")
+	}
+
+	return b.Buffer.Write(p)
+}
+
+func (b *limitedBuffer) isExceeded() bool {
+	select {
+	case <-b.exceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *SSHConnection) startCommand() {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+
+	c.running++
+}
+
+func (c *SSHConnection) finishCommand() {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+
+	c.running--
+	c.lastUsed = time.Now()
+}
+
+// touch marks the connection as used without running a command
+func (c *SSHConnection) touch() {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+
+	c.lastUsed = time.Now()
+}
+
+// idle returns the duration since the last command finished (0 while commands are running)
+func (c *SSHConnection) idle() time.Duration {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+
+	if c.running > 0 {
+		return 0
+	}
+
+	return time.Since(c.lastUsed)
+}
+
 func (c *SSHConnection) isConnected() bool {
 	return c.conn != nil
 }
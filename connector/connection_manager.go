@@ -39,12 +39,44 @@ func WithKeepAliveTimeout(d time.Duration) Option {
 	}
 }
 
+// WithCommandTimeout sets the maximum duration of a command before it gets aborted (default: no limit)
+func WithCommandTimeout(d time.Duration) Option {
+	return func(m *SSHConnectionManager) {
+		m.commandTimeout = d
+	}
+}
+
+// WithMaxOutputSize sets the maximum number of bytes a command may return before it gets aborted (default: no limit)
+func WithMaxOutputSize(size int) Option {
+	return func(m *SSHConnectionManager) {
+		m.maxOutputSize = size
+	}
+}
+
+// WithDNSCacheTTL sets the duration resolved addresses of a host are reused for new connections (default: resolve on each connection attempt)
+func WithDNSCacheTTL(d time.Duration) Option {
+	return func(m *SSHConnectionManager) {
+		m.resolver.ttl = d
+	}
+}
+
+// WithIdleTimeout sets the duration after which a connection not used by any command is closed (default: never)
+func WithIdleTimeout(d time.Duration) Option {
+	return func(m *SSHConnectionManager) {
+		m.idleTimeout = d
+	}
+}
+
 // SSHConnectionManager manages SSH connections to different devices
 type SSHConnectionManager struct {
 	connections       map[string]*SSHConnection
 	reconnectInterval time.Duration
 	keepAliveInterval time.Duration
 	keepAliveTimeout  time.Duration
+	commandTimeout    time.Duration
+	maxOutputSize     int
+	idleTimeout       time.Duration
+	resolver          *resolver
 	mu                sync.Mutex
 }
 
@@ -55,6 +87,7 @@ func NewConnectionManager(opts ...Option) *SSHConnectionManager {
 		reconnectInterval: 30 * time.Second,
 		keepAliveInterval: 10 * time.Second,
 		keepAliveTimeout:  15 * time.Second,
+		resolver:          newResolver(0),
 	}
 
 	for _, opt := range opts {
@@ -74,6 +107,8 @@ func (m *SSHConnectionManager) Connect(device *Device) (*SSHConnection, error) {
 			return nil, errors.New("not connected")
 		}
 
+		// the connection is about to be used, so it must not expire before the first command is run
+		connection.touch()
 		return connection, nil
 	}
 
@@ -87,10 +122,14 @@ func (m *SSHConnectionManager) connect(device *Device) (*SSHConnection, error) {
 	}
 
 	c := &SSHConnection{
-		conn:   conn,
-		client: client,
-		device: device,
-		done:   make(chan struct{}),
+		conn:           conn,
+		client:         client,
+		device:         device,
+		done:           make(chan struct{}),
+		commandTimeout: m.commandTimeout,
+		maxOutputSize:  m.maxOutputSize,
+		aborts:         make(map[string]uint64),
+		lastUsed:       time.Now(),
 	}
 	go m.keepAlive(c)
 
@@ -109,7 +148,7 @@ func (m *SSHConnectionManager) connectToDevice(device *Device) (*ssh.Client, net
 
 	host := m.tcpAddressForHost(device.Host)
 
-	conn, err := net.DialTimeout("tcp", host, cfg.Timeout)
+	conn, err := m.dial(host, cfg.Timeout)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not open tcp connection")
 	}
@@ -122,6 +161,42 @@ func (m *SSHConnectionManager) connectToDevice(device *Device) (*ssh.Client, net
 	return ssh.NewClient(c, chans, reqs), conn, nil
 }
 
+// dial connects to the first reachable address the host resolves to
+func (m *SSHConnectionManager) dial(host string, timeout time.Duration) (net.Conn, error) {
+	h, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := m.resolver.resolve(h)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		var conn net.Conn
+		conn, err = net.DialTimeout("tcp", net.JoinHostPort(addr, port), timeout)
+		if err == nil {
+			m.resolver.connected(h, addr)
+			return conn, nil
+		}
+
+		log.Debugf("Could not connect to %s (%s): %v", host, addr, err)
+	}
+
+	return nil, err
+}
+
+// Resolution returns the result of the latest name resolution of a host. Hosts specified as IP address are not resolved.
+func (m *SSHConnectionManager) Resolution(host string) (*Resolution, bool) {
+	h, _, err := net.SplitHostPort(m.tcpAddressForHost(host))
+	if err != nil {
+		return nil, false
+	}
+
+	return m.resolver.resolution(h)
+}
+
 func (m *SSHConnectionManager) tcpAddressForHost(host string) string {
 	colonCount := strings.Count(host, ":")
 
@@ -153,6 +228,11 @@ func (m *SSHConnectionManager) keepAlive(connection *SSHConnection) {
 	for {
 		select {
 		case <-time.After(m.keepAliveInterval):
+			if m.idleTimeout > 0 && m.expire(connection) {
+				log.Debugf("Closed idle connection to %s", connection.device)
+				return
+			}
+
 			log.Debugf("Sending keepalive for ")
 			connection.conn.SetDeadline(time.Now().Add(m.keepAliveTimeout))
 			_, _, err := connection.client.SendRequest("keepalive@golang.org", true, nil)
@@ -167,6 +247,24 @@ func (m *SSHConnectionManager) keepAlive(connection *SSHConnection) {
 	}
 }
 
+// expire removes the connection if it has been idle for longer than the idle timeout, so the next scrape of the device
+// establishes a new one. Idleness is checked while holding the lock, so a connection just returned by Connect is kept.
+func (m *SSHConnectionManager) expire(connection *SSHConnection) bool {
+	m.mu.Lock()
+	if connection.idle() <= m.idleTimeout {
+		m.mu.Unlock()
+		return false
+	}
+
+	if m.connections[connection.device.Host] == connection {
+		delete(m.connections, connection.device.Host)
+	}
+	m.mu.Unlock()
+
+	connection.terminate()
+	return true
+}
+
 func (m *SSHConnectionManager) reconnect(connection *SSHConnection) {
 	for {
 		client, conn, err := m.connectToDevice(connection.device)
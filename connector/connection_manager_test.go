@@ -1,7 +1,9 @@
 package connector
 
 import (
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,3 +60,31 @@ func TestTCPAddressForHost(t *testing.T) {
 		})
 	}
 }
+
+func TestExpireKeepsConnectionReturnedByConnect(t *testing.T) {
+	m := NewConnectionManager(WithIdleTimeout(time.Minute))
+
+	conn, peer := net.Pipe()
+	defer peer.Close()
+
+	device := &Device{Host: "router1"}
+	c := &SSHConnection{
+		device:   device,
+		conn:     conn,
+		lastUsed: time.Now().Add(-time.Hour),
+	}
+	m.connections[device.Host] = c
+
+	connection, err := m.Connect(device)
+	assert.NoError(t, err)
+	assert.Same(t, c, connection)
+
+	// the keep alive of the connection detected the idle timeout before Connect returned it
+	assert.False(t, m.expire(c), "connection should not expire")
+	assert.True(t, connection.isConnected(), "connection should still be connected")
+
+	c.lastUsed = time.Now().Add(-time.Hour)
+	assert.True(t, m.expire(c), "connection should expire")
+	assert.False(t, c.isConnected(), "connection should be terminated")
+	assert.Empty(t, m.connections)
+}
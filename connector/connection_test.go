@@ -0,0 +1,31 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitedBuffer(t *testing.T) {
+	b := newLimitedBuffer(10)
+
+	_, err := b.Write([]byte("12345"))
+	assert.NoError(t, err)
+	assert.False(t, b.isExceeded(), "limit should not be exceeded")
+
+	_, err = b.Write([]byte("678901"))
+	assert.Error(t, err)
+	assert.True(t, b.isExceeded(), "limit should be exceeded")
+
+	_, err = b.Write([]byte("1"))
+	assert.Error(t, err, "writes after exceeding the limit should fail")
+	assert.Equal(t, "12345", b.String())
+}
+
+func TestLimitedBufferWithoutLimit(t *testing.T) {
+	b := newLimitedBuffer(0)
+
+	_, err := b.Write(make([]byte, 1<<20))
+	assert.NoError(t, err)
+	assert.False(t, b.isExceeded(), "limit should not be exceeded")
+}
@@ -1,14 +1,18 @@
 package connector
 
 import (
+	"bytes"
 	"io"
+	"sync"
 
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 )
 
 type Device struct {
-	Host string
-	Auth AuthMethod
+	Host             string
+	Auth             AuthMethod
+	PasswordFallback *PasswordFallback
 }
 
 // AuthMethod is the method to use to authenticate agaist the device
@@ -22,6 +26,53 @@ func AuthByPassword(username, password string) AuthMethod {
 	}
 }
 
+// PasswordFallback is a prioritized list of passwords. It remembers the last password which was tried,
+// so the next connection attempt starts with the password that succeeded before.
+type PasswordFallback struct {
+	passwords []string
+	current   int
+	mu        sync.Mutex
+}
+
+// NewPasswordFallback creates a new password fallback list
+func NewPasswordFallback(passwords []string) *PasswordFallback {
+	return &PasswordFallback{passwords: passwords}
+}
+
+// Index returns the index of the password last used
+func (p *PasswordFallback) Index() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.current
+}
+
+func (p *PasswordFallback) password(attempt int) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if attempt > 0 {
+		p.current = (p.current + 1) % len(p.passwords)
+	}
+
+	return p.passwords[p.current]
+}
+
+// AuthByPasswordFallback uses password authentication trying the passwords of the list in order
+func AuthByPasswordFallback(username string, p *PasswordFallback) AuthMethod {
+	return func(cfg *ssh.ClientConfig) {
+		cfg.User = username
+
+		attempt := 0
+		cb := func() (string, error) {
+			pw := p.password(attempt)
+			attempt++
+			return pw, nil
+		}
+		cfg.Auth = append(cfg.Auth, ssh.RetryableAuthMethod(ssh.PasswordCallback(cb), len(p.passwords)))
+	}
+}
+
 // AuthByKey uses public key authentication
 func AuthByKey(username string, key io.Reader) (AuthMethod, error) {
 	pk, err := loadPrivateKey(key)
@@ -35,6 +86,43 @@ func AuthByKey(username string, key io.Reader) (AuthMethod, error) {
 	}, nil
 }
 
+// Credentials are the credentials used to authenticate against a device
+type Credentials struct {
+	Username   string
+	Password   string
+	PrivateKey []byte
+}
+
+// CredentialsProvider retrieves credentials for a device
+type CredentialsProvider func() (*Credentials, error)
+
+// AuthByProvider retrieves the credentials from the provider each time a connection is established.
+// This allows credentials to be rotated without reloading the exporter.
+func AuthByProvider(host string, provider CredentialsProvider) AuthMethod {
+	return func(cfg *ssh.ClientConfig) {
+		creds, err := provider()
+		if err != nil {
+			log.Errorf("Could not retrieve credentials for %s: %v", host, err)
+			return
+		}
+
+		cfg.User = creds.Username
+
+		if len(creds.PrivateKey) > 0 {
+			pk, err := loadPrivateKey(bytes.NewReader(creds.PrivateKey))
+			if err != nil {
+				log.Errorf("Could not load private key for %s: %v", host, err)
+			} else {
+				cfg.Auth = append(cfg.Auth, pk)
+			}
+		}
+
+		if creds.Password != "" {
+			cfg.Auth = append(cfg.Auth, ssh.Password(creds.Password))
+		}
+	}
+}
+
 func (d *Device) String() string {
 	return d.Host
 }
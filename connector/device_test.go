@@ -0,0 +1,21 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordFallback(t *testing.T) {
+	p := NewPasswordFallback([]string{"old", "new", "newer"})
+
+	assert.Equal(t, "old", p.password(0))
+	assert.Equal(t, "new", p.password(1))
+	assert.Equal(t, 1, p.Index(), "index after fallback")
+
+	// next connection starts with the password which succeeded before
+	assert.Equal(t, "new", p.password(0))
+	assert.Equal(t, "newer", p.password(1))
+	assert.Equal(t, "old", p.password(2))
+	assert.Equal(t, 0, p.Index(), "index after wrap around")
+}
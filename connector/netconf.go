@@ -0,0 +1,225 @@
+package connector
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// netconfDelimiter terminates each message of the NETCONF 1.0 framing
+const netconfDelimiter = "]]>]]>"
+
+const netconfHello = `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities><capability>urn:ietf:params:netconf:base:1.0</capability></capabilities></hello>`
+
+// LoadConfiguration loads the configuration (text format) into a private candidate configuration and commits it using NETCONF.
+// Requires the NETCONF service to be enabled on the device (set system services netconf ssh).
+func (c *SSHConnection) LoadConfiguration(config, comment string) error {
+	return c.runNetconf(func(s *netconfSession) error {
+		return s.loadConfiguration(config, comment)
+	})
+}
+
+// RunNetconfRPC sends the RPC using NETCONF and returns the reply (e.g. for RPCs without CLI equivalent in operational mode).
+// Requires the NETCONF service to be enabled on the device (set system services netconf ssh).
+func (c *SSHConnection) RunNetconfRPC(rpc string) ([]byte, error) {
+	var reply []byte
+	err := c.runNetconf(func(s *netconfSession) error {
+		var err error
+		reply, err = s.run(rpc)
+		return err
+	})
+
+	return reply, err
+}
+
+func (c *SSHConnection) runNetconf(f func(s *netconfSession) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	c.startCommand()
+	defer c.finishCommand()
+
+	if c.client == nil {
+		return errors.New("not connected")
+	}
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		return errors.Wrap(err, "could not open session")
+	}
+	defer session.Close()
+
+	w, err := session.StdinPipe()
+	if err != nil {
+		return errors.Wrap(err, "could not open session")
+	}
+
+	r, err := session.StdoutPipe()
+	if err != nil {
+		return errors.Wrap(err, "could not open session")
+	}
+
+	err = session.RequestSubsystem("netconf")
+	if err != nil {
+		return errors.Wrap(err, "could not start NETCONF subsystem")
+	}
+
+	if c.commandTimeout > 0 {
+		t := time.AfterFunc(c.commandTimeout, func() {
+			c.abort(AbortReasonTimeout)
+			session.Close()
+		})
+		defer t.Stop()
+	}
+
+	return f(newNetconfSession(r, w))
+}
+
+// netconfSession exchanges NETCONF messages using the 1.0 framing (messages terminated by ]]>]]>)
+type netconfSession struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func newNetconfSession(r io.Reader, w io.Writer) *netconfSession {
+	return &netconfSession{r: bufio.NewReader(r), w: w}
+}
+
+func (s *netconfSession) hello() error {
+	_, err := s.read()
+	if err != nil {
+		return errors.Wrap(err, "could not read NETCONF hello")
+	}
+
+	return s.write(netconfHello)
+}
+
+func (s *netconfSession) loadConfiguration(config, comment string) error {
+	err := s.hello()
+	if err != nil {
+		return err
+	}
+
+	rpcs := []struct {
+		name string
+		rpc  string
+	}{
+		{name: "open configuration", rpc: "<open-configuration><private/></open-configuration>"},
+		{name: "load configuration", rpc: `<load-configuration action="replace" format="text"><configuration-text>` + escapeXML(config) + "</configuration-text></load-configuration>"},
+		{name: "commit", rpc: "<commit-configuration><log>" + escapeXML(comment) + "</log></commit-configuration>"},
+		{name: "close configuration", rpc: "<close-configuration/>"},
+	}
+
+	for _, r := range rpcs {
+		_, err = s.call(r.rpc)
+		if err != nil {
+			return errors.Wrap(err, "could not "+r.name)
+		}
+	}
+
+	return s.write("<rpc><close-session/></rpc>")
+}
+
+// run sends a single RPC and returns the reply
+func (s *netconfSession) run(rpc string) ([]byte, error) {
+	err := s.hello()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := s.call(rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, s.write("<rpc><close-session/></rpc>")
+}
+
+// call sends a RPC and returns an error if the reply contains errors
+func (s *netconfSession) call(rpc string) ([]byte, error) {
+	err := s.write("<rpc>" + rpc + "</rpc>")
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, netconfError(reply)
+}
+
+func (s *netconfSession) write(msg string) error {
+	_, err := io.WriteString(s.w, msg+netconfDelimiter)
+	return err
+}
+
+func (s *netconfSession) read() ([]byte, error) {
+	var b bytes.Buffer
+
+	for !bytes.HasSuffix(b.Bytes(), []byte(netconfDelimiter)) {
+		chunk, err := s.r.ReadBytes('>')
+		b.Write(chunk)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.TrimSuffix(b.Bytes(), []byte(netconfDelimiter)), nil
+}
+
+// netconfError returns the messages of all rpc-error elements of a reply with severity error (warnings are ignored)
+func netconfError(reply []byte) error {
+	type rpcError struct {
+		Severity string `xml:"error-severity"`
+		Message  string `xml:"error-message"`
+	}
+
+	msgs := make([]string, 0)
+	d := xml.NewDecoder(bytes.NewReader(reply))
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "could not parse NETCONF reply")
+		}
+
+		start, ok := t.(xml.StartElement)
+		if !ok || start.Name.Local != "rpc-error" {
+			continue
+		}
+
+		var e rpcError
+		err = d.DecodeElement(&e, &start)
+		if err != nil {
+			return errors.Wrap(err, "could not parse NETCONF reply")
+		}
+
+		if strings.TrimSpace(e.Severity) == "error" {
+			msgs = append(msgs, strings.TrimSpace(e.Message))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
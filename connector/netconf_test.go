@@ -0,0 +1,92 @@
+package connector
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNetconfServer answers each RPC with the next reply and records the messages received
+func fakeNetconfServer(replies []string) (io.Reader, io.Writer, <-chan []string) {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	received := make(chan []string, 1)
+
+	go func() {
+		defer serverW.Close()
+
+		msgs := make([]string, 0)
+		defer func() { received <- msgs }()
+
+		io.WriteString(serverW, "<hello><capabilities/></hello>"+netconfDelimiter)
+
+		s := newNetconfSession(serverR, nil)
+		for {
+			msg, err := s.read()
+			if err != nil {
+				return
+			}
+			msgs = append(msgs, string(msg))
+
+			if strings.Contains(string(msg), "<close-session/>") {
+				return
+			}
+
+			if len(msgs) > 1 && len(replies) > 0 {
+				io.WriteString(serverW, replies[0]+netconfDelimiter)
+				replies = replies[1:]
+			}
+		}
+	}()
+
+	return clientR, clientW, received
+}
+
+func TestNetconfLoadConfiguration(t *testing.T) {
+	ok := "<rpc-reply><ok/></rpc-reply>"
+	r, w, received := fakeNetconfServer([]string{ok, "<rpc-reply><load-configuration-results><ok/></load-configuration-results></rpc-reply>", ok, ok})
+
+	err := newNetconfSession(r, w).loadConfiguration("services { rpm { replace: probe p1 {} } }", "a & b")
+	assert.NoError(t, err)
+
+	msgs := <-received
+	assert.Len(t, msgs, 6)
+	assert.Contains(t, msgs[0], "<hello")
+	assert.Contains(t, msgs[1], "<open-configuration><private/></open-configuration>")
+	assert.Contains(t, msgs[2], `<load-configuration action="replace" format="text">`)
+	assert.Contains(t, msgs[2], "replace: probe p1")
+	assert.Contains(t, msgs[3], "<log>a &amp; b</log>")
+	assert.Contains(t, msgs[5], "<close-session/>")
+}
+
+func TestNetconfLoadConfigurationError(t *testing.T) {
+	ok := "<rpc-reply><ok/></rpc-reply>"
+	loadErr := `<rpc-reply><load-configuration-results><rpc-error><error-severity>error</error-severity><error-message>syntax error</error-message></rpc-error></load-configuration-results></rpc-reply>`
+	r, w, _ := fakeNetconfServer([]string{ok, loadErr})
+
+	err := newNetconfSession(r, w).loadConfiguration("invalid", "")
+	assert.EqualError(t, err, "could not load configuration: syntax error")
+}
+
+func TestNetconfRun(t *testing.T) {
+	reply := "<rpc-reply><configuration-information><configuration-output>[edit]</configuration-output></configuration-information></rpc-reply>"
+	r, w, received := fakeNetconfServer([]string{reply})
+
+	b, err := newNetconfSession(r, w).run(`<get-configuration database="candidate"/>`)
+	assert.NoError(t, err)
+	assert.Equal(t, reply, string(b))
+
+	msgs := <-received
+	assert.Len(t, msgs, 3)
+	assert.Contains(t, msgs[1], `<rpc><get-configuration database="candidate"/></rpc>`)
+	assert.Contains(t, msgs[2], "<close-session/>")
+}
+
+func TestNetconfError(t *testing.T) {
+	assert.NoError(t, netconfError([]byte("<rpc-reply><ok/></rpc-reply>")))
+
+	warning := `<rpc-reply><rpc-error><error-severity>warning</error-severity><error-message>statement not found</error-message></rpc-error><ok/></rpc-reply>`
+	assert.NoError(t, netconfError([]byte(warning)), "warnings are ignored")
+}
@@ -0,0 +1,148 @@
+package connector
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const resolveTimeout = 5 * time.Second
+
+// Resolution is the result of the latest name resolution of a host
+type Resolution struct {
+	Addresses []string
+	// FailureReason is empty if the latest resolution succeeded (e.g. not_found, timeout, temporary)
+	FailureReason string
+	Time          time.Time
+}
+
+// resolver resolves host names on each connection attempt (or after the cache TTL) instead of relying on caching by the OS.
+// Hosts with multiple addresses are tried in order starting with the address connected to last.
+type resolver struct {
+	ttl       time.Duration
+	entries   map[string]*Resolution
+	preferred map[string]string
+	lookup    func(ctx context.Context, host string) ([]string, error)
+	mu        sync.Mutex
+}
+
+func newResolver(ttl time.Duration) *resolver {
+	return &resolver{
+		ttl:       ttl,
+		entries:   make(map[string]*Resolution),
+		preferred: make(map[string]string),
+		lookup:    net.DefaultResolver.LookupHost,
+	}
+}
+
+// resolve returns the addresses to connect to for a host
+func (r *resolver) resolve(host string) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+
+	r.mu.Lock()
+	e, found := r.entries[host]
+	r.mu.Unlock()
+
+	if !found || e.FailureReason != "" || time.Since(e.Time) >= r.ttl {
+		e = r.update(host, e)
+	}
+
+	if len(e.Addresses) == 0 {
+		return nil, &net.DNSError{Err: e.FailureReason, Name: host}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.ordered(host, e.Addresses), nil
+}
+
+// update looks up the host without holding the lock, so a slow DNS server does not block connections to other hosts
+func (r *resolver) update(host string, previous *Resolution) *Resolution {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	addrs, err := r.lookup(ctx, host)
+	if err == nil {
+		e := &Resolution{Addresses: addrs, Time: time.Now()}
+		r.store(host, e)
+		return e
+	}
+
+	e := &Resolution{FailureReason: failureReason(err), Time: time.Now()}
+	if previous != nil {
+		// keep using the addresses resolved before so a DNS outage does not affect established management access
+		log.Warnf("Could not resolve %s (%v), using previously resolved addresses", host, err)
+		e.Addresses = previous.Addresses
+	}
+
+	r.store(host, e)
+	return e
+}
+
+func (r *resolver) store(host string, e *Resolution) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[host] = e
+}
+
+func (r *resolver) ordered(host string, addrs []string) []string {
+	p, found := r.preferred[host]
+	if !found {
+		return addrs
+	}
+
+	ordered := make([]string, 0, len(addrs))
+	ordered = append(ordered, p)
+	for _, a := range addrs {
+		if a != p {
+			ordered = append(ordered, a)
+		}
+	}
+
+	if len(ordered) > len(addrs) {
+		// preferred address is not resolved anymore
+		return addrs
+	}
+
+	return ordered
+}
+
+func (r *resolver) connected(host, addr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferred[host] = addr
+}
+
+func (r *resolver) resolution(host string) (*Resolution, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, found := r.entries[host]
+	return e, found
+}
+
+func failureReason(err error) string {
+	dnsErr, ok := err.(*net.DNSError)
+	if !ok {
+		return "error"
+	}
+
+	switch {
+	case dnsErr.IsNotFound:
+		return "not_found"
+	case dnsErr.IsTimeout:
+		return "timeout"
+	case dnsErr.IsTemporary:
+		return "temporary"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,99 @@
+package connector
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolverFallsBackToPreviousAddresses(t *testing.T) {
+	r := newResolver(0)
+
+	var err error
+	r.lookup = func(ctx context.Context, host string) ([]string, error) {
+		return []string{"192.0.2.1", "192.0.2.2"}, err
+	}
+
+	addrs, resolveErr := r.resolve("router1")
+	assert.NoError(t, resolveErr)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, addrs)
+
+	err = &net.DNSError{Err: "no such host", Name: "router1", IsNotFound: true}
+	addrs, resolveErr = r.resolve("router1")
+	assert.NoError(t, resolveErr)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, addrs)
+
+	res, found := r.resolution("router1")
+	assert.True(t, found)
+	assert.Equal(t, "not_found", res.FailureReason)
+}
+
+func TestResolverFailsWithoutAddresses(t *testing.T) {
+	r := newResolver(0)
+	r.lookup = func(ctx context.Context, host string) ([]string, error) {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+	}
+
+	_, err := r.resolve("router1")
+	assert.Error(t, err)
+
+	res, _ := r.resolution("router1")
+	assert.Equal(t, "timeout", res.FailureReason)
+}
+
+func TestResolverPrefersConnectedAddress(t *testing.T) {
+	r := newResolver(time.Minute)
+	r.lookup = func(ctx context.Context, host string) ([]string, error) {
+		return []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, nil
+	}
+
+	_, err := r.resolve("router1")
+	assert.NoError(t, err)
+
+	r.connected("router1", "192.0.2.2")
+	addrs, err := r.resolve("router1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.1", "192.0.2.3"}, addrs)
+}
+
+func TestResolverDoesNotResolveIPs(t *testing.T) {
+	r := newResolver(0)
+
+	addrs, err := r.resolve("2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2001:db8::1"}, addrs)
+
+	_, found := r.resolution("2001:db8::1")
+	assert.False(t, found)
+}
+
+func TestResolverDoesNotBlockOtherHosts(t *testing.T) {
+	r := newResolver(time.Minute)
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+	r.lookup = func(ctx context.Context, host string) ([]string, error) {
+		if host == "slow" {
+			<-blocked
+		}
+		return []string{"192.0.2.1"}, nil
+	}
+
+	go r.resolve("slow")
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		r.resolve("router1")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("resolving router1 was blocked by the lookup of another host")
+	}
+}
@@ -0,0 +1,67 @@
+package craft
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_craft_"
+
+var (
+	ledOnDesc        *prometheus.Desc
+	alarmRelayOnDesc *prometheus.Desc
+)
+
+func init() {
+	ledOnDesc = prometheus.NewDesc(prefix+"led_on", "LED on the front panel is lit (1 = on, 0 = off)", []string{"target", "led", "color"}, nil)
+	alarmRelayOnDesc = prometheus.NewDesc(prefix+"alarm_relay_on", "Alarm relay of the craft interface is active (1 = on, 0 = off)", []string{"target", "relay"}, nil)
+}
+
+type craftCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &craftCollector{}
+}
+
+// Name returns the name of the collector
+func (*craftCollector) Name() string {
+	return "Craft Interface"
+}
+
+// Describe describes the metrics
+func (*craftCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ledOnDesc
+	ch <- alarmRelayOnDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *craftCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = craftInterfaceRpc{}
+	err := client.RunCommandAndParse("show chassis craft-interface", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range x.Information.LEDs {
+		ch <- prometheus.MustNewConstMetric(ledOnDesc, prometheus.GaugeValue, stateToFloat(l.State), append(labelValues, l.Name, strings.ToLower(l.Color))...)
+	}
+
+	for _, r := range x.Information.Relays {
+		ch <- prometheus.MustNewConstMetric(alarmRelayOnDesc, prometheus.GaugeValue, stateToFloat(r.State), append(labelValues, r.Name)...)
+	}
+
+	return nil
+}
+
+func stateToFloat(state string) float64 {
+	if strings.EqualFold(strings.TrimSpace(state), "on") {
+		return 1
+	}
+
+	return 0
+}
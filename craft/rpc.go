@@ -0,0 +1,19 @@
+package craft
+
+type craftInterfaceRpc struct {
+	Information struct {
+		LEDs   []led        `xml:"front-panel-led-information>front-panel-led"`
+		Relays []alarmRelay `xml:"alarm-relay-information>alarm-relay"`
+	} `xml:"craft-information"`
+}
+
+type led struct {
+	Name  string `xml:"led-name"`
+	Color string `xml:"led-color"`
+	State string `xml:"led-state"`
+}
+
+type alarmRelay struct {
+	Name  string `xml:"relay-name"`
+	State string `xml:"relay-state"`
+}
@@ -0,0 +1,47 @@
+package craft
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCraftInterface(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <craft-information>
+        <front-panel-led-information>
+            <front-panel-led>
+                <led-name>Alarm</led-name>
+                <led-color>Red</led-color>
+                <led-state>On</led-state>
+            </front-panel-led>
+            <front-panel-led>
+                <led-name>Alarm</led-name>
+                <led-color>Yellow</led-color>
+                <led-state>Off</led-state>
+            </front-panel-led>
+        </front-panel-led-information>
+        <alarm-relay-information>
+            <alarm-relay>
+                <relay-name>Major</relay-name>
+                <relay-state>On</relay-state>
+            </alarm-relay>
+        </alarm-relay-information>
+    </craft-information>
+</rpc-reply>`
+
+	x := craftInterfaceRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.Information.LEDs, 2)
+	assert.Equal(t, led{Name: "Alarm", Color: "Red", State: "On"}, x.Information.LEDs[0])
+	assert.Equal(t, float64(1), stateToFloat(x.Information.LEDs[0].State))
+	assert.Equal(t, float64(0), stateToFloat(x.Information.LEDs[1].State))
+
+	assert.Len(t, x.Information.Relays, 1)
+	assert.Equal(t, "Major", x.Information.Relays[0].Name)
+}
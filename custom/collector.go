@@ -0,0 +1,102 @@
+package custom
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metric struct {
+	cfg       *config.CustomMetricConfig
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+type customCollector struct {
+	cfg        *config.CustomCollectorConfig
+	labelNames []string
+	labelPaths []string
+	metrics    []*metric
+}
+
+// NewCollector creates a new collector for the given definition
+func NewCollector(cfg *config.CustomCollectorConfig) collector.RPCCollector {
+	c := &customCollector{cfg: cfg}
+
+	l := []string{"target"}
+	for name, path := range cfg.Labels {
+		c.labelNames = append(c.labelNames, name)
+		c.labelPaths = append(c.labelPaths, path)
+		l = append(l, name)
+	}
+
+	for _, m := range cfg.Metrics {
+		help := m.Help
+		if help == "" {
+			help = "Value of " + m.Value + " (" + cfg.Command + ")"
+		}
+
+		t := prometheus.GaugeValue
+		if m.Type == "counter" {
+			t = prometheus.CounterValue
+		}
+
+		c.metrics = append(c.metrics, &metric{
+			cfg:       m,
+			desc:      prometheus.NewDesc(m.Name, help, l, nil),
+			valueType: t,
+		})
+	}
+
+	return c
+}
+
+// Name returns the name of the collector
+func (c *customCollector) Name() string {
+	return "Custom (" + c.cfg.Name + ")"
+}
+
+// Describe describes the metrics
+func (c *customCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics {
+		ch <- m.desc
+	}
+}
+
+// Collect collects metrics from JunOS
+func (c *customCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x node
+	err := client.RunCommandAndParse(c.cfg.Command, &x)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range x.find(c.cfg.Items) {
+		c.collectForItem(item, ch, labelValues)
+	}
+
+	return nil
+}
+
+func (c *customCollector) collectForItem(item *node, ch chan<- prometheus.Metric, labelValues []string) {
+	l := append([]string{}, labelValues...)
+	for _, path := range c.labelPaths {
+		v, _ := item.value(path)
+		l = append(l, v)
+	}
+
+	for _, m := range c.metrics {
+		s, found := item.value(m.cfg.Value)
+		if !found {
+			continue
+		}
+
+		v, ok := m.cfg.Transform.Parse(s)
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(m.desc, m.valueType, v, l...)
+	}
+}
@@ -0,0 +1,94 @@
+package custom
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+const routingEngineXML = `<rpc-reply>
+<route-engine-information>
+<route-engine>
+<slot>0</slot>
+<mastership-state>master</mastership-state>
+<memory-buffer-utilization>21</memory-buffer-utilization>
+<cpu-idle>97</cpu-idle>
+</route-engine>
+<route-engine>
+<slot>1</slot>
+<mastership-state>backup</mastership-state>
+<memory-buffer-utilization>18</memory-buffer-utilization>
+<cpu-idle>99</cpu-idle>
+</route-engine>
+</route-engine-information>
+</rpc-reply>`
+
+func TestFind(t *testing.T) {
+	var x node
+	err := xml.Unmarshal([]byte(routingEngineXML), &x)
+	assert.NoError(t, err)
+
+	items := x.find("route-engine-information/route-engine")
+	assert.Len(t, items, 2)
+
+	v, found := items[1].value("mastership-state")
+	assert.True(t, found)
+	assert.Equal(t, "backup", v)
+
+	_, found = items[1].value("temperature")
+	assert.False(t, found)
+}
+
+func TestGenerate(t *testing.T) {
+	cfg, err := Generate("re", "show chassis routing-engine", strings.NewReader(routingEngineXML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, &config.CustomCollectorConfig{
+		Name:    "re",
+		Command: "show chassis routing-engine",
+		Items:   "route-engine-information/route-engine",
+		Labels:  map[string]string{"mastership_state": "mastership-state"},
+		Metrics: []*config.CustomMetricConfig{
+			{Name: "junos_re_slot", Value: "slot"},
+			{Name: "junos_re_memory_buffer_utilization", Value: "memory-buffer-utilization"},
+			{Name: "junos_re_cpu_idle", Value: "cpu-idle"},
+		},
+	}, cfg)
+}
+
+func FuzzCollect(f *testing.F) {
+	f.Add([]byte(routingEngineXML))
+	f.Add([]byte(`<rpc-reply><route-engine-information><route-engine><slot>x</slot></route-engine></route-engine-information></rpc-reply>`))
+	f.Add([]byte(`<rpc-reply><route-engine-information><route-engine>`))
+
+	c := NewCollector(&config.CustomCollectorConfig{
+		Name:    "re",
+		Command: "show chassis routing-engine",
+		Items:   "route-engine-information/route-engine",
+		Labels:  map[string]string{"state": "mastership-state"},
+		Metrics: []*config.CustomMetricConfig{
+			{Name: "junos_re_cpu_idle", Value: "cpu-idle"},
+			{Name: "junos_re_slot", Value: "slot"},
+		},
+	}).(*customCollector)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var x node
+		if xml.Unmarshal(b, &x) != nil {
+			return
+		}
+
+		ch := make(chan prometheus.Metric, 1024)
+		for _, item := range x.find(c.cfg.Items) {
+			if len(ch) > 512 {
+				break
+			}
+
+			c.collectForItem(item, ch, []string{"router1"})
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package custom
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/pkg/errors"
+)
+
+// Generate creates a collector definition from the XML output of a command (e.g. saved from "show chassis routing-engine | display xml").
+// The first element having numeric child elements is used as item. Its numeric children become metrics, all other text children become labels.
+func Generate(name, command string, r io.Reader) (*config.CustomCollectorConfig, error) {
+	var root node
+	err := xml.NewDecoder(r).Decode(&root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse XML")
+	}
+
+	path, item := findItem(&root, nil)
+	if item == nil {
+		return nil, errors.New("no element with numeric values found")
+	}
+
+	cfg := &config.CustomCollectorConfig{
+		Name:    name,
+		Command: command,
+		Items:   strings.Join(path, "/"),
+		Labels:  make(map[string]string),
+	}
+
+	seen := make(map[string]bool)
+	for _, leaf := range item.Nodes {
+		n := leaf.XMLName.Local
+		if len(leaf.Nodes) > 0 || leaf.text() == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+
+		if !isNumeric(leaf.text()) {
+			cfg.Labels[sanitize(n)] = n
+			continue
+		}
+
+		cfg.Metrics = append(cfg.Metrics, &config.CustomMetricConfig{
+			Name:  "junos_" + sanitize(name) + "_" + sanitize(n),
+			Value: n,
+		})
+	}
+
+	return cfg, nil
+}
+
+func findItem(n *node, path []string) ([]string, *node) {
+	for _, c := range n.Nodes {
+		if len(c.Nodes) == 0 && isNumeric(c.text()) {
+			return path, n
+		}
+	}
+
+	for _, c := range n.Nodes {
+		p, item := findItem(c, append(append([]string{}, path...), c.XMLName.Local))
+		if item != nil {
+			return p, item
+		}
+	}
+
+	return nil, nil
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+func sanitize(s string) string {
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(s)
+}
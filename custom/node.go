@@ -0,0 +1,47 @@
+package custom
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// node is an element of a generically parsed XML document
+type node struct {
+	XMLName xml.Name
+	Content string  `xml:",chardata"`
+	Nodes   []*node `xml:",any"`
+}
+
+func (n *node) text() string {
+	return strings.TrimSpace(n.Content)
+}
+
+// find returns all elements matching the path (element names separated by /) relative to the node
+func (n *node) find(path string) []*node {
+	nodes := []*node{n}
+
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		next := make([]*node, 0)
+		for _, c := range nodes {
+			for _, child := range c.Nodes {
+				if child.XMLName.Local == name {
+					next = append(next, child)
+				}
+			}
+		}
+
+		nodes = next
+	}
+
+	return nodes
+}
+
+// value returns the text of the first element matching the path
+func (n *node) value(path string) (string, bool) {
+	nodes := n.find(path)
+	if len(nodes) == 0 {
+		return "", false
+	}
+
+	return nodes[0].text(), true
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"net"
 	"os"
 	"regexp"
 	"strings"
@@ -8,17 +9,51 @@ import (
 	"github.com/czerwonk/junos_exporter/config"
 	"github.com/czerwonk/junos_exporter/connector"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
 )
 
+var targetConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "junos_exporter_target_conflicts_total",
+	Help: "Number of target entries dropped because the same target was already supplied by a source of higher precedence",
+}, []string{"source"})
+
+func init() {
+	exporterRegistry.MustRegister(targetConflicts)
+}
+
+// targetSource is a list of devices supplied by one of the target sources
+type targetSource struct {
+	name        string
+	devices     []*config.DeviceConfig
+	overlapping bool
+}
+
 func devicesForConfig(cfg *config.Config) ([]*connector.Device, error) {
-	if cfg.Devices == nil {
-		if cfg.Targets == nil {
-			cfg.Targets = strings.Split(strings.Trim(*sshHosts, " "), ",")
-		}
+	sources := []targetSource{
+		{name: "devices", devices: cfg.Devices},
+		{name: "targets", devices: devicesFromTargets(cfg.Targets)},
+	}
+
+	// without a config file the flag has already been used to populate the targets
+	if cfg.Targets == nil || *configFile != "" {
+		sources = append(sources, targetSource{name: "flag", devices: devicesFromTargets(strings.Split(*sshHosts, ","))})
+	}
 
-		cfg.Devices = devicesFromTargets(cfg.Targets)
+	// discovered targets are expected to overlap with configured ones, so duplicates are not counted as conflicts
+	sources = append(sources, targetSource{name: "discovery", devices: discovered.devices(cfg.DiscoveryLabels()), overlapping: true})
+
+	for _, s := range sources[1:] {
+		for _, d := range s.devices {
+			err := cfg.ApplyDefaults(d)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not apply defaults to device %s", d.Host)
+			}
+		}
 	}
 
+	cfg.Devices = mergeTargetSources(sources)
+
 	devs := make([]*connector.Device, len(cfg.Devices))
 	var err error
 	for i, d := range cfg.Devices {
@@ -31,6 +66,37 @@ func devicesForConfig(cfg *config.Config) ([]*connector.Device, error) {
 	return devs, nil
 }
 
+// mergeTargetSources merges the sources in order of precedence. A target supplied by more than one source
+// (or more than once by the same source) is only scraped once using the settings of its first occurrence.
+func mergeTargetSources(sources []targetSource) []*config.DeviceConfig {
+	merged := make([]*config.DeviceConfig, 0)
+	seen := make(map[string]string)
+
+	for _, s := range sources {
+		for _, d := range s.devices {
+			key := targetKey(d)
+			if key == "" {
+				continue
+			}
+
+			if first, found := seen[key]; found {
+				if s.overlapping {
+					continue
+				}
+
+				log.Warnf("Target %s of %s is already defined in %s, ignoring it", d.Host, s.name, first)
+				targetConflicts.WithLabelValues(s.name).Inc()
+				continue
+			}
+
+			seen[key] = s.name
+			merged = append(merged, d)
+		}
+	}
+
+	return merged
+}
+
 func devicesFromTargets(targets []string) []*config.DeviceConfig {
 	devices := make([]*config.DeviceConfig, len(targets))
 	for i, t := range targets {
@@ -42,10 +108,39 @@ func devicesFromTargets(targets []string) []*config.DeviceConfig {
 	return devices
 }
 
+// targetKey normalizes the host of a device, so e.g. "Router1" and "router1:22" are considered the same target
+func targetKey(d *config.DeviceConfig) string {
+	host := strings.TrimSpace(d.Host)
+	if host == "" {
+		return ""
+	}
+
+	if d.IsHostPattern {
+		return "pattern:" + host
+	}
+
+	host = strings.ToLower(host)
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+
+	return host
+}
+
 func deviceFromDeviceConfig(device *config.DeviceConfig, cfg *config.Config) (*connector.Device, error) {
-	auth, err := authForDevice(device, cfg)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not initialize config for device %s", device.Host)
+	dev := &connector.Device{
+		Host: device.Host,
+	}
+
+	var auth connector.AuthMethod
+	var err error
+
+	// no connection is established when serving recorded outputs, so credentials are not required
+	if *replayDir == "" {
+		auth, err = authForDevice(device, cfg, dev)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initialize config for device %s", device.Host)
+		}
 	}
 
 	// check whether there is a device specific regex otherwise fallback to global regex
@@ -55,18 +150,22 @@ func deviceFromDeviceConfig(device *config.DeviceConfig, cfg *config.Config) (*c
 		regexp.MustCompile(device.IfDescReg)
 	}
 
-	return &connector.Device{
-		Host: device.Host,
-		Auth: auth,
-	}, nil
+	dev.Auth = auth
+
+	return dev, nil
 }
 
-func authForDevice(device *config.DeviceConfig, cfg *config.Config) (connector.AuthMethod, error) {
+func authForDevice(device *config.DeviceConfig, cfg *config.Config, dev *connector.Device) (connector.AuthMethod, error) {
 	user := *sshUsername
 	if device.Username != "" {
 		user = device.Username
 	}
 
+	if vaultClient != nil {
+		path := vaultClient.PathForHost(device.Host, device.VaultPath)
+		return connector.AuthByProvider(device.Host, vaultClient.CredentialsProvider(path, user)), nil
+	}
+
 	if device.KeyFile != "" {
 		return authForKeyFile(user, device.KeyFile)
 	}
@@ -75,10 +174,18 @@ func authForDevice(device *config.DeviceConfig, cfg *config.Config) (connector.A
 		return authForKeyFile(user, *sshKeyFile)
 	}
 
+	if len(device.Passwords) > 0 {
+		return authForPasswords(user, device.Passwords, dev), nil
+	}
+
 	if device.Password != "" {
 		return connector.AuthByPassword(user, device.Password), nil
 	}
 
+	if len(cfg.Passwords) > 0 {
+		return authForPasswords(user, cfg.Passwords, dev), nil
+	}
+
 	if cfg.Password != "" {
 		return connector.AuthByPassword(user, cfg.Password), nil
 	}
@@ -90,6 +197,11 @@ func authForDevice(device *config.DeviceConfig, cfg *config.Config) (connector.A
 	return nil, errors.New("no valid authentication method available")
 }
 
+func authForPasswords(username string, passwords []string, dev *connector.Device) connector.AuthMethod {
+	dev.PasswordFallback = connector.NewPasswordFallback(passwords)
+	return connector.AuthByPasswordFallback(username, dev.PasswordFallback)
+}
+
 func authForKeyFile(username, keyFile string) (connector.AuthMethod, error) {
 	f, err := os.Open(keyFile)
 	if err != nil {
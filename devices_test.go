@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTargetSources(t *testing.T) {
+	router1 := &config.DeviceConfig{Host: "router1", Username: "admin"}
+	pattern := &config.DeviceConfig{Host: "router.*", IsHostPattern: true}
+
+	before := testutil.ToFloat64(targetConflicts.WithLabelValues("targets"))
+	beforeFlag := testutil.ToFloat64(targetConflicts.WithLabelValues("flag"))
+
+	merged := mergeTargetSources([]targetSource{
+		{name: "devices", devices: []*config.DeviceConfig{router1, pattern}},
+		{name: "targets", devices: devicesFromTargets([]string{"Router1:22", "router2", "router2", ""})},
+		{name: "flag", devices: devicesFromTargets([]string{"router1", "router2:830", "router.*"})},
+	})
+
+	hosts := make([]string, len(merged))
+	for i, d := range merged {
+		hosts[i] = d.Host
+	}
+	assert.Equal(t, []string{"router1", "router.*", "router2", "router2:830", "router.*"}, hosts)
+	assert.Same(t, router1, merged[0], "settings of the first occurrence")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(targetConflicts.WithLabelValues("targets"))-before)
+	assert.Equal(t, float64(1), testutil.ToFloat64(targetConflicts.WithLabelValues("flag"))-beforeFlag)
+}
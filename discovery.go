@@ -0,0 +1,230 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/discovery"
+	"github.com/czerwonk/junos_exporter/mibwalk"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	discoveryConcurrency = 64
+	discoveryLabel       = "discovery"
+)
+
+var (
+	discoverySweeps = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "junos_exporter_discovery_sweeps_total",
+		Help: "Number of subnet sweeps performed by the target discovery",
+	})
+	discoveryDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "junos_exporter_discovery_duration_seconds",
+		Help: "Duration of the last subnet sweep in seconds",
+	})
+	discoveryHosts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "junos_exporter_discovery_hosts",
+		Help: "Number of addresses in the last subnet sweep by result (probed, responded, juniper)",
+	}, []string{"result"})
+	discoveryChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "junos_exporter_discovery_target_changes_total",
+		Help: "Number of targets added or removed by the target discovery",
+	}, []string{"change"})
+
+	discovered = &discoveredTargets{}
+)
+
+func init() {
+	exporterRegistry.MustRegister(discoverySweeps, discoveryDuration, discoveryHosts, discoveryChanges)
+}
+
+// discoveredTargets holds the Juniper devices found by the latest subnet sweep
+type discoveredTargets struct {
+	targets []*discovery.Target
+	mu      sync.RWMutex
+}
+
+// set replaces the discovered targets and returns whether they (or their metadata) have changed
+func (d *discoveredTargets) set(targets []*discovery.Target) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if reflect.DeepEqual(d.targets, targets) {
+		return false
+	}
+
+	added, removed := diffHosts(targetAddresses(d.targets), targetAddresses(targets))
+	discoveryChanges.WithLabelValues("added").Add(float64(added))
+	discoveryChanges.WithLabelValues("removed").Add(float64(removed))
+
+	d.targets = targets
+	return true
+}
+
+// devices returns the discovered targets labeled as auto discovered and with the labels mapped to their metadata
+func (d *discoveredTargets) devices(labels map[string]string) []*config.DeviceConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	devices := make([]*config.DeviceConfig, len(d.targets))
+	for i, t := range d.targets {
+		l := map[string]string{discoveryLabel: "auto"}
+		for name, key := range labels {
+			l[name] = discoveryMetadata(t, key)
+		}
+
+		devices[i] = &config.DeviceConfig{Host: t.Address, Labels: l}
+	}
+
+	return devices
+}
+
+func discoveryMetadata(t *discovery.Target, key string) string {
+	switch key {
+	case config.DiscoverySysObjectID:
+		return t.SysObjectID
+	case config.DiscoverySysObjectName:
+		return mibwalk.ObjectName(t.SysObjectID)
+	case config.DiscoverySubnet:
+		return t.Subnet
+	default:
+		return ""
+	}
+}
+
+func targetAddresses(targets []*discovery.Target) []string {
+	addrs := make([]string, len(targets))
+	for i, t := range targets {
+		addrs[i] = t.Address
+	}
+
+	return addrs
+}
+
+func diffHosts(old, new []string) (added, removed int) {
+	m := make(map[string]bool)
+	for _, h := range old {
+		m[h] = true
+	}
+
+	for _, h := range new {
+		if m[h] {
+			delete(m, h)
+		} else {
+			added++
+		}
+	}
+
+	return added, len(m)
+}
+
+func startDiscovery(subnets []*net.IPNet, interval time.Duration) {
+	log.Infof("Starting target discovery for %d subnets (interval: %v)", len(subnets), interval)
+
+	go func() {
+		for {
+			if discoverTargets(subnets) {
+				log.Infoln("Discovered targets have changed, updating targets")
+				if err := applyDiscoveredTargets(); err != nil {
+					log.Errorf("Error updating discovered targets: %s", err)
+				}
+			}
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// applyDiscoveredTargets updates the targets of the current config with the discovered ones. Unlike a reload,
+// the devices, connections and caches of unchanged targets are kept and gNMI subscriptions are only restarted
+// if the gNMI enabled targets have changed.
+func applyDiscoveredTargets() error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if cfg == nil {
+		return nil
+	}
+
+	c := *cfg
+	c.Devices = configDevices
+	devs, err := devicesForNewConfig(&c)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*connector.Device)
+	for _, d := range devices {
+		existing[d.Host] = d
+	}
+	for i, d := range devs {
+		if prev, found := existing[d.Host]; found {
+			devs[i] = prev
+		}
+	}
+
+	if !reflect.DeepEqual(gnmiHosts(cfg, devices), gnmiHosts(&c, devs)) {
+		err = startGNMISubscriptions(&c, devs)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg = &c
+	devices = devs
+
+	return nil
+}
+
+// gnmiHosts returns the targets with gNMI subscriptions
+func gnmiHosts(c *config.Config, devs []*connector.Device) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, d := range devs {
+		hosts[d.Host] = true
+	}
+
+	res := make(map[string]bool)
+	for _, d := range c.Devices {
+		if d.GNMI != "" && !d.IsHostPattern && hosts[d.Host] {
+			res[d.Host] = true
+		}
+	}
+
+	return res
+}
+
+// discoverTargets sweeps the subnets and returns whether the discovered targets have changed
+func discoverTargets(subnets []*net.IPNet) bool {
+	start := time.Now()
+	res := discovery.Sweep(subnets, probeSysObjectID, discoveryConcurrency)
+
+	discoverySweeps.Inc()
+	discoveryDuration.Set(time.Since(start).Seconds())
+	discoveryHosts.WithLabelValues("probed").Set(float64(res.Probed))
+	discoveryHosts.WithLabelValues("responded").Set(float64(res.Responded))
+	discoveryHosts.WithLabelValues("juniper").Set(float64(len(res.Found)))
+
+	log.Debugf("Subnet sweep found %d Juniper devices (%d of %d addresses responded)", len(res.Found), res.Responded, res.Probed)
+
+	return discovered.set(res.Targets)
+}
+
+// probeSysObjectID queries the sysObjectID with one retry, so a single lost packet does not remove a target
+func probeSysObjectID(host string) (string, error) {
+	oid, err := discovery.SysObjectID(host, *discoveryCommunity, *discoveryTimeout)
+	if err != nil {
+		oid, err = discovery.SysObjectID(host, *discoveryCommunity, *discoveryTimeout)
+	}
+
+	if err == nil {
+		log.Debugf("sysObjectID of %s: %s", host, mibwalk.ObjectName(oid))
+	}
+
+	return oid, err
+}
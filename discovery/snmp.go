@@ -0,0 +1,282 @@
+package discovery
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagNull        = 0x05
+	tagOID         = 0x06
+	tagSequence    = 0x30
+	tagGetRequest  = 0xa0
+	tagGetResponse = 0xa2
+
+	snmpVersion2c = 1
+)
+
+// sysObjectIDOID is the OID of SNMPv2-MIB::sysObjectID.0
+var sysObjectIDOID = []int{1, 3, 6, 1, 2, 1, 1, 2, 0}
+
+// SysObjectID queries the sysObjectID of the host using a single SNMPv2c get request
+func SysObjectID(host, community string, timeout time.Duration) (string, error) {
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(host, "161"), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	requestID := rand.Int31()
+	_, err = conn.Write(encodeGetRequest(community, requestID, sysObjectIDOID))
+	if err != nil {
+		return "", err
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return "", err
+		}
+
+		id, oid, err := decodeGetResponse(buf[:n])
+		if err != nil {
+			return "", err
+		}
+
+		// ignore late responses to previous requests
+		if id == requestID {
+			return oid, nil
+		}
+	}
+}
+
+func encodeGetRequest(community string, requestID int32, oid []int) []byte {
+	varbind := encodeTLV(tagSequence, append(encodeTLV(tagOID, encodeOID(oid)), encodeTLV(tagNull, nil)...))
+
+	pdu := encodeInteger(int64(requestID))
+	pdu = append(pdu, encodeInteger(0)...) // error-status
+	pdu = append(pdu, encodeInteger(0)...) // error-index
+	pdu = append(pdu, encodeTLV(tagSequence, varbind)...)
+
+	msg := encodeInteger(snmpVersion2c)
+	msg = append(msg, encodeTLV(tagOctetString, []byte(community))...)
+	msg = append(msg, encodeTLV(tagGetRequest, pdu)...)
+
+	return encodeTLV(tagSequence, msg)
+}
+
+// decodeGetResponse returns the request ID and the OID value of the first variable binding of the response
+func decodeGetResponse(b []byte) (int32, string, error) {
+	msg, err := expect(b, tagSequence)
+	if err != nil {
+		return 0, "", err
+	}
+
+	_, _, msg, err = readTLV(msg) // version
+	if err != nil {
+		return 0, "", err
+	}
+
+	_, _, msg, err = readTLV(msg) // community
+	if err != nil {
+		return 0, "", err
+	}
+
+	pdu, err := expect(msg, tagGetResponse)
+	if err != nil {
+		return 0, "", err
+	}
+
+	values := make([]int64, 3)
+	for i := range values {
+		var v []byte
+		v, pdu, err = expectNext(pdu, tagInteger)
+		if err != nil {
+			return 0, "", err
+		}
+		values[i] = decodeInteger(v)
+	}
+
+	requestID, errorStatus := int32(values[0]), values[1]
+	if errorStatus != 0 {
+		return requestID, "", fmt.Errorf("SNMP error status %d", errorStatus)
+	}
+
+	varbinds, err := expect(pdu, tagSequence)
+	if err != nil {
+		return requestID, "", err
+	}
+
+	varbind, err := expect(varbinds, tagSequence)
+	if err != nil {
+		return requestID, "", err
+	}
+
+	_, varbind, err = expectNext(varbind, tagOID)
+	if err != nil {
+		return requestID, "", err
+	}
+
+	tag, value, _, err := readTLV(varbind)
+	if err != nil {
+		return requestID, "", err
+	}
+
+	if tag != tagOID {
+		// e.g. noSuchObject or noSuchInstance
+		return requestID, "", fmt.Errorf("unexpected value type 0x%02x", tag)
+	}
+
+	oid, err := decodeOID(value)
+	if err != nil {
+		return requestID, "", err
+	}
+
+	return requestID, oid, nil
+}
+
+func encodeTLV(tag byte, value []byte) []byte {
+	b := []byte{tag}
+
+	l := len(value)
+	if l < 0x80 {
+		b = append(b, byte(l))
+	} else {
+		lb := make([]byte, 0)
+		for ; l > 0; l >>= 8 {
+			lb = append([]byte{byte(l)}, lb...)
+		}
+		b = append(b, 0x80|byte(len(lb)))
+		b = append(b, lb...)
+	}
+
+	return append(b, value...)
+}
+
+func encodeInteger(v int64) []byte {
+	b := []byte{byte(v)}
+	for v > 0x7f || v < -0x80 {
+		v >>= 8
+		b = append([]byte{byte(v)}, b...)
+	}
+
+	return encodeTLV(tagInteger, b)
+}
+
+func decodeInteger(b []byte) int64 {
+	var v int64
+	for i, c := range b {
+		if i == 0 && c&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(c)
+	}
+
+	return v
+}
+
+func encodeOID(oid []int) []byte {
+	b := []byte{byte(oid[0]*40 + oid[1])}
+	for _, n := range oid[2:] {
+		s := []byte{byte(n & 0x7f)}
+		for n >>= 7; n > 0; n >>= 7 {
+			s = append([]byte{byte(n&0x7f) | 0x80}, s...)
+		}
+		b = append(b, s...)
+	}
+
+	return b
+}
+
+func decodeOID(b []byte) (string, error) {
+	if len(b) == 0 {
+		return "", errors.New("empty OID")
+	}
+
+	parts := []string{strconv.Itoa(int(b[0]) / 40), strconv.Itoa(int(b[0]) % 40)}
+
+	var n uint64
+	l := 0
+	for _, c := range b[1:] {
+		n = n<<7 | uint64(c&0x7f)
+		l++
+
+		// sub-identifiers are limited to 32 bit (RFC 2578)
+		if l > 5 || n > math.MaxUint32 {
+			return "", errors.New("sub-identifier of OID out of range")
+		}
+
+		if c&0x80 == 0 {
+			parts = append(parts, strconv.FormatUint(n, 10))
+			n, l = 0, 0
+		}
+	}
+
+	if l > 0 {
+		return "", errors.New("truncated OID")
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// readTLV returns tag and value of the first element and the remaining bytes
+func readTLV(b []byte) (byte, []byte, []byte, error) {
+	if len(b) < 2 {
+		return 0, nil, nil, errors.New("truncated message")
+	}
+
+	tag, l, offset := b[0], int(b[1]), 2
+	if l&0x80 != 0 {
+		n := l & 0x7f
+		if n == 0 || n > 4 || len(b) < 2+n {
+			return 0, nil, nil, errors.New("invalid length")
+		}
+
+		l = 0
+		for _, c := range b[2 : 2+n] {
+			l = l<<8 | int(c)
+		}
+		offset += n
+	}
+
+	if l < 0 || len(b) < offset+l {
+		return 0, nil, nil, errors.New("truncated message")
+	}
+
+	return tag, b[offset : offset+l], b[offset+l:], nil
+}
+
+// expect returns the value of the first element if it has the given tag
+func expect(b []byte, tag byte) ([]byte, error) {
+	v, _, err := expectNext(b, tag)
+	return v, err
+}
+
+// expectNext returns the value of the first element if it has the given tag and the remaining bytes
+func expectNext(b []byte, tag byte) ([]byte, []byte, error) {
+	t, v, rest, err := readTLV(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if t != tag {
+		return nil, nil, fmt.Errorf("expected tag 0x%02x, got 0x%02x", tag, t)
+	}
+
+	return v, rest, nil
+}
@@ -0,0 +1,126 @@
+package discovery
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeGetRequest(t *testing.T) {
+	b := encodeGetRequest("public", 1, sysObjectIDOID)
+
+	expected := []byte{
+		0x30, 0x26,
+		0x02, 0x01, 0x01,
+		0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+		0xa0, 0x19,
+		0x02, 0x01, 0x01,
+		0x02, 0x01, 0x00,
+		0x02, 0x01, 0x00,
+		0x30, 0x0e, 0x30, 0x0c,
+		0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x02, 0x00,
+		0x05, 0x00,
+	}
+	assert.Equal(t, expected, b)
+}
+
+func TestDecodeGetResponse(t *testing.T) {
+	oid := []int{1, 3, 6, 1, 4, 1, 2636, 1, 1, 1, 2, 29}
+	varbind := encodeTLV(tagSequence, append(encodeTLV(tagOID, encodeOID(sysObjectIDOID)), encodeTLV(tagOID, encodeOID(oid))...))
+
+	pdu := encodeInteger(123456)
+	pdu = append(pdu, encodeInteger(0)...)
+	pdu = append(pdu, encodeInteger(0)...)
+	pdu = append(pdu, encodeTLV(tagSequence, varbind)...)
+
+	msg := encodeInteger(snmpVersion2c)
+	msg = append(msg, encodeTLV(tagOctetString, []byte("public"))...)
+	msg = append(msg, encodeTLV(tagGetResponse, pdu)...)
+
+	id, value, err := decodeGetResponse(encodeTLV(tagSequence, msg))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(123456), id, "request id")
+	assert.Equal(t, "1.3.6.1.4.1.2636.1.1.1.2.29", value, "sysObjectID")
+}
+
+func TestDecodeGetResponseInvalid(t *testing.T) {
+	_, _, err := decodeGetResponse([]byte{0x30, 0x10, 0x02})
+	assert.Error(t, err, "truncated")
+
+	// noSuchObject
+	varbind := encodeTLV(tagSequence, append(encodeTLV(tagOID, encodeOID(sysObjectIDOID)), encodeTLV(0x80, nil)...))
+	pdu := append(append(append(encodeInteger(1), encodeInteger(0)...), encodeInteger(0)...), encodeTLV(tagSequence, varbind)...)
+	msg := append(append(encodeInteger(snmpVersion2c), encodeTLV(tagOctetString, []byte("public"))...), encodeTLV(tagGetResponse, pdu)...)
+
+	_, _, err = decodeGetResponse(encodeTLV(tagSequence, msg))
+	assert.Error(t, err, "no such object")
+}
+
+func TestInteger(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 255, 256, -1, -128, -129, 2147483647} {
+		_, b, _, err := readTLV(encodeInteger(v))
+		assert.NoError(t, err)
+		assert.Equal(t, v, decodeInteger(b), "value %d", v)
+	}
+}
+
+func TestLongLength(t *testing.T) {
+	value := make([]byte, 300)
+	_, b, rest, err := readTLV(encodeTLV(tagOctetString, value))
+	assert.NoError(t, err)
+	assert.Len(t, b, 300)
+	assert.Empty(t, rest)
+}
+
+func TestDecodeOIDInvalid(t *testing.T) {
+	_, err := decodeOID(nil)
+	assert.Error(t, err, "empty")
+
+	_, err = decodeOID([]byte{0x2b, 0x06, 0x81})
+	assert.Error(t, err, "truncated sub-identifier")
+
+	_, err = decodeOID([]byte{0x2b, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f})
+	assert.Error(t, err, "sub-identifier exceeding 32 bit")
+}
+
+func FuzzDecodeGetResponse(f *testing.F) {
+	varbind := encodeTLV(tagSequence, append(encodeTLV(tagOID, encodeOID(sysObjectIDOID)), encodeTLV(tagOID, encodeOID([]int{1, 3, 6, 1, 4, 1, 2636, 1, 1, 1, 2, 29}))...))
+	pdu := append(append(append(encodeInteger(1), encodeInteger(0)...), encodeInteger(0)...), encodeTLV(tagSequence, varbind)...)
+	msg := append(append(encodeInteger(snmpVersion2c), encodeTLV(tagOctetString, []byte("public"))...), encodeTLV(tagGetResponse, pdu)...)
+	valid := encodeTLV(tagSequence, msg)
+
+	f.Add(valid)
+	f.Add(valid[:len(valid)/2])
+	f.Add([]byte{0x30, 0x84, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0x30, 0x10, 0x02})
+	f.Add(encodeGetRequest("public", 1, sysObjectIDOID))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		_, oid, err := decodeGetResponse(b)
+		if err != nil {
+			return
+		}
+
+		for _, p := range strings.Split(oid, ".") {
+			_, err := strconv.ParseUint(p, 10, 32)
+			assert.NoError(t, err, "OID %s", oid)
+		}
+	})
+}
+
+func FuzzReadTLV(f *testing.F) {
+	f.Add([]byte{0x02, 0x01, 0x01})
+	f.Add([]byte{0x04, 0x82, 0x01, 0x00})
+	f.Add([]byte{0x04, 0x80})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		_, v, rest, err := readTLV(b)
+		if err != nil {
+			return
+		}
+
+		assert.LessOrEqual(t, len(v)+len(rest), len(b))
+	})
+}
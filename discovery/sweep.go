@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// JuniperEnterpriseOID is the prefix of the sysObjectID of all Juniper devices
+const JuniperEnterpriseOID = "1.3.6.1.4.1.2636."
+
+// maxHosts limits the number of addresses swept per subnet
+const maxHosts = 65536
+
+// ProbeFunc returns the sysObjectID of a host
+type ProbeFunc func(host string) (string, error)
+
+// Result is the result of a subnet sweep
+type Result struct {
+	// Probed is the number of addresses probed
+	Probed int
+	// Responded is the number of addresses responding to the probe
+	Responded int
+	// Found contains the addresses of all Juniper devices (sorted)
+	Found []string
+	// Targets contains the Juniper devices with the metadata of the sweep (sorted by address)
+	Targets []*Target
+}
+
+// Target is a Juniper device found by a sweep
+type Target struct {
+	Address     string
+	Subnet      string
+	SysObjectID string
+}
+
+// ParseSubnets parses a comma separated list of subnets in CIDR notation
+func ParseSubnets(s string) ([]*net.IPNet, error) {
+	subnets := make([]*net.IPNet, 0)
+	for _, str := range strings.Split(s, ",") {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(str)
+		if err != nil {
+			return nil, err
+		}
+
+		ones, bits := n.Mask.Size()
+		if bits-ones > 16 {
+			return nil, fmt.Errorf("subnet %s exceeds the maximum of %d addresses", str, maxHosts)
+		}
+
+		subnets = append(subnets, n)
+	}
+
+	return subnets, nil
+}
+
+// Hosts returns all host addresses of the subnet (excluding network and broadcast address for IPv4 subnets larger than /31)
+func Hosts(subnet *net.IPNet) []string {
+	ones, bits := subnet.Mask.Size()
+	size := 1 << uint(bits-ones)
+	skipEdges := bits == 32 && size > 2
+
+	hosts := make([]string, 0, size)
+	ip := subnet.IP.Mask(subnet.Mask)
+	for i := 0; i < size; i++ {
+		if !skipEdges || (i > 0 && i < size-1) {
+			hosts = append(hosts, ip.String())
+		}
+		ip = nextIP(ip)
+	}
+
+	return hosts
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
+
+// Sweep probes all hosts of the subnets using the given number of concurrent probes
+func Sweep(subnets []*net.IPNet, probe ProbeFunc, concurrency int) *Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type host struct {
+		address string
+		subnet  string
+	}
+
+	hosts := make(chan host)
+	res := &Result{Found: make([]string, 0), Targets: make([]*Target, 0)}
+	mu := sync.Mutex{}
+
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+
+			for h := range hosts {
+				oid, err := probe(h.address)
+
+				mu.Lock()
+				res.Probed++
+				if err == nil {
+					res.Responded++
+
+					if strings.HasPrefix(oid, JuniperEnterpriseOID) {
+						res.Found = append(res.Found, h.address)
+						res.Targets = append(res.Targets, &Target{Address: h.address, Subnet: h.subnet, SysObjectID: oid})
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, s := range subnets {
+		for _, h := range Hosts(s) {
+			hosts <- host{address: h, subnet: s.String()}
+		}
+	}
+	close(hosts)
+	wg.Wait()
+
+	sort.Strings(res.Found)
+	sort.Slice(res.Targets, func(i, j int) bool {
+		return res.Targets[i].Address < res.Targets[j].Address
+	})
+	return res
+}
@@ -0,0 +1,59 @@
+package discovery
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSubnets(t *testing.T) {
+	subnets, err := ParseSubnets("192.168.0.0/24, 2001:db8::/120")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 2)
+
+	_, err = ParseSubnets("10.0.0.0/8")
+	assert.Error(t, err, "too large")
+
+	_, err = ParseSubnets("10.0.0.1")
+	assert.Error(t, err, "no CIDR")
+}
+
+func TestHosts(t *testing.T) {
+	_, n, _ := net.ParseCIDR("192.168.0.4/30")
+	assert.Equal(t, []string{"192.168.0.5", "192.168.0.6"}, Hosts(n))
+
+	_, n, _ = net.ParseCIDR("192.168.0.4/31")
+	assert.Equal(t, []string{"192.168.0.4", "192.168.0.5"}, Hosts(n))
+
+	_, n, _ = net.ParseCIDR("192.168.0.255/32")
+	assert.Equal(t, []string{"192.168.0.255"}, Hosts(n))
+
+	_, n, _ = net.ParseCIDR("2001:db8::/127")
+	assert.Equal(t, []string{"2001:db8::", "2001:db8::1"}, Hosts(n))
+}
+
+func TestSweep(t *testing.T) {
+	subnets, _ := ParseSubnets("192.168.0.0/29")
+
+	probe := func(host string) (string, error) {
+		switch host {
+		case "192.168.0.1", "192.168.0.3":
+			return "1.3.6.1.4.1.2636.1.1.1.2.29", nil
+		case "192.168.0.2":
+			return "1.3.6.1.4.1.9.1.1", nil
+		default:
+			return "", errors.New("timeout")
+		}
+	}
+
+	res := Sweep(subnets, probe, 4)
+	assert.Equal(t, 6, res.Probed, "probed")
+	assert.Equal(t, 3, res.Responded, "responded")
+	assert.Equal(t, []string{"192.168.0.1", "192.168.0.3"}, res.Found, "found")
+	assert.Equal(t, []*Target{
+		{Address: "192.168.0.1", Subnet: "192.168.0.0/29", SysObjectID: "1.3.6.1.4.1.2636.1.1.1.2.29"},
+		{Address: "192.168.0.3", Subnet: "192.168.0.0/29", SysObjectID: "1.3.6.1.4.1.2636.1.1.1.2.29"},
+	}, res.Targets, "targets")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/discovery"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// sweepTargets returns the targets found by a sweep of 192.168.0.0/24
+func sweepTargets(hosts ...string) []*discovery.Target {
+	targets := make([]*discovery.Target, len(hosts))
+	for i, h := range hosts {
+		targets[i] = &discovery.Target{Address: h, Subnet: "192.168.0.0/24", SysObjectID: "1.3.6.1.4.1.2636.1.1.1.2.29"}
+	}
+
+	return targets
+}
+
+func TestDiscoveredTargets(t *testing.T) {
+	d := &discoveredTargets{}
+	added := testutil.ToFloat64(discoveryChanges.WithLabelValues("added"))
+	removed := testutil.ToFloat64(discoveryChanges.WithLabelValues("removed"))
+
+	assert.True(t, d.set(sweepTargets("192.168.0.1", "192.168.0.2")), "initial sweep")
+	assert.False(t, d.set(sweepTargets("192.168.0.1", "192.168.0.2")), "unchanged")
+	assert.True(t, d.set(sweepTargets("192.168.0.2", "192.168.0.3")), "changed")
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(discoveryChanges.WithLabelValues("added"))-added, "added")
+	assert.Equal(t, float64(1), testutil.ToFloat64(discoveryChanges.WithLabelValues("removed"))-removed, "removed")
+
+	devices := d.devices(nil)
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "192.168.0.2", devices[0].Host)
+	assert.Equal(t, map[string]string{"discovery": "auto"}, devices[0].Labels)
+
+	changed := sweepTargets("192.168.0.2", "192.168.0.3")
+	changed[0].SysObjectID = "1.3.6.1.4.1.2636.1.1.1.2.25"
+	assert.True(t, d.set(changed), "metadata changed")
+}
+
+func TestDiscoveredTargetsLabels(t *testing.T) {
+	d := &discoveredTargets{}
+	d.set(sweepTargets("192.168.0.1"))
+
+	devices := d.devices(map[string]string{"platform_oid": "sysObjectID", "mgmt_subnet": "subnet"})
+	assert.Equal(t, map[string]string{
+		"discovery":    "auto",
+		"platform_oid": "1.3.6.1.4.1.2636.1.1.1.2.29",
+		"mgmt_subnet":  "192.168.0.0/24",
+	}, devices[0].Labels)
+}
+
+func TestApplyDiscoveredTargets(t *testing.T) {
+	prevCfg, prevDevices, prevConfigDevices, prevDiscovered := cfg, devices, configDevices, discovered
+	defer func() {
+		cfg, devices, configDevices, discovered = prevCfg, prevDevices, prevConfigDevices, prevDiscovered
+	}()
+
+	configured := []*config.DeviceConfig{{Host: "router1", Password: "secret"}}
+	cfg = config.New()
+	cfg.Password = "secret"
+	cfg.Devices = configured
+	configDevices = configured
+	router1 := &connector.Device{Host: "router1"}
+	devices = []*connector.Device{router1}
+
+	discovered = &discoveredTargets{}
+	discovered.set(sweepTargets("router1", "192.168.0.2"))
+
+	err := applyDiscoveredTargets()
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+	assert.Same(t, router1, devices[0], "unchanged targets are kept")
+	assert.Equal(t, "192.168.0.2", devices[1].Host)
+	assert.Len(t, cfg.Devices, 2)
+	assert.Equal(t, configured, configDevices, "configured devices are not modified")
+
+	discovered.set(sweepTargets())
+
+	err = applyDiscoveredTargets()
+	assert.NoError(t, err)
+	assert.Len(t, devices, 1)
+	assert.Same(t, router1, devices[0])
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// dryRun collects all targets once and writes the metrics in text format
+func dryRun(w io.Writer) error {
+	reg := prometheus.NewRegistry()
+
+	collectorForDevices := func(devs []*connector.Device) prometheus.Collector {
+		return newCoalescingCollector(context.Background(), devs, "")
+	}
+
+	if *reverseDNSEnabled || cfg.HasDeviceLabels() {
+		registerWithTargetLabels(reg, devices, collectorForDevices)
+	} else {
+		reg.MustRegister(collectorForDevices(devices))
+	}
+
+	mfs, err := withMetricNames(withTransformations(reg)).Gather()
+	if err != nil {
+		return err
+	}
+
+	enc := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, mf := range mfs {
+		err = enc.Encode(mf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package dump
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/pkg/errors"
+)
+
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// Dir returns the directory the outputs of a device are stored in
+func Dir(dir, host string) string {
+	return filepath.Join(dir, unsafeChars.ReplaceAllString(host, "_"))
+}
+
+// fileName returns the name of the file the output of a command is stored in
+func fileName(cmd string) string {
+	cmd = strings.TrimSuffix(cmd, " | display xml")
+	return strings.Trim(unsafeChars.ReplaceAllString(cmd, "_"), "_") + ".xml"
+}
+
+type recorder struct {
+	rpc.Connection
+	dir string
+}
+
+// NewRecorder creates a connection writing the output of each command to a file in dir
+func NewRecorder(conn rpc.Connection, dir string) (rpc.Connection, error) {
+	d := Dir(dir, conn.Host())
+	err := os.MkdirAll(d, 0755)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create dump directory")
+	}
+
+	return &recorder{Connection: conn, dir: d}, nil
+}
+
+// RunCommand runs a command against the device and records the output
+func (r *recorder) RunCommand(cmd string) ([]byte, error) {
+	b, err := r.Connection.RunCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(r.dir, fileName(cmd)), b, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not record output")
+	}
+
+	return b, nil
+}
+
+type replay struct {
+	device *connector.Device
+	dir    string
+}
+
+// NewReplay creates a connection serving command outputs recorded for the device instead of connecting to it
+func NewReplay(device *connector.Device, dir string) rpc.Connection {
+	return &replay{device: device, dir: Dir(dir, device.Host)}
+}
+
+// RunCommand returns the recorded output of the command
+func (r *replay) RunCommand(cmd string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(r.dir, fileName(cmd)))
+	if os.IsNotExist(err) {
+		return nil, errors.Errorf("no output recorded for command %s", cmd)
+	}
+
+	return b, err
+}
+
+// Host returns the host of the device
+func (r *replay) Host() string {
+	return r.device.Host
+}
+
+// Device returns the device
+func (r *replay) Device() *connector.Device {
+	return r.device
+}
+
+// Aborts returns the number of aborted commands (always none)
+func (r *replay) Aborts() map[string]uint64 {
+	return map[string]uint64{}
+}
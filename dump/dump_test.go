@@ -0,0 +1,59 @@
+package dump
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConnection struct {
+	device *connector.Device
+}
+
+func (c *fakeConnection) RunCommand(cmd string) ([]byte, error) {
+	return []byte("<rpc-reply>" + cmd + "</rpc-reply>"), nil
+}
+
+func (c *fakeConnection) Host() string {
+	return c.device.Host
+}
+
+func (c *fakeConnection) Device() *connector.Device {
+	return c.device
+}
+
+func (c *fakeConnection) Aborts() map[string]uint64 {
+	return map[string]uint64{}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := &connector.Device{Host: "[2001:db8::1]:22"}
+	r, err := NewRecorder(&fakeConnection{device: d}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.RunCommand("show bgp neighbor | display xml")
+	assert.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "_2001_db8_1_22", "show_bgp_neighbor.xml"))
+	assert.NoError(t, err, "file name")
+
+	p := NewReplay(d, dir)
+	b, err := p.RunCommand("show bgp neighbor | display xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "<rpc-reply>show bgp neighbor | display xml</rpc-reply>", string(b))
+
+	_, err = p.RunCommand("show interfaces | display xml")
+	assert.Error(t, err, "not recorded")
+}
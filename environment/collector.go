@@ -57,6 +57,7 @@ func (*environmentCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- temperaturesDesc
 	ch <- fanDesc
 	ch <- dcPowerDesc
+	ch <- collector.ComponentHealthyDesc
 }
 
 // Collect collects metrics from JunOS
@@ -113,6 +114,9 @@ func (c *environmentCollector) environmentItems(client *rpc.Client, ch chan<- pr
 			if strings.Contains(item.Name, "Power Supply") || strings.Contains(item.Name, "PEM") {
 				l = append(l, item.Name, item.Status)
 				ch <- prometheus.MustNewConstMetric(powerSupplyDesc, prometheus.GaugeValue, float64(statusValues[item.Status]), l...)
+				collectComponentHealth(ch, labelValues[0], collector.ComponentPSU, re.Name, item)
+			} else if item.Class == "Fans" {
+				collectComponentHealth(ch, labelValues[0], collector.ComponentFan, re.Name, item)
 			} else if item.Temperature != nil {
 				l = append(l, item.Name)
 				ch <- prometheus.MustNewConstMetric(temperaturesDesc, prometheus.GaugeValue, item.Temperature.Value, l...)
@@ -174,6 +178,20 @@ func (c *environmentCollector) environmentPEMItems(client *rpc.Client, ch chan<-
 	return nil
 }
 
+// collectComponentHealth emits the normalized health of an item. Absent items (e.g. empty slots) are skipped.
+func collectComponentHealth(ch chan<- prometheus.Metric, target, componentType, reName string, item EnvironmentItemRpc) {
+	if item.Status == "Absent" {
+		return
+	}
+
+	name := item.Name
+	if reName != "N/A" {
+		name = reName + " " + name
+	}
+
+	ch <- collector.ComponentHealthy(target, componentType, name, item.Status == "OK")
+}
+
 func parseXML(b []byte, res *RpcReply) error {
 	if strings.Contains(string(b), "multi-routing-engine-results") {
 		return xml.Unmarshal(b, res)
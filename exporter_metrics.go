@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promcollectors "github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// exporterRegistry contains the metrics about the exporter itself. They are served on a separate path to keep the device metrics clean
+	exporterRegistry = prometheus.NewRegistry()
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "junos_exporter_requests_in_flight",
+		Help: "Number of requests of the metrics path currently served",
+	})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "junos_exporter_request_duration_seconds",
+		Help:    "Duration of requests of the metrics path (including the collection of the devices)",
+		Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+	}, []string{"code"})
+	responseSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "junos_exporter_response_size_bytes",
+		Help:    "Size of responses of the metrics path",
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 8),
+	}, []string{})
+	scrapeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "junos_exporter_scrape_duration_seconds",
+		Help:    "Duration of the collection of a target (with trace ID as exemplar if tracing is enabled)",
+		Buckets: []float64{0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+	}, []string{"target"})
+)
+
+func init() {
+	exporterRegistry.MustRegister(requestsInFlight, requestDuration, responseSize, scrapeDuration)
+}
+
+// observeScrapeDuration adds the duration of the collection of a target. If the scrape was sampled by the tracer,
+// its trace ID is attached as exemplar so a slow scrape can be looked up in the tracing backend.
+func observeScrapeDuration(target string, d time.Duration, span trace.Span) {
+	o := scrapeDuration.WithLabelValues(target)
+
+	sc := span.SpanContext()
+	if !sc.IsSampled() {
+		o.Observe(d.Seconds())
+		return
+	}
+
+	o.(prometheus.ExemplarObserver).ObserveWithExemplar(d.Seconds(), prometheus.Labels{"trace_id": sc.TraceID().String()})
+}
+
+// instrumentMetricsHandler adds metrics about requests of the metrics path, to tell slow devices from huge responses
+func instrumentMetricsHandler(h http.HandlerFunc) http.Handler {
+	return promhttp.InstrumentHandlerInFlight(requestsInFlight,
+		promhttp.InstrumentHandlerDuration(requestDuration,
+			promhttp.InstrumentHandlerResponseSize(responseSize, h)))
+}
+
+func exporterMetricsHandler() http.Handler {
+	if *goMetricsEnabled {
+		exporterRegistry.MustRegister(promcollectors.NewGoCollector())
+	}
+
+	if *processMetricsEnabled {
+		exporterRegistry.MustRegister(promcollectors.NewProcessCollector(promcollectors.ProcessCollectorOpts{}))
+	}
+
+	// exemplars are only exposed in the OpenMetrics format
+	return promhttp.HandlerFor(exporterRegistry, promhttp.HandlerOpts{
+		EnableOpenMetrics: *tracingEndpoint != "",
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserveScrapeDuration(t *testing.T) {
+	defer scrapeDuration.Reset()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	span := trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), sc))
+
+	observeScrapeDuration("router1", 3*time.Second, span)
+	observeScrapeDuration("router2", 3*time.Second, trace.SpanFromContext(context.Background()))
+
+	families, err := exporterRegistry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exemplars := make(map[string]string)
+	for _, f := range families {
+		if f.GetName() != "junos_exporter_scrape_duration_seconds" {
+			continue
+		}
+
+		for _, m := range f.GetMetric() {
+			target := m.GetLabel()[0].GetValue()
+			exemplars[target] = ""
+
+			for _, b := range m.GetHistogram().GetBucket() {
+				if e := b.GetExemplar(); e != nil {
+					assert.Equal(t, 5.0, b.GetUpperBound())
+					exemplars[target] = e.GetLabel()[0].GetValue()
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"router1": sc.TraceID().String(),
+		"router2": "",
+	}, exemplars)
+}
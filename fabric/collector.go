@@ -0,0 +1,136 @@
+package fabric
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_fabric_"
+
+var (
+	planeStateDesc  *prometheus.Desc
+	planeErrorsDesc *prometheus.Desc
+	planeUptimeDesc *prometheus.Desc
+	linkUpDesc      *prometheus.Desc
+	planeStateMap   = map[string]int{
+		"Offline": 0,
+		"Online":  1,
+		"Spare":   2,
+		"Check":   3,
+		"Fault":   4,
+		"Empty":   5,
+	}
+)
+
+func init() {
+	l := []string{"target", "plane"}
+
+	planeStateDesc = prometheus.NewDesc(prefix+"plane_state", "State of the fabric plane (0 = Offline, 1 = Online, 2 = Spare, 3 = Check, 4 = Fault, 5 = Empty, -1 = unknown)", l, nil)
+	collector.RegisterStates(prefix+"plane_state", planeStateMap)
+	planeErrorsDesc = prometheus.NewDesc(prefix+"plane_errors", "Fabric plane reports errors (1 = errors, 0 = no errors)", l, nil)
+	planeUptimeDesc = prometheus.NewDesc(prefix+"plane_uptime_seconds", "Time since the fabric plane came online", l, nil)
+	linkUpDesc = prometheus.NewDesc(prefix+"plane_link_up", "Links between the fabric plane and the PFE are ok (1 = ok, 0 = error or disabled)", append(l, "fpc", "pfe"), nil)
+}
+
+type fabricCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &fabricCollector{}
+}
+
+// Name returns the name of the collector
+func (*fabricCollector) Name() string {
+	return "Fabric"
+}
+
+// Describe describes the metrics
+func (*fabricCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- planeStateDesc
+	ch <- planeErrorsDesc
+	ch <- planeUptimeDesc
+	ch <- linkUpDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *fabricCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	err := c.collectSummary(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	return c.collectPlanes(client, ch, labelValues)
+}
+
+func (c *fabricCollector) collectSummary(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = fabricSummaryRpc{}
+	err := client.RunCommandAndParse("show chassis fabric summary", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range x.Planes {
+		l := append(labelValues, p.Slot)
+
+		ch <- prometheus.MustNewConstMetric(planeStateDesc, prometheus.GaugeValue, planeStateValue(p.State), l...)
+
+		if p.Errors != "" {
+			ch <- prometheus.MustNewConstMetric(planeErrorsDesc, prometheus.GaugeValue, errorsToFloat(p.Errors), l...)
+		}
+
+		if p.Uptime.Seconds > 0 {
+			ch <- prometheus.MustNewConstMetric(planeUptimeDesc, prometheus.GaugeValue, float64(p.Uptime.Seconds), l...)
+		}
+	}
+
+	return nil
+}
+
+func (c *fabricCollector) collectPlanes(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = fabricPlaneRpc{}
+	err := client.RunCommandAndParse("show chassis fabric plane", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range x.Planes {
+		for _, f := range p.FPCs {
+			for _, pfe := range f.PFEs {
+				l := append(labelValues, p.Slot, f.Slot, pfe.Slot)
+				ch <- prometheus.MustNewConstMetric(linkUpDesc, prometheus.GaugeValue, linkStatusToFloat(pfe.Status), l...)
+			}
+		}
+	}
+
+	return nil
+}
+
+func planeStateValue(state string) float64 {
+	for name, v := range planeStateMap {
+		if strings.EqualFold(strings.TrimSpace(state), name) {
+			return float64(v)
+		}
+	}
+
+	return -1
+}
+
+func errorsToFloat(errors string) float64 {
+	if strings.EqualFold(strings.TrimSpace(errors), "no") {
+		return 0
+	}
+
+	return 1
+}
+
+func linkStatusToFloat(status string) float64 {
+	if strings.EqualFold(strings.TrimSpace(status), "links ok") {
+		return 1
+	}
+
+	return 0
+}
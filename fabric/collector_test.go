@@ -0,0 +1,11 @@
+package fabric
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector/collectortest"
+)
+
+func TestCollectorGolden(t *testing.T) {
+	collectortest.AssertGolden(t, NewCollector(), "router1")
+}
@@ -0,0 +1,34 @@
+package fabric
+
+type fabricSummaryRpc struct {
+	Planes []plane `xml:"fm-state-information>fm-state-item"`
+}
+
+type plane struct {
+	Slot   string `xml:"plane-slot"`
+	State  string `xml:"state"`
+	Errors string `xml:"errors"`
+	Uptime struct {
+		Seconds uint64 `xml:"seconds,attr"`
+	} `xml:"fm-uptime"`
+}
+
+type fabricPlaneRpc struct {
+	Planes []planeLinks `xml:"fm-plane-state-information>fmp-plane"`
+}
+
+type planeLinks struct {
+	Slot  string    `xml:"slot"`
+	State string    `xml:"state"`
+	FPCs  []fpcLink `xml:"fmp-fpc-state"`
+}
+
+type fpcLink struct {
+	Slot string    `xml:"slot"`
+	PFEs []pfeLink `xml:"pfe-link-status"`
+}
+
+type pfeLink struct {
+	Slot   string `xml:"slot"`
+	Status string `xml:"link-state"`
+}
@@ -2,6 +2,7 @@ package fpc
 
 import (
 	"encoding/xml"
+	"log"
 	"strconv"
 	"strings"
 
@@ -27,14 +28,20 @@ var (
 	cpuAvgDesc                  *prometheus.Desc
 	memoryHeapUtilizationDesc   *prometheus.Desc
 	memoryBufferUtilizationDesc *prometheus.Desc
+
+	scrapedDesc *prometheus.Desc
 )
 
 type fpcCollector struct {
+	pfeStatistics bool
 }
 
 func init() {
+	scrapedDesc = prometheus.NewDesc(prefix+"scraped", "Number of FPCs found in the output of the device (all routing engines)", []string{"target"}, nil)
+
 	l := []string{"target", "re_name", "slot"}
 	upDesc = prometheus.NewDesc(prefix+"up", "Status of the linecard (1 = Online)", l, nil)
+	collector.RegisterStates(prefix+"up", map[string]int{"Offline": 0, "Online": 1})
 	temperatureDesc = prometheus.NewDesc(prefix+"temperature_celsius", "Temperature in degree celsius", l, nil)
 	uptimeDesc = prometheus.NewDesc(prefix+"uptime_seconds", "Seconds since boot", l, nil)
 	powerDesc = prometheus.NewDesc(prefix+"max_power_consumption_watt", "Maximum power consumption in Watt", l, nil)
@@ -50,11 +57,14 @@ func init() {
 
 	l_pic := []string{"target", "re_name", "fpc_slot", "pic_slot", "pic_type"}
 	picstatusDesc = prometheus.NewDesc(prefix+"pic_status", "Status of the PIC (1 = Online, 0 = Offline)", l_pic, nil)
+	collector.RegisterStates(prefix+"pic_status", map[string]int{"Offline": 0, "Online": 1})
 }
 
-// NewCollector creates a new collector
-func NewCollector() collector.RPCCollector {
-	return &fpcCollector{}
+// NewCollector creates a new collector. When pfeStatistics is set the traffic statistics of the packet forwarding engines are collected for each online linecard.
+func NewCollector(pfeStatistics bool) collector.RPCCollector {
+	return &fpcCollector{
+		pfeStatistics: pfeStatistics,
+	}
 }
 
 // Name returns the name of the collector
@@ -63,7 +73,7 @@ func (*fpcCollector) Name() string {
 }
 
 // Describe describes the metrics
-func (*fpcCollector) Describe(ch chan<- *prometheus.Desc) {
+func (c *fpcCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- upDesc
 	ch <- temperatureDesc
 	ch <- memoryDesc
@@ -75,6 +85,17 @@ func (*fpcCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- memoryHeapUtilizationDesc
 	ch <- memoryBufferUtilizationDesc
 	ch <- cpuAvgDesc
+	ch <- scrapedDesc
+
+	if c.pfeStatistics {
+		ch <- pfeInputPacketsDesc
+		ch <- pfeOutputPacketsDesc
+		ch <- pfeInputPacketsRateDesc
+		ch <- pfeOutputPacketsRateDesc
+		ch <- pfeFabricInputPacketsDesc
+		ch <- pfeFabricOutputPacketsDesc
+		ch <- pfeDiscardsDesc
+	}
 }
 
 // Collect collects metrics from JunOS
@@ -128,12 +149,24 @@ func (c *fpcCollector) CollectFPC(client *rpc.Client, ch chan<- prometheus.Metri
 		return err
 	}
 
+	count := 0
 	for _, r := range r.MultiRoutingEngineResults.RoutingEngine {
 		labels := append(labelValues, r.Name)
 		for _, f := range r.FPCs.FPC {
 			c.collectForFPC(ch, labels, &f)
+
+			if c.pfeStatistics && f.State == "Online" {
+				// the statistics of a single PFE might not be available (e.g. FPC restarting), the other FPCs are collected anyway
+				err = c.collectPFE(client, ch, labels, &f)
+				if err != nil {
+					log.Printf("could not get PFE statistics of FPC %d of %s: %s", f.Slot, client.Device().Host, err)
+				}
+			}
 		}
+		count += len(r.FPCs.FPC)
 	}
+
+	ch <- prometheus.MustNewConstMetric(scrapedDesc, prometheus.GaugeValue, float64(count), labelValues...)
 	return nil
 }
 
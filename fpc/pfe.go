@@ -0,0 +1,91 @@
+package fpc
+
+import (
+	"fmt"
+
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pfeInputPacketsDesc        *prometheus.Desc
+	pfeOutputPacketsDesc       *prometheus.Desc
+	pfeInputPacketsRateDesc    *prometheus.Desc
+	pfeOutputPacketsRateDesc   *prometheus.Desc
+	pfeFabricInputPacketsDesc  *prometheus.Desc
+	pfeFabricOutputPacketsDesc *prometheus.Desc
+	pfeDiscardsDesc            *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target", "re_name", "slot"}
+	pfeInputPacketsDesc = prometheus.NewDesc(prefix+"pfe_input_packets_total", "Number of packets received by the packet forwarding engines of the linecard", l, nil)
+	pfeOutputPacketsDesc = prometheus.NewDesc(prefix+"pfe_output_packets_total", "Number of packets sent by the packet forwarding engines of the linecard", l, nil)
+	pfeInputPacketsRateDesc = prometheus.NewDesc(prefix+"pfe_input_packets_per_second", "Input rate of the packet forwarding engines of the linecard as calculated by the device", l, nil)
+	pfeOutputPacketsRateDesc = prometheus.NewDesc(prefix+"pfe_output_packets_per_second", "Output rate of the packet forwarding engines of the linecard as calculated by the device", l, nil)
+	pfeFabricInputPacketsDesc = prometheus.NewDesc(prefix+"pfe_fabric_input_packets_total", "Number of packets received from the fabric", l, nil)
+	pfeFabricOutputPacketsDesc = prometheus.NewDesc(prefix+"pfe_fabric_output_packets_total", "Number of packets sent to the fabric", l, nil)
+	pfeDiscardsDesc = prometheus.NewDesc(prefix+"pfe_hardware_discards_total", "Number of packets discarded by the packet forwarding engines of the linecard", append(l, "type"), nil)
+}
+
+type pfeTrafficRpc struct {
+	Statistics struct {
+		Traffic struct {
+			InputPackets        uint64 `xml:"pfe-input-packets"`
+			InputPacketsRate    uint64 `xml:"input-pps"`
+			OutputPackets       uint64 `xml:"pfe-output-packets"`
+			OutputPacketsRate   uint64 `xml:"output-pps"`
+			FabricInputPackets  uint64 `xml:"pfe-fabric-input"`
+			FabricOutputPackets uint64 `xml:"pfe-fabric-output"`
+		} `xml:"pfe-traffic-statistics"`
+		Discards struct {
+			Timeout          uint64 `xml:"timeout-discard"`
+			TruncatedKey     uint64 `xml:"truncated-key-discard"`
+			BitsToTest       uint64 `xml:"bits-to-test-discard"`
+			DataError        uint64 `xml:"data-error-discard"`
+			StackUnderflow   uint64 `xml:"stack-underflow-discard"`
+			StackOverflow    uint64 `xml:"stack-overflow-discard"`
+			Nexthop          uint64 `xml:"nexthop-discard"`
+			InvalidInterface uint64 `xml:"invalid-iif-discard"`
+			InfoCell         uint64 `xml:"info-cell-discard"`
+			Fabric           uint64 `xml:"fabric-discard"`
+		} `xml:"pfe-hardware-discard-statistics"`
+	} `xml:"pfe-statistics"`
+}
+
+// collectPFE collects the traffic statistics of the packet forwarding engines of an online linecard
+func (c *fpcCollector) collectPFE(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string, fpc *FPC) error {
+	x := pfeTrafficRpc{}
+	err := client.RunCommandAndParse(fmt.Sprintf("show pfe statistics traffic fpc %d", fpc.Slot), &x)
+	if err != nil {
+		return err
+	}
+
+	l := append(labelValues, fmt.Sprint(fpc.Slot))
+	t := x.Statistics.Traffic
+	ch <- prometheus.MustNewConstMetric(pfeInputPacketsDesc, prometheus.CounterValue, float64(t.InputPackets), l...)
+	ch <- prometheus.MustNewConstMetric(pfeOutputPacketsDesc, prometheus.CounterValue, float64(t.OutputPackets), l...)
+	ch <- prometheus.MustNewConstMetric(pfeInputPacketsRateDesc, prometheus.GaugeValue, float64(t.InputPacketsRate), l...)
+	ch <- prometheus.MustNewConstMetric(pfeOutputPacketsRateDesc, prometheus.GaugeValue, float64(t.OutputPacketsRate), l...)
+	ch <- prometheus.MustNewConstMetric(pfeFabricInputPacketsDesc, prometheus.CounterValue, float64(t.FabricInputPackets), l...)
+	ch <- prometheus.MustNewConstMetric(pfeFabricOutputPacketsDesc, prometheus.CounterValue, float64(t.FabricOutputPackets), l...)
+
+	d := x.Statistics.Discards
+	discards := map[string]uint64{
+		"timeout":           d.Timeout,
+		"truncated_key":     d.TruncatedKey,
+		"bits_to_test":      d.BitsToTest,
+		"data_error":        d.DataError,
+		"stack_underflow":   d.StackUnderflow,
+		"stack_overflow":    d.StackOverflow,
+		"nexthop":           d.Nexthop,
+		"invalid_interface": d.InvalidInterface,
+		"info_cell":         d.InfoCell,
+		"fabric":            d.Fabric,
+	}
+	for k, v := range discards {
+		ch <- prometheus.MustNewConstMetric(pfeDiscardsDesc, prometheus.CounterValue, float64(v), append(l, k)...)
+	}
+
+	return nil
+}
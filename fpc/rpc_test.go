@@ -1,6 +1,7 @@
 package fpc
 
 import (
+	"encoding/xml"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -361,3 +362,47 @@ func TestParseSRXPicOutput(t *testing.T) {
 	assert.Equal(t, "Online", p.PicState, "pic-state")
 	assert.Equal(t, "N/A", p.PicType, "pic-type")
 }
+
+func TestParsePFETrafficStatistics(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <pfe-statistics>
+        <pfe-traffic-statistics>
+            <pfe-input-packets>1520578450</pfe-input-packets>
+            <input-pps>1203</input-pps>
+            <pfe-output-packets>1497411380</pfe-output-packets>
+            <output-pps>1187</output-pps>
+            <pfe-fabric-input>10345</pfe-fabric-input>
+            <pfe-fabric-input-pps>0</pfe-fabric-input-pps>
+            <pfe-fabric-output>10678</pfe-fabric-output>
+            <pfe-fabric-output-pps>0</pfe-fabric-output-pps>
+        </pfe-traffic-statistics>
+        <pfe-hardware-discard-statistics>
+            <timeout-discard>0</timeout-discard>
+            <truncated-key-discard>0</truncated-key-discard>
+            <bits-to-test-discard>0</bits-to-test-discard>
+            <data-error-discard>0</data-error-discard>
+            <stack-underflow-discard>0</stack-underflow-discard>
+            <stack-overflow-discard>0</stack-overflow-discard>
+            <nexthop-discard>42</nexthop-discard>
+            <invalid-iif-discard>7</invalid-iif-discard>
+            <info-cell-discard>0</info-cell-discard>
+            <fabric-discard>0</fabric-discard>
+        </pfe-hardware-discard-statistics>
+    </pfe-statistics>
+    <cli>
+        <banner></banner>
+    </cli>
+</rpc-reply>`
+
+	x := pfeTrafficRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(1520578450), x.Statistics.Traffic.InputPackets, "input packets")
+	assert.Equal(t, uint64(1187), x.Statistics.Traffic.OutputPacketsRate, "output pps")
+	assert.Equal(t, uint64(10678), x.Statistics.Traffic.FabricOutputPackets, "fabric output")
+	assert.Equal(t, uint64(42), x.Statistics.Discards.Nexthop, "nexthop discards")
+	assert.Equal(t, uint64(7), x.Statistics.Discards.InvalidInterface, "invalid interface discards")
+}
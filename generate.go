@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/custom"
+	"gopkg.in/yaml.v2"
+)
+
+func generateCustomCollector(file, name, command string, w io.Writer) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cc, err := custom.Generate(name, command, f)
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(struct {
+		CustomCollectors []*config.CustomCollectorConfig `yaml:"custom_collectors"`
+	}{
+		CustomCollectors: []*config.CustomCollectorConfig{cc},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
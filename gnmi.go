@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/gnmi"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// gnmiStore holds the values received by the subscriptions of the current config (replaced on reload)
+	gnmiStore  = gnmi.NewStore()
+	gnmiCancel context.CancelFunc
+)
+
+// startGNMISubscriptions ends the subscriptions of the previous config and subscribes to all devices with gNMI enabled.
+// The subscriptions of the previous config are kept if an error occurs.
+func startGNMISubscriptions(c *config.Config, devs []*connector.Device) error {
+	store := gnmi.NewStore()
+	if c.GNMI == nil || *replayDir != "" {
+		stopGNMISubscriptions()
+		gnmiStore = store
+		return nil
+	}
+
+	hosts := make(map[string]bool)
+	for _, d := range devs {
+		hosts[d.Host] = true
+	}
+
+	subscribers := make([]*gnmi.Subscriber, 0)
+	for _, d := range c.Devices {
+		if d.GNMI == "" || d.IsHostPattern || !hosts[d.Host] {
+			continue
+		}
+
+		s, err := gnmi.NewSubscriber(d.Host, c.GNMI, gnmiCredentials(d, c), store)
+		if err != nil {
+			return errors.Wrapf(err, "could not initialize gNMI subscription for device %s", d.Host)
+		}
+
+		subscribers = append(subscribers, s)
+	}
+
+	stopGNMISubscriptions()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, s := range subscribers {
+		go s.Run(ctx)
+	}
+
+	if len(subscribers) > 0 {
+		log.Infof("Subscribed to gNMI telemetry of %d devices", len(subscribers))
+	}
+
+	gnmiStore, gnmiCancel = store, cancel
+	return nil
+}
+
+func stopGNMISubscriptions() {
+	if gnmiCancel != nil {
+		gnmiCancel()
+		gnmiCancel = nil
+	}
+}
+
+// gnmiCredentials resolves the credentials of the device in the same order as for SSH (vault, passwords of the device, global passwords, flag).
+// Password files are already resolved when loading the config. Key files can not be used for gNMI.
+func gnmiCredentials(d *config.DeviceConfig, c *config.Config) connector.CredentialsProvider {
+	user := gnmiUsername(d)
+
+	if vaultClient != nil {
+		return vaultClient.CredentialsProvider(vaultClient.PathForHost(d.Host, d.VaultPath), user)
+	}
+
+	creds := &connector.Credentials{Username: user, Password: gnmiPassword(d, c)}
+	return func() (*connector.Credentials, error) {
+		res := *creds
+		return &res, nil
+	}
+}
+
+func gnmiUsername(d *config.DeviceConfig) string {
+	if d.Username != "" {
+		return d.Username
+	}
+
+	return *sshUsername
+}
+
+func gnmiPassword(d *config.DeviceConfig, c *config.Config) string {
+	for _, p := range []string{first(d.Passwords), d.Password, first(c.Passwords), c.Password, *sshPassword} {
+		if p != "" {
+			return p
+		}
+	}
+
+	return ""
+}
+
+func first(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	return s[0]
+}
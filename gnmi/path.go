@@ -0,0 +1,136 @@
+package gnmi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var invalidNameCharsRe = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// ParsePath parses a path in the string representation (e.g. /interfaces/interface[name=xe-0/0/0]/state)
+func ParsePath(p string) (*gpb.Path, error) {
+	path := &gpb.Path{}
+
+	for _, e := range splitPath(strings.TrimPrefix(p, "/")) {
+		elem, err := parseElem(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s: %v", p, err)
+		}
+
+		path.Elem = append(path.Elem, elem)
+	}
+
+	return path, nil
+}
+
+// splitPath splits the path at slashes not enclosed in brackets (key values may contain slashes)
+func splitPath(p string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+
+	for i, c := range p {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, p[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if start < len(p) {
+		parts = append(parts, p[start:])
+	}
+
+	return parts
+}
+
+func parseElem(s string) (*gpb.PathElem, error) {
+	i := strings.Index(s, "[")
+	if i < 0 {
+		if s == "" {
+			return nil, fmt.Errorf("empty element")
+		}
+
+		return &gpb.PathElem{Name: s}, nil
+	}
+
+	elem := &gpb.PathElem{Name: s[:i], Key: make(map[string]string)}
+	for _, k := range strings.Split(strings.TrimSuffix(s[i+1:], "]"), "][") {
+		kv := strings.SplitN(k, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid key %s", k)
+		}
+
+		elem.Key[kv[0]] = kv[1]
+	}
+
+	return elem, nil
+}
+
+// series derives metric name and labels from the path of an update.
+// Path elements are joined to the name, keys are converted to labels (prefixed by the element name if the key name is already used).
+func series(elems []*gpb.PathElem) (string, []string, []string) {
+	names := make([]string, 0, len(elems))
+	labelNames := make([]string, 0)
+	labelValues := make([]string, 0)
+	seen := map[string]bool{"target": true, "value": true}
+
+	for _, e := range elems {
+		names = append(names, e.Name)
+
+		for _, k := range sortedKeys(e.Key) {
+			l := sanitize(k)
+			if seen[l] {
+				l = sanitize(e.Name) + "_" + l
+			}
+			seen[l] = true
+
+			labelNames = append(labelNames, l)
+			labelValues = append(labelValues, e.Key[k])
+		}
+	}
+
+	return sanitize(strings.Join(names, "_")), labelNames, labelValues
+}
+
+func sanitize(s string) string {
+	return invalidNameCharsRe.ReplaceAllString(s, "_")
+}
+
+// joinPath returns the elements of the path prefixed by the elements of the notification prefix
+func joinPath(prefix, path *gpb.Path) []*gpb.PathElem {
+	elems := make([]*gpb.PathElem, 0)
+	if prefix != nil {
+		elems = append(elems, prefix.Elem...)
+	}
+
+	if path != nil {
+		elems = append(elems, path.Elem...)
+	}
+
+	return elems
+}
+
+// pathString returns the string representation of the path elements
+func pathString(elems []*gpb.PathElem) string {
+	b := strings.Builder{}
+	for _, e := range elems {
+		b.WriteString("/")
+		b.WriteString(e.Name)
+
+		for _, k := range sortedKeys(e.Key) {
+			b.WriteString(fmt.Sprintf("[%s=%s]", k, e.Key[k]))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package gnmi
+
+import (
+	"testing"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	p, err := ParsePath("/interfaces/interface[name=xe-0/0/0]/state/counters")
+	assert.NoError(t, err)
+	assert.Equal(t, "/interfaces/interface[name=xe-0/0/0]/state/counters", pathString(p.Elem))
+	assert.Equal(t, map[string]string{"name": "xe-0/0/0"}, p.Elem[1].Key)
+
+	p, err = ParsePath("/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=bgp]")
+	assert.NoError(t, err)
+	assert.Len(t, p.Elem, 4)
+	assert.Equal(t, map[string]string{"identifier": "BGP", "name": "bgp"}, p.Elem[3].Key)
+
+	_, err = ParsePath("/interfaces//state")
+	assert.Error(t, err, "empty element")
+
+	_, err = ParsePath("/interfaces/interface[name]")
+	assert.Error(t, err, "key without value")
+}
+
+func TestSeries(t *testing.T) {
+	p, _ := ParsePath("/interfaces/interface[name=xe-0/0/0]/subinterfaces/subinterface[index=0]/state/counters/in-octets")
+	name, labelNames, labelValues := series(p.Elem)
+
+	assert.Equal(t, "interfaces_interface_subinterfaces_subinterface_state_counters_in_octets", name)
+	assert.Equal(t, []string{"name", "index"}, labelNames)
+	assert.Equal(t, []string{"xe-0/0/0", "0"}, labelValues)
+
+	p, _ = ParsePath("/components/component[name=FPC0]/subcomponents/subcomponent[name=PIC0]/state/name")
+	_, labelNames, _ = series(p.Elem)
+	assert.Equal(t, []string{"name", "subcomponent_name"}, labelNames, "duplicate key name")
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		val      *gpb.TypedValue
+		num      float64
+		str      string
+		isString bool
+		ok       bool
+	}{
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 42}}, num: 42, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: -3}}, num: -3, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: 1.5}}, num: 1.5, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, num: 1, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_DecimalVal{DecimalVal: &gpb.Decimal64{Digits: 1234, Precision: 2}}}, num: 12.34, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "UP"}}, str: "UP", isString: true, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`"18446744073709551615"`)}}, num: 18446744073709551615, ok: true},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(`{"a":1}`)}}},
+		{val: &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: []byte{1}}}},
+	}
+
+	for _, test := range tests {
+		num, str, isString, ok := value(test.val)
+		assert.Equal(t, test.ok, ok, "ok %v", test.val)
+		assert.InDelta(t, test.num, num, 0.0001, "num %v", test.val)
+		assert.Equal(t, test.str, str, "str %v", test.val)
+		assert.Equal(t, test.isString, isString, "isString %v", test.val)
+	}
+}
@@ -0,0 +1,168 @@
+package gnmi
+
+import (
+	"strings"
+	"sync"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_gnmi_"
+
+var (
+	connectedDesc *prometheus.Desc
+	updatesDesc   *prometheus.Desc
+)
+
+func init() {
+	connectedDesc = prometheus.NewDesc(prefix+"connected", "Subscription to the gNMI telemetry of the target is established", []string{"target"}, nil)
+	updatesDesc = prometheus.NewDesc(prefix+"updates_total", "Number of updates received via gNMI from the target", []string{"target"}, nil)
+}
+
+type sample struct {
+	name        string
+	labelNames  []string
+	labelValues []string
+	value       float64
+	str         string
+	isString    bool
+}
+
+type targetState struct {
+	connected bool
+	updates   uint64
+	samples   map[string]*sample
+}
+
+// Store holds the latest values received via gNMI per target
+type Store struct {
+	targets map[string]*targetState
+	mu      sync.RWMutex
+}
+
+// NewStore creates a new store
+func NewStore() *Store {
+	return &Store{
+		targets: make(map[string]*targetState),
+	}
+}
+
+func (s *Store) target(host string) *targetState {
+	t, found := s.targets[host]
+	if !found {
+		t = &targetState{samples: make(map[string]*sample)}
+		s.targets[host] = t
+	}
+
+	return t
+}
+
+// SetConnected sets the connection state of the target. Values of a target are discarded when the subscription ends.
+func (s *Store) SetConnected(host string, connected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.target(host)
+	t.connected = connected
+
+	if !connected {
+		t.samples = make(map[string]*sample)
+	}
+}
+
+// Remove removes the target from the store
+func (s *Store) Remove(host string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.targets, host)
+}
+
+// Update applies the updates and deletes of a notification
+func (s *Store) Update(host string, n *gpb.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.target(host)
+
+	for _, d := range n.Delete {
+		p := pathString(joinPath(n.Prefix, d))
+		for k := range t.samples {
+			if k == p || strings.HasPrefix(k, p+"/") {
+				delete(t.samples, k)
+			}
+		}
+	}
+
+	for _, u := range n.Update {
+		num, str, isString, ok := value(u.Val)
+		if !ok {
+			continue
+		}
+
+		elems := joinPath(n.Prefix, u.Path)
+		name, labelNames, labelValues := series(elems)
+		t.samples[pathString(elems)] = &sample{
+			name:        name,
+			labelNames:  labelNames,
+			labelValues: labelValues,
+			value:       num,
+			str:         str,
+			isString:    isString,
+		}
+		t.updates++
+	}
+}
+
+// Collect sends the latest values of the target to the channel. String values (e.g. oper-status) are exported as info metric with the value as label.
+func (s *Store) Collect(host string, ch chan<- prometheus.Metric, labelValues []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, found := s.targets[host]
+	if !found {
+		ch <- prometheus.MustNewConstMetric(connectedDesc, prometheus.GaugeValue, 0, labelValues...)
+		return
+	}
+
+	connected := 0
+	if t.connected {
+		connected = 1
+	}
+	ch <- prometheus.MustNewConstMetric(connectedDesc, prometheus.GaugeValue, float64(connected), labelValues...)
+	ch <- prometheus.MustNewConstMetric(updatesDesc, prometheus.CounterValue, float64(t.updates), labelValues...)
+
+	for _, smp := range t.samples {
+		m, err := smp.metric(labelValues)
+		if err == nil {
+			ch <- m
+		}
+	}
+}
+
+// Connected returns whether the subscription to the target is established
+func (s *Store) Connected(host string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, found := s.targets[host]
+	return found && t.connected
+}
+
+func (smp *sample) metric(labelValues []string) (prometheus.Metric, error) {
+	name := prefix + smp.name
+	l := append([]string{"target"}, smp.labelNames...)
+	lv := append(append([]string{}, labelValues...), smp.labelValues...)
+	v := smp.value
+
+	if smp.isString {
+		name += "_info"
+		l = append(l, "value")
+		lv = append(lv, smp.str)
+		v = 1
+	}
+
+	desc := prometheus.NewDesc(name, "Value received via gNMI subscription", l, nil)
+	return prometheus.NewConstMetric(desc, prometheus.UntypedValue, v, lv...)
+}
@@ -0,0 +1,78 @@
+package gnmi
+
+import (
+	"testing"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore()
+	prefix, _ := ParsePath("/interfaces/interface[name=xe-0/0/0]/state")
+	inOctets, _ := ParsePath("/counters/in-octets")
+	operStatus, _ := ParsePath("/oper-status")
+
+	s.SetConnected("router1", true)
+	s.Update("router1", &gpb.Notification{
+		Prefix: prefix,
+		Update: []*gpb.Update{
+			{Path: inOctets, Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1000}}},
+			{Path: operStatus, Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "UP"}}},
+		},
+	})
+
+	mfs := gather(t, s, "router1")
+	assert.Equal(t, float64(1), mfs["junos_gnmi_connected"][0].GetGauge().GetValue(), "connected")
+	assert.Equal(t, float64(2), mfs["junos_gnmi_updates_total"][0].GetCounter().GetValue(), "updates")
+	assert.Equal(t, float64(1000), mfs["junos_gnmi_interfaces_interface_state_counters_in_octets"][0].GetUntyped().GetValue())
+
+	info := mfs["junos_gnmi_interfaces_interface_state_oper_status_info"][0]
+	labels := make(map[string]string)
+	for _, l := range info.Label {
+		labels[l.GetName()] = l.GetValue()
+	}
+	assert.Equal(t, map[string]string{"target": "router1", "name": "xe-0/0/0", "value": "UP"}, labels)
+
+	counters, _ := ParsePath("/counters")
+	s.Update("router1", &gpb.Notification{Prefix: prefix, Delete: []*gpb.Path{counters}})
+	mfs = gather(t, s, "router1")
+	assert.NotContains(t, mfs, "junos_gnmi_interfaces_interface_state_counters_in_octets", "deleted")
+	assert.Contains(t, mfs, "junos_gnmi_interfaces_interface_state_oper_status_info")
+
+	s.SetConnected("router1", false)
+	mfs = gather(t, s, "router1")
+	assert.Equal(t, float64(0), mfs["junos_gnmi_connected"][0].GetGauge().GetValue(), "disconnected")
+	assert.NotContains(t, mfs, "junos_gnmi_interfaces_interface_state_oper_status_info", "discarded")
+}
+
+type storeCollector struct {
+	store *Store
+	host  string
+}
+
+func (c *storeCollector) Describe(ch chan<- *prometheus.Desc) {
+}
+
+func (c *storeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.store.Collect(c.host, ch, []string{c.host})
+}
+
+func gather(t *testing.T, s *Store, host string) map[string][]*dto.Metric {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(&storeCollector{store: s, host: host})
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mfs := make(map[string][]*dto.Metric)
+	for _, mf := range families {
+		mfs[mf.GetName()] = mf.Metric
+	}
+
+	return mfs
+}
@@ -0,0 +1,199 @@
+package gnmi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	minRetryInterval = time.Second
+	maxRetryInterval = time.Minute
+)
+
+// Subscriber subscribes to the gNMI telemetry of a device and stores the received values
+type Subscriber struct {
+	host        string
+	address     string
+	credentials connector.CredentialsProvider
+	tls         bool
+	creds       credentials.TransportCredentials
+	request     *gpb.SubscribeRequest
+	store       *Store
+}
+
+// NewSubscriber creates a new subscriber for the host. The credentials are retrieved from the provider each time the subscription is established.
+func NewSubscriber(host string, cfg *config.GNMIConfig, creds connector.CredentialsProvider, store *Store) (*Subscriber, error) {
+	req, err := subscribeRequest(cfg.Subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	tc, err := transportCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		h = host
+	}
+
+	return &Subscriber{
+		host:        host,
+		address:     net.JoinHostPort(h, strconv.Itoa(cfg.Port)),
+		credentials: creds,
+		tls:         cfg.TLS,
+		creds:       tc,
+		request:     req,
+		store:       store,
+	}, nil
+}
+
+func subscribeRequest(subscriptions []*config.GNMISubscription) (*gpb.SubscribeRequest, error) {
+	list := &gpb.SubscriptionList{
+		Mode:     gpb.SubscriptionList_STREAM,
+		Encoding: gpb.Encoding_PROTO,
+	}
+
+	for _, s := range subscriptions {
+		p, err := ParsePath(s.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		sub := &gpb.Subscription{Path: p, Mode: gpb.SubscriptionMode_SAMPLE, SampleInterval: uint64(s.SampleInterval.Nanoseconds())}
+		if s.Mode == config.GNMIModeOnChange {
+			sub.Mode = gpb.SubscriptionMode_ON_CHANGE
+			sub.SampleInterval = 0
+		}
+
+		list.Subscription = append(list.Subscription, sub)
+	}
+
+	return &gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{Subscribe: list},
+	}, nil
+}
+
+func transportCredentials(cfg *config.GNMIConfig) (credentials.TransportCredentials, error) {
+	if !cfg.TLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	if cfg.CAFile != "" {
+		b, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read CA file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errors.New("no certificates found in CA file")
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// Run subscribes until the context is canceled. The subscription is established again with increasing intervals after errors.
+func (s *Subscriber) Run(ctx context.Context) {
+	retry := minRetryInterval
+
+	for {
+		start := time.Now()
+		err := s.subscribe(ctx)
+		s.store.SetConnected(s.host, false)
+
+		if ctx.Err() != nil {
+			s.store.Remove(s.host)
+			return
+		}
+
+		log.Errorf("gNMI subscription to %s failed: %v", s.host, err)
+
+		if time.Since(start) > maxRetryInterval {
+			retry = minRetryInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			s.store.Remove(s.host)
+			return
+		case <-time.After(retry):
+		}
+
+		retry *= 2
+		if retry > maxRetryInterval {
+			retry = maxRetryInterval
+		}
+	}
+}
+
+func (s *Subscriber) subscribe(ctx context.Context) error {
+	c, err := s.credentials()
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve credentials")
+	}
+
+	// credentials are sent as metadata, so they must not be sent unencrypted
+	if c.Password != "" && !s.tls {
+		return errors.New("refusing to send credentials without TLS (set tls in the gnmi section)")
+	}
+
+	conn, err := grpc.DialContext(ctx, s.address, grpc.WithTransportCredentials(s.creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if c.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", c.Username, "password", c.Password)
+	}
+
+	stream, err := gpb.NewGNMIClient(conn).Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(s.request)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return errors.New("subscription closed by target")
+		}
+		if err != nil {
+			return err
+		}
+
+		s.store.SetConnected(s.host, true)
+
+		if u, ok := resp.Response.(*gpb.SubscribeResponse_Update); ok {
+			s.store.Update(s.host, u.Update)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gnmi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeRequiresTLSForCredentials(t *testing.T) {
+	cfg := &config.GNMIConfig{Port: 32767, Subscriptions: []*config.GNMISubscription{{Path: "/interfaces", Mode: config.GNMIModeOnChange}}}
+	creds := func() (*connector.Credentials, error) {
+		return &connector.Credentials{Username: "junos_exporter", Password: "secret"}, nil
+	}
+
+	s, err := NewSubscriber("192.0.2.1", cfg, creds, NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.subscribe(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "without TLS")
+	}
+}
@@ -0,0 +1,77 @@
+package gnmi
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// value converts a typed value into a numeric value or a string value (e.g. oper-status UP).
+// ok is false for values not representable as sample (e.g. JSON objects or bytes).
+func value(v *gpb.TypedValue) (num float64, str string, isString bool, ok bool) {
+	switch x := v.GetValue().(type) {
+	case *gpb.TypedValue_IntVal:
+		return float64(x.IntVal), "", false, true
+	case *gpb.TypedValue_UintVal:
+		return float64(x.UintVal), "", false, true
+	case *gpb.TypedValue_FloatVal:
+		return float64(x.FloatVal), "", false, true
+	case *gpb.TypedValue_DoubleVal:
+		return x.DoubleVal, "", false, true
+	case *gpb.TypedValue_DecimalVal:
+		return float64(x.DecimalVal.Digits) / math.Pow10(int(x.DecimalVal.Precision)), "", false, true
+	case *gpb.TypedValue_BoolVal:
+		if x.BoolVal {
+			return 1, "", false, true
+		}
+		return 0, "", false, true
+	case *gpb.TypedValue_StringVal:
+		return 0, x.StringVal, true, true
+	case *gpb.TypedValue_AsciiVal:
+		return 0, x.AsciiVal, true, true
+	case *gpb.TypedValue_JsonVal:
+		return jsonValue(x.JsonVal)
+	case *gpb.TypedValue_JsonIetfVal:
+		return jsonValue(x.JsonIetfVal)
+	default:
+		return 0, "", false, false
+	}
+}
+
+// jsonValue converts JSON encoded scalars. Numbers encoded as strings (like 64 bit integers in RFC7951) are treated as numbers.
+func jsonValue(b []byte) (float64, string, bool, bool) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return 0, "", false, false
+	}
+
+	switch x := v.(type) {
+	case float64:
+		return x, "", false, true
+	case bool:
+		if x {
+			return 1, "", false, true
+		}
+		return 0, "", false, true
+	case string:
+		if f, err := strconv.ParseFloat(x, 64); err == nil {
+			return f, "", false, true
+		}
+		return 0, x, true, true
+	default:
+		return 0, "", false, false
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,92 @@
+package hostresources
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/mibwalk"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_host_resources_"
+
+var (
+	storageSizeDesc               *prometheus.Desc
+	storageUsedDesc               *prometheus.Desc
+	storageAllocationFailuresDesc *prometheus.Desc
+	processorLoadDesc             *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target", "index", "description"}
+	storageSizeDesc = prometheus.NewDesc(prefix+"storage_size_bytes", "Size of the storage area (hrStorageSize)", l, nil)
+	storageUsedDesc = prometheus.NewDesc(prefix+"storage_used_bytes", "Used space of the storage area (hrStorageUsed)", l, nil)
+	storageAllocationFailuresDesc = prometheus.NewDesc(prefix+"storage_allocation_failures_total", "Number of failed allocation requests in the storage area (hrStorageAllocationFailures)", l, nil)
+	processorLoadDesc = prometheus.NewDesc(prefix+"processor_load_percent", "Average load of the processor over the last minute (hrProcessorLoad)", []string{"target", "index"}, nil)
+}
+
+type hostResourcesCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &hostResourcesCollector{}
+}
+
+// Name returns the name of the collector
+func (*hostResourcesCollector) Name() string {
+	return "Host Resources"
+}
+
+// Describe describes the metrics
+func (*hostResourcesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageSizeDesc
+	ch <- storageUsedDesc
+	ch <- storageAllocationFailuresDesc
+	ch <- processorLoadDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *hostResourcesCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	err := c.collectStorage(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	return c.collectProcessors(client, ch, labelValues)
+}
+
+func (c *hostResourcesCollector) collectStorage(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = mibwalk.Result{}
+	err := client.RunCommandAndParse("show snmp mib walk hrStorageTable", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range storageEntries(x.Information.Objects) {
+		l := append(labelValues, e.index, e.description)
+
+		ch <- prometheus.MustNewConstMetric(storageSizeDesc, prometheus.GaugeValue, e.size*e.allocationUnits, l...)
+		ch <- prometheus.MustNewConstMetric(storageUsedDesc, prometheus.GaugeValue, e.used*e.allocationUnits, l...)
+		ch <- prometheus.MustNewConstMetric(storageAllocationFailuresDesc, prometheus.CounterValue, e.allocationFailures, l...)
+	}
+
+	return nil
+}
+
+func (c *hostResourcesCollector) collectProcessors(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = mibwalk.Result{}
+	err := client.RunCommandAndParse("show snmp mib walk hrProcessorLoad", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range index.Rows(x.Information.Objects) {
+		if !r.Has("hrProcessorLoad") {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(processorLoadDesc, prometheus.GaugeValue, r.Float("hrProcessorLoad"), append(labelValues, r.Labels...)...)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package hostresources
+
+import (
+	"github.com/czerwonk/junos_exporter/mibwalk"
+)
+
+var index = mibwalk.Index{{Label: "index", Type: mibwalk.Integer}}
+
+type storageEntry struct {
+	index              string
+	description        string
+	allocationUnits    float64
+	size               float64
+	used               float64
+	allocationFailures float64
+}
+
+// storageEntries groups the columns of hrStorageTable by index
+func storageEntries(objects []mibwalk.Object) []*storageEntry {
+	entries := make([]*storageEntry, 0)
+
+	for _, r := range index.Rows(objects) {
+		entries = append(entries, &storageEntry{
+			index:              r.Labels[0],
+			description:        r.String("hrStorageDescr"),
+			allocationUnits:    r.Float("hrStorageAllocationUnits"),
+			size:               r.Float("hrStorageSize"),
+			used:               r.Float("hrStorageUsed"),
+			allocationFailures: r.Float("hrStorageAllocationFailures"),
+		})
+	}
+
+	return entries
+}
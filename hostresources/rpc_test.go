@@ -0,0 +1,69 @@
+package hostresources
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/mibwalk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStorageTable(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <snmp-object-information xmlns="http://xml.juniper.net/junos/21.4R3/junos-snmp">
+        <snmp-object>
+            <name>hrStorageIndex.1</name>
+            <object-value-type>number</object-value-type>
+            <object-value>1</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageDescr.1</name>
+            <object-value-type>text</object-value-type>
+            <object-value>/dev/gpt/junos: root file system, mounted on: /.mount</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageAllocationUnits.1</name>
+            <object-value-type>number</object-value-type>
+            <object-value>2048</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageSize.1</name>
+            <object-value-type>number</object-value-type>
+            <object-value>1000</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageUsed.1</name>
+            <object-value-type>number</object-value-type>
+            <object-value>250</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageAllocationFailures.1</name>
+            <object-value-type>number</object-value-type>
+            <object-value>3</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>hrStorageDescr.2</name>
+            <object-value-type>text</object-value-type>
+            <object-value>Physical memory</object-value>
+        </snmp-object>
+    </snmp-object-information>
+</rpc-reply>`
+
+	x := mibwalk.Result{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := storageEntries(x.Information.Objects)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, &storageEntry{
+		index:              "1",
+		description:        "/dev/gpt/junos: root file system, mounted on: /.mount",
+		allocationUnits:    2048,
+		size:               1000,
+		used:               250,
+		allocationFailures: 3,
+	}, entries[0])
+	assert.Equal(t, "Physical memory", entries[1].description)
+}
@@ -18,6 +18,7 @@ const prefix = "junos_interface_diagnostics_"
 
 type interfaceDiagnosticsCollector struct {
 	labels                                 *interfacelabels.DynamicLabels
+	scrapedDesc                            *prometheus.Desc
 	laserBiasCurrentDesc                   *prometheus.Desc
 	laserBiasCurrentHighAlarmThresholdDesc *prometheus.Desc
 	laserBiasCurrentLowAlarmThresholdDesc  *prometheus.Desc
@@ -62,6 +63,8 @@ type interfaceDiagnosticsCollector struct {
 
 	rxSignalAvgOpticalPowerDesc    *prometheus.Desc
 	rxSignalAvgOpticalPowerDbmDesc *prometheus.Desc
+
+	flagDesc *prometheus.Desc
 }
 
 // NewCollector creates a new collector
@@ -80,6 +83,8 @@ func (*interfaceDiagnosticsCollector) Name() string {
 }
 
 func (c *interfaceDiagnosticsCollector) init() {
+	c.scrapedDesc = prometheus.NewDesc(prefix+"optics_scraped", "Number of optics found in the output of the device", []string{"target"}, nil)
+
 	l := []string{"target", "name"}
 	l = append(l, c.labels.LabelNames()...)
 
@@ -127,10 +132,13 @@ func (c *interfaceDiagnosticsCollector) init() {
 	c.laserRxOpticalPowerLowAlarmThresholdDbmDesc = prometheus.NewDesc(prefix+"laser_rx_low_alarm_threshold_dbm", "Laser rx power low alarm threshold_dbm in dBm", l, nil)
 	c.laserRxOpticalPowerHighWarnThresholdDbmDesc = prometheus.NewDesc(prefix+"laser_rx_high_warn_threshold_dbm", "Laser rx power high warn threshold_dbm in dBm", l, nil)
 	c.laserRxOpticalPowerLowWarnThresholdDbmDesc = prometheus.NewDesc(prefix+"laser_rx_low_warn_threshold_dbm", "Laser rx power low warn threshold_dbm in dBm", l, nil)
+
+	c.flagDesc = prometheus.NewDesc(prefix+"flag", "Alarm or warning flag raised by the module (1 = raised)", append(l, "flag"), nil)
 }
 
 // Describe describes the metrics
 func (c *interfaceDiagnosticsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.scrapedDesc
 	ch <- c.laserBiasCurrentDesc
 	ch <- c.laserBiasCurrentHighAlarmThresholdDesc
 	ch <- c.laserBiasCurrentLowAlarmThresholdDesc
@@ -171,6 +179,9 @@ func (c *interfaceDiagnosticsCollector) Describe(ch chan<- *prometheus.Desc) {
 
 	ch <- c.rxSignalAvgOpticalPowerDesc
 	ch <- c.rxSignalAvgOpticalPowerDbmDesc
+
+	ch <- c.flagDesc
+	ch <- collector.ComponentHealthyDesc
 }
 
 // Collect collects metrics from JunOS
@@ -213,6 +224,12 @@ func (c *interfaceDiagnosticsCollector) Collect(client *rpc.Client, ch chan<- pr
 			ch <- prometheus.MustNewConstMetric(c.rxSignalAvgOpticalPowerDbmDesc, prometheus.GaugeValue, d.RxSignalAvgOpticalPowerDbm, l...)
 		}
 
+		c.collectFlags(ch, d, l)
+		for _, lane := range d.Lanes {
+			c.collectFlags(ch, lane, l)
+		}
+		ch <- collector.ComponentHealthy(labelValues[0], collector.ComponentOptic, d.Name, !d.AlarmRaised())
+
 		var data []*InterfaceDiagnostics
 		if len(d.Lanes) > 0 {
 			data = d.Lanes
@@ -250,9 +267,22 @@ func (c *interfaceDiagnosticsCollector) Collect(client *rpc.Client, ch chan<- pr
 		}
 	}
 
+	ch <- prometheus.MustNewConstMetric(c.scrapedDesc, prometheus.GaugeValue, float64(len(diagnostics)), labelValues...)
+
 	return nil
 }
 
+func (c *interfaceDiagnosticsCollector) collectFlags(ch chan<- prometheus.Metric, d *InterfaceDiagnostics, labelValues []string) {
+	for name, raised := range d.Flags {
+		v := 0.0
+		if raised {
+			v = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.flagDesc, prometheus.GaugeValue, v, append(labelValues, d.Index, name)...)
+	}
+}
+
 func (c *interfaceDiagnosticsCollector) interfaceDiagnostics(client *rpc.Client) ([]*InterfaceDiagnostics, error) {
 	var x = InterfaceDiagnosticsRPC{}
 	err := client.RunCommandAndParse("show interfaces diagnostics optics", &x)
@@ -374,6 +404,8 @@ func interfaceDiagnosticsFromRPCResult(result InterfaceDiagnosticsRPC) []*Interf
 			LaserRxOpticalPowerLowAlarmThresholdDbm:  dbmStringToFloat(diag.Diagnostics.LaserRxOpticalPowerLowAlarmThresholdDbm),
 			LaserRxOpticalPowerHighWarnThresholdDbm:  dbmStringToFloat(diag.Diagnostics.LaserRxOpticalPowerHighWarnThresholdDbm),
 			LaserRxOpticalPowerLowWarnThresholdDbm:   dbmStringToFloat(diag.Diagnostics.LaserRxOpticalPowerLowWarnThresholdDbm),
+
+			Flags: flagsFromElements(diag.Diagnostics.Other),
 		}
 
 		if len(diag.Diagnostics.Lanes) > 0 {
@@ -386,6 +418,7 @@ func interfaceDiagnosticsFromRPCResult(result InterfaceDiagnosticsRPC) []*Interf
 					LaserOutputPowerDbm:    dbmStringToFloat(lane.LaserOutputPowerDbm),
 					LaserRxOpticalPower:    float64(lane.LaserRxOpticalPower),
 					LaserRxOpticalPowerDbm: dbmStringToFloat(lane.LaserRxOpticalPowerDbm),
+					Flags:                  flagsFromElements(lane.Other),
 				}
 
 				d.Lanes = append(d.Lanes, l)
@@ -398,6 +431,27 @@ func interfaceDiagnosticsFromRPCResult(result InterfaceDiagnosticsRPC) []*Interf
 	return diagnostics
 }
 
+// flagsFromElements returns the alarm and warning flags (elements ending on -alarm or -warn with value on/off)
+func flagsFromElements(elements []Element) map[string]bool {
+	flags := make(map[string]bool)
+
+	for _, e := range elements {
+		name := e.XMLName.Local
+		if !strings.HasSuffix(name, "-alarm") && !strings.HasSuffix(name, "-warn") {
+			continue
+		}
+
+		v := strings.TrimSpace(e.Value)
+		if v != "on" && v != "off" {
+			continue
+		}
+
+		flags[strings.Replace(name, "-", "_", -1)] = v == "on"
+	}
+
+	return flags
+}
+
 func dbmStringToFloat(value string) float64 {
 	f, err := strconv.ParseFloat(value, 64)
 	if err == nil {
@@ -1,9 +1,13 @@
 package interfacediagnostics
 
 import (
+	"encoding/xml"
 	"math"
 	"testing"
 
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -130,3 +134,88 @@ func TestInterfaceDiagnosticsFromRPCResult(t *testing.T) {
 	assert.Equal(t, float64(11), l.LaserRxOpticalPower)
 	assert.Equal(t, math.Inf(-1), l.LaserRxOpticalPowerDbm)
 }
+
+func TestFlagsFromXML(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/18.2R3/junos">
+    <interface-information xmlns="http://xml.juniper.net/junos/18.2R3/junos-interface" junos:style="normal">
+        <physical-interface>
+            <name>et-0/0/1</name>
+            <optics-diagnostics>
+                <module-temperature junos:celsius="32">32 degrees C / 90 degrees F</module-temperature>
+                <module-temperature-high-alarm>off</module-temperature-high-alarm>
+                <module-temperature-low-warn>off</module-temperature-low-warn>
+                <laser-rx-power-low-alarm-threshold>0.0355</laser-rx-power-low-alarm-threshold>
+                <optics-diagnostics-lane-values>
+                    <lane-index>0</lane-index>
+                    <laser-bias-current>6.120</laser-bias-current>
+                    <laser-rx-power-low-alarm>on</laser-rx-power-low-alarm>
+                    <tx-loss-of-signal-functionality-alarm>off</tx-loss-of-signal-functionality-alarm>
+                </optics-diagnostics-lane-values>
+            </optics-diagnostics>
+        </physical-interface>
+    </interface-information>
+</rpc-reply>`
+
+	var x InterfaceDiagnosticsRPC
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := interfaceDiagnosticsFromRPCResult(x)
+	assert.Len(t, d, 1)
+	assert.Equal(t, map[string]bool{
+		"module_temperature_high_alarm": false,
+		"module_temperature_low_warn":   false,
+	}, d[0].Flags)
+	assert.Equal(t, map[string]bool{
+		"laser_rx_power_low_alarm":              true,
+		"tx_loss_of_signal_functionality_alarm": false,
+	}, d[0].Lanes[0].Flags)
+}
+
+func TestAlarmRaised(t *testing.T) {
+	d := &InterfaceDiagnostics{
+		Flags: map[string]bool{
+			"laser_rx_power_low_warn":  true,
+			"laser_rx_power_low_alarm": false,
+		},
+	}
+	assert.False(t, d.AlarmRaised(), "warnings only")
+
+	d.Lanes = []*InterfaceDiagnostics{
+		{Index: "1", Flags: map[string]bool{"laser_bias_current_high_alarm": true}},
+	}
+	assert.True(t, d.AlarmRaised(), "alarm on lane")
+}
+
+func TestCollectFlags(t *testing.T) {
+	c := NewCollector(interfacelabels.NewDynamicLabels()).(*interfaceDiagnosticsCollector)
+	d := &InterfaceDiagnostics{
+		Index: "0",
+		Flags: map[string]bool{"laser_rx_power_low_alarm": true},
+	}
+
+	ch := make(chan prometheus.Metric, 1)
+	assert.NotPanics(t, func() {
+		c.collectFlags(ch, d, []string{"router1", "et-0/0/1"})
+	})
+
+	var m dto.Metric
+	err := (<-ch).Write(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make(map[string]string)
+	for _, l := range m.Label {
+		labels[l.GetName()] = l.GetValue()
+	}
+	assert.Equal(t, map[string]string{
+		"target": "router1",
+		"name":   "et-0/0/1",
+		"lane":   "0",
+		"flag":   "laser_rx_power_low_alarm",
+	}, labels)
+	assert.Equal(t, float64(1), m.GetGauge().GetValue())
+}
@@ -1,5 +1,7 @@
 package interfacediagnostics
 
+import "strings"
+
 type InterfaceDiagnostics struct {
 	Index                              string
 	Name                               string
@@ -47,5 +49,25 @@ type InterfaceDiagnostics struct {
 	RxSignalAvgOpticalPower         float64
 	RxSignalAvgOpticalPowerDbm      float64
 
+	// Flags are the alarm and warning flags raised by the module (e.g. laser_rx_power_low_alarm)
+	Flags map[string]bool
+
 	Lanes []*InterfaceDiagnostics
 }
+
+// AlarmRaised returns true if the module or any of its lanes raised an alarm flag (warnings are ignored)
+func (d *InterfaceDiagnostics) AlarmRaised() bool {
+	for name, raised := range d.Flags {
+		if raised && strings.HasSuffix(name, "_alarm") {
+			return true
+		}
+	}
+
+	for _, lane := range d.Lanes {
+		if lane.AlarmRaised() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,5 +1,7 @@
 package interfacediagnostics
 
+import "encoding/xml"
+
 type InterfaceDiagnosticsRPC struct {
 	Information struct {
 		Diagnostics []PhyDiagInterface `xml:"physical-interface"`
@@ -60,6 +62,8 @@ type PhyInterfaceDiagnostic struct {
 	NA string `xml:"optic-diagnostics-not-available"`
 
 	Lanes []LaneValue `xml:"optics-diagnostics-lane-values,omitempty"`
+
+	Other []Element `xml:",any"`
 }
 
 type Temperature struct {
@@ -73,4 +77,12 @@ type LaneValue struct {
 	LaserOutputPowerDbm    string  `xml:"laser-output-power-dbm,omitempty"`
 	LaserRxOpticalPower    float64 `xml:"laser-rx-optical-power,omitempty"`
 	LaserRxOpticalPowerDbm string  `xml:"laser-rx-optical-power-dbm,omitempty"`
+
+	Other []Element `xml:",any"`
+}
+
+// Element is an element not mapped explicitly (e.g. alarm and warning flags like laser-rx-power-low-alarm)
+type Element struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
 }
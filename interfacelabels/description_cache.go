@@ -0,0 +1,52 @@
+package interfacelabels
+
+import (
+	"sync"
+	"time"
+)
+
+var descriptions = &descriptionCache{entries: make(map[string]*descriptionEntry)}
+
+type descriptionEntry struct {
+	ifaces    []PhyInterface
+	timestamp time.Time
+}
+
+// descriptionCache keeps the interface descriptions of each device, so they are not retrieved on every scrape
+type descriptionCache struct {
+	refreshInterval time.Duration
+	entries         map[string]*descriptionEntry
+	mu              sync.Mutex
+}
+
+// SetRefreshInterval sets the interval in which interface descriptions of a device are retrieved again (0 = on every scrape)
+func SetRefreshInterval(d time.Duration) {
+	descriptions.mu.Lock()
+	defer descriptions.mu.Unlock()
+
+	descriptions.refreshInterval = d
+	descriptions.entries = make(map[string]*descriptionEntry)
+}
+
+func (c *descriptionCache) get(host string, now time.Time) ([]PhyInterface, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, found := c.entries[host]
+	if !found || now.Sub(e.timestamp) >= c.refreshInterval {
+		return nil, false
+	}
+
+	return e.ifaces, true
+}
+
+func (c *descriptionCache) set(host string, ifaces []PhyInterface, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refreshInterval == 0 {
+		return
+	}
+
+	c.entries[host] = &descriptionEntry{ifaces: ifaces, timestamp: now}
+}
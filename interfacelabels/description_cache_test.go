@@ -0,0 +1,31 @@
+package interfacelabels
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptionCache(t *testing.T) {
+	c := &descriptionCache{entries: make(map[string]*descriptionEntry)}
+	now := time.Now()
+	ifaces := []PhyInterface{{Name: "xe-0/0/0", Description: "[foo=x]"}}
+
+	c.set("device1", ifaces, now)
+	_, found := c.get("device1", now)
+	assert.False(t, found, "refresh on every scrape")
+
+	c.refreshInterval = 5 * time.Minute
+	c.set("device1", ifaces, now)
+
+	cached, found := c.get("device1", now.Add(time.Minute))
+	assert.True(t, found, "within refresh interval")
+	assert.Equal(t, ifaces, cached)
+
+	_, found = c.get("device1", now.Add(5*time.Minute))
+	assert.False(t, found, "refresh interval elapsed")
+
+	_, found = c.get("device2", now)
+	assert.False(t, found, "unknown device")
+}
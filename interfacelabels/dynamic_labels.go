@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/czerwonk/junos_exporter/connector"
 	"github.com/czerwonk/junos_exporter/rpc"
@@ -46,19 +47,28 @@ type interfaceLabel struct {
 
 // CollectDescriptions collects labels from descriptions
 func (l *DynamicLabels) CollectDescriptions(device *connector.Device, client *rpc.Client, ifDescReg *regexp.Regexp) error {
-	r := &InterfaceRPC{}
-	err := client.RunCommandAndParse("show interfaces descriptions", r)
-	if err != nil {
-		return errors.Wrap(err, "could not retrieve interface descriptions for "+device.Host)
+	ifaces, found := descriptions.get(device.Host, time.Now())
+	if !found {
+		r := &InterfaceRPC{}
+		err := client.RunCommandAndParse("show interfaces descriptions", r)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve interface descriptions for "+device.Host)
+		}
+
+		ifaces = r.Information.Interfaces
+		descriptions.set(device.Host, ifaces, time.Now())
 	}
 
-	l.parseDescriptions(device, r.Information.Interfaces, ifDescReg)
+	l.parseDescriptions(device, ifaces, ifDescReg)
 
 	return nil
 }
 
 // LabelNames returns the names for all dynamic labels
 func (l *DynamicLabels) LabelNames() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	names := make([]string, len(l.labelNames))
 
 	for k, v := range l.labelNames {
@@ -70,19 +80,26 @@ func (l *DynamicLabels) LabelNames() []string {
 
 // ValuesForInterface returns the values for all dynamic labels
 func (l *DynamicLabels) ValuesForInterface(device *connector.Device, ifaceName string) []string {
-	labels := make([]string, len(l.labelNames))
+	return l.AppendValuesForInterface(nil, device, ifaceName)
+}
 
-	k := interfaceKey{host: device.Host, ifaceName: ifaceName}
-	ifaceLabels, found := l.labels[k]
-	if !found {
-		return labels
+// AppendValuesForInterface appends the values for all dynamic labels to dst and returns the extended slice.
+// It allows building the label values of an interface without allocating if dst has sufficient capacity.
+func (l *DynamicLabels) AppendValuesForInterface(dst []string, device *connector.Device, ifaceName string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := len(dst)
+	for i := 0; i < len(l.labelNames); i++ {
+		dst = append(dst, "")
 	}
 
-	for _, la := range ifaceLabels {
-		labels[l.labelNames[la.name]] = la.value
+	k := interfaceKey{host: device.Host, ifaceName: ifaceName}
+	for _, la := range l.labels[k] {
+		dst[n+l.labelNames[la.name]] = la.value
 	}
 
-	return labels
+	return dst
 }
 
 func (l *DynamicLabels) parseDescriptions(device *connector.Device, ifaces []PhyInterface, ifDescReg *regexp.Regexp) {
@@ -92,15 +109,21 @@ func (l *DynamicLabels) parseDescriptions(device *connector.Device, ifaces []Phy
 	for _, in := range ifaces {
 		labels := l.parseDescription(in, ifDescReg)
 
+		// labels of previous descriptions are replaced, otherwise they would pile up on every refresh
+		k := interfaceKey{host: device.Host, ifaceName: in.Name}
+		if len(labels) == 0 {
+			delete(l.labels, k)
+			continue
+		}
+
 		for _, la := range labels {
 			if _, found := l.labelNames[la.name]; !found {
 				l.labelNames[la.name] = l.labelCount
 				l.labelCount++
 			}
-
-			k := interfaceKey{host: device.Host, ifaceName: in.Name}
-			l.labels[k] = append(l.labels[k], la)
 		}
+
+		l.labels[k] = labels
 	}
 }
 
@@ -69,3 +69,19 @@ func TestParseDescriptions(t *testing.T) {
 		assert.Equal(t, []string{"x", "y", "", "", "is"}, l.ValuesForInterface(d3, if3.Name), "Values if3")
 	})
 }
+
+func TestParseDescriptionsReplacesLabels(t *testing.T) {
+	l := NewDynamicLabels()
+	regex := regexp.MustCompile(`\[([^=\]]+)(=[^\]]+)?\]`)
+	d := &connector.Device{Host: "device1"}
+
+	l.parseDescriptions(d, []PhyInterface{{Name: "xe-0/0/0", Description: "[foo=x]"}}, regex)
+	l.parseDescriptions(d, []PhyInterface{{Name: "xe-0/0/0", Description: "[foo=y]"}}, regex)
+	assert.Equal(t, []string{"y"}, l.ValuesForInterface(d, "xe-0/0/0"))
+	assert.Len(t, l.labels[interfaceKey{host: d.Host, ifaceName: "xe-0/0/0"}], 1)
+
+	l.parseDescriptions(d, []PhyInterface{{Name: "xe-0/0/0", Description: "no labels"}}, regex)
+	assert.Equal(t, []string{""}, l.ValuesForInterface(d, "xe-0/0/0"))
+
+	assert.Equal(t, []string{"router1", ""}, l.AppendValuesForInterface([]string{"router1"}, d, "xe-0/0/1"), "prefix is kept")
+}
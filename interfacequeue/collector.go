@@ -10,10 +10,11 @@ import (
 
 const prefix = "junos_interface_queues_"
 
-// NewCollector creates an queue collector instance
-func NewCollector(labels *interfacelabels.DynamicLabels) collector.RPCCollector {
+// NewCollector creates an queue collector instance. When bufferOccupancy is set the peak buffer occupancy of the queues is exported (supported by QFX/EX).
+func NewCollector(labels *interfacelabels.DynamicLabels, bufferOccupancy bool) collector.RPCCollector {
 	c := &interfaceQueueCollector{
-		labels: labels,
+		labels:          labels,
+		bufferOccupancy: bufferOccupancy,
 	}
 	c.init()
 
@@ -41,6 +42,10 @@ type interfaceQueueCollector struct {
 	tailDropPackets      *prometheus.Desc
 	totalDropPackets     *prometheus.Desc
 	totalDropBytes       *prometheus.Desc
+
+	bufferOccupancy                bool
+	peakBufferOccupancyBytesDesc   *prometheus.Desc
+	peakBufferOccupancyPercentDesc *prometheus.Desc
 }
 
 // Name returns the name of the collector
@@ -72,6 +77,8 @@ func (c *interfaceQueueCollector) init() {
 	c.tailDropPackets = prometheus.NewDesc(prefix+"tail_drop_packets_count", "Number of tail droped packets", l, nil)
 	c.totalDropPackets = prometheus.NewDesc(prefix+"drop_packets_count", "Number of packets droped", l, nil)
 	c.totalDropBytes = prometheus.NewDesc(prefix+"drop_bytes_count", "Number of bytes droped", l, nil)
+	c.peakBufferOccupancyBytesDesc = prometheus.NewDesc(prefix+"peak_buffer_occupancy_bytes", "Peak buffer occupancy of the queue in bytes", l, nil)
+	c.peakBufferOccupancyPercentDesc = prometheus.NewDesc(prefix+"peak_buffer_occupancy_ratio", "Peak buffer occupancy of the queue (0-1 of the buffer available to the queue)", l, nil)
 }
 
 // Describe describes the metrics
@@ -95,6 +102,8 @@ func (c *interfaceQueueCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.tailDropPackets
 	ch <- c.totalDropBytes
 	ch <- c.totalDropPackets
+	ch <- c.peakBufferOccupancyBytesDesc
+	ch <- c.peakBufferOccupancyPercentDesc
 }
 
 // Collect collects metrics from JunOS
@@ -110,6 +119,38 @@ func (c *interfaceQueueCollector) Collect(client *rpc.Client, ch chan<- promethe
 		c.collectForInterface(iface, client.Device(), ch, labelValues)
 	}
 
+	if c.bufferOccupancy {
+		return c.collectBufferOccupancy(client, ch, labelValues)
+	}
+
+	return nil
+}
+
+func (c *interfaceQueueCollector) collectBufferOccupancy(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	q := InterfaceQueueRPC{}
+
+	err := client.RunCommandAndParse("show interfaces queue buffer-occupancy", &q)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range q.InterfaceInformation.Interfaces {
+		l := append(labelValues[:len(labelValues):len(labelValues)], iface.Name, iface.Description)
+		l = append(l, c.labels.ValuesForInterface(client.Device(), iface.Name)...)
+
+		for _, queue := range iface.QueueCounters.Queues {
+			ql := append(l, queue.Number)
+
+			if queue.PeakBufferOccupancyBytes != nil {
+				ch <- prometheus.MustNewConstMetric(c.peakBufferOccupancyBytesDesc, prometheus.GaugeValue, float64(*queue.PeakBufferOccupancyBytes), ql...)
+			}
+
+			if queue.PeakBufferOccupancyPercent != nil {
+				ch <- prometheus.MustNewConstMetric(c.peakBufferOccupancyPercentDesc, prometheus.GaugeValue, *queue.PeakBufferOccupancyPercent/100, ql...)
+			}
+		}
+	}
+
 	return nil
 }
 
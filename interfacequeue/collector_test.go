@@ -0,0 +1,12 @@
+package interfacequeue
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector/collectortest"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+)
+
+func TestCollectorGolden(t *testing.T) {
+	collectortest.AssertGolden(t, NewCollector(interfacelabels.NewDynamicLabels(), true), "qfx1")
+}
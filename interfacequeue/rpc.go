@@ -35,4 +35,8 @@ type Queue struct {
 	TailDropPackets      uint64 `xml:"queue-counters-tail-drop-packets"`
 	TotalDropPackets     uint64 `xml:"queue-counters-total-drop-packets"`
 	TotalDropBytes       uint64 `xml:"queue-counters-total-drop-bytes"`
+
+	// peak buffer occupancy (show interfaces queue buffer-occupancy)
+	PeakBufferOccupancyBytes   *uint64  `xml:"queue-counters-peak-buffer-occ-bytes"`
+	PeakBufferOccupancyPercent *float64 `xml:"queue-counters-peak-buffer-occ-percent"`
 }
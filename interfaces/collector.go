@@ -3,6 +3,8 @@ package interfaces
 import (
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/czerwonk/junos_exporter/collector"
 	"github.com/czerwonk/junos_exporter/connector"
@@ -15,42 +17,88 @@ const prefix = "junos_interface_"
 
 // Collector collects interface metrics
 type interfaceCollector struct {
-	labels                  *interfacelabels.DynamicLabels
-	receiveBytesDesc        *prometheus.Desc
-	receivePacketsDesc      *prometheus.Desc
-	receiveErrorsDesc       *prometheus.Desc
-	receiveDropsDesc        *prometheus.Desc
-	interfaceSpeedDesc      *prometheus.Desc
-	transmitBytesDesc       *prometheus.Desc
-	transmitPacketsDesc     *prometheus.Desc
-	transmitErrorsDesc      *prometheus.Desc
-	transmitDropsDesc       *prometheus.Desc
-	ipv6receiveBytesDesc    *prometheus.Desc
-	ipv6receivePacketsDesc  *prometheus.Desc
-	ipv6transmitBytesDesc   *prometheus.Desc
-	ipv6transmitPacketsDesc *prometheus.Desc
-	adminStatusDesc         *prometheus.Desc
-	operStatusDesc          *prometheus.Desc
-	errorStatusDesc         *prometheus.Desc
-	lastFlappedDesc         *prometheus.Desc
-	receiveUnicastsDesc     *prometheus.Desc
-	receiveBroadcastsDesc   *prometheus.Desc
-	receiveMulticastsDesc   *prometheus.Desc
-	receiveCrcErrorsDesc    *prometheus.Desc
-	transmitUnicastsDesc    *prometheus.Desc
-	transmitBroadcastsDesc  *prometheus.Desc
-	transmitMulticastsDesc  *prometheus.Desc
-	transmitCrcErrorsDesc   *prometheus.Desc
-	fecCcwCountDesc         *prometheus.Desc
-	fecNccwCountDesc        *prometheus.Desc
-	fecCcwErrorRateDesc     *prometheus.Desc
-	fecNccwErrorRateDesc    *prometheus.Desc
+	labels                         *interfacelabels.DynamicLabels
+	utilization                    bool
+	topN                           int
+	receiveBytesDesc               *prometheus.Desc
+	receivePacketsDesc             *prometheus.Desc
+	receiveErrorsDesc              *prometheus.Desc
+	receiveDropsDesc               *prometheus.Desc
+	interfaceSpeedDesc             *prometheus.Desc
+	transmitBytesDesc              *prometheus.Desc
+	transmitPacketsDesc            *prometheus.Desc
+	transmitErrorsDesc             *prometheus.Desc
+	transmitDropsDesc              *prometheus.Desc
+	ipv6receiveBytesDesc           *prometheus.Desc
+	ipv6receivePacketsDesc         *prometheus.Desc
+	ipv6transmitBytesDesc          *prometheus.Desc
+	ipv6transmitPacketsDesc        *prometheus.Desc
+	adminStatusDesc                *prometheus.Desc
+	operStatusDesc                 *prometheus.Desc
+	errorStatusDesc                *prometheus.Desc
+	lastFlappedDesc                *prometheus.Desc
+	receiveUnicastsDesc            *prometheus.Desc
+	receiveBroadcastsDesc          *prometheus.Desc
+	receiveMulticastsDesc          *prometheus.Desc
+	receiveCrcErrorsDesc           *prometheus.Desc
+	transmitUnicastsDesc           *prometheus.Desc
+	transmitBroadcastsDesc         *prometheus.Desc
+	transmitMulticastsDesc         *prometheus.Desc
+	transmitCrcErrorsDesc          *prometheus.Desc
+	fecCcwCountDesc                *prometheus.Desc
+	fecNccwCountDesc               *prometheus.Desc
+	fecCcwErrorRateDesc            *prometheus.Desc
+	fecNccwErrorRateDesc           *prometheus.Desc
+	receiveFramingErrorsDesc       *prometheus.Desc
+	receiveRuntsDesc               *prometheus.Desc
+	receiveGiantsDesc              *prometheus.Desc
+	receivePolicedDiscardsDesc     *prometheus.Desc
+	receiveL3IncompletesDesc       *prometheus.Desc
+	receiveL2ChannelErrorsDesc     *prometheus.Desc
+	receiveL2MismatchTimeoutsDesc  *prometheus.Desc
+	receiveFifoErrorsDesc          *prometheus.Desc
+	receiveResourceErrorsDesc      *prometheus.Desc
+	transmitCarrierTransitionsDesc *prometheus.Desc
+	transmitCollisionsDesc         *prometheus.Desc
+	transmitAgedPacketsDesc        *prometheus.Desc
+	transmitMtuErrorsDesc          *prometheus.Desc
+	transmitHsLinkCrcErrorsDesc    *prometheus.Desc
+	transmitFifoErrorsDesc         *prometheus.Desc
+	transmitResourceErrorsDesc     *prometheus.Desc
+	scrapedDesc                    *prometheus.Desc
+	removedDesc                    *prometheus.Desc
+	discontinuityDesc              *prometheus.Desc
+	utilizationDesc                *prometheus.Desc
+	descriptionInfoDesc            *prometheus.Desc
+	descriptionChangesDesc         *prometheus.Desc
+	counterSourceDesc              *prometheus.Desc
+	othersCountDesc                *prometheus.Desc
+	othersReceiveDesc              *prometheus.Desc
+	othersTransmitDesc             *prometheus.Desc
+	// system is the configuration hierarchy of the logical system or tenant system the interfaces are exported for (empty for all interfaces)
+	system string
 }
 
-// NewCollector creates a new collector
-func NewCollector(labels *interfacelabels.DynamicLabels) collector.RPCCollector {
+// labelBuffers holds the slices the label values of an interface are built in to avoid allocations per interface
+var labelBuffers = sync.Pool{
+	New: func() interface{} {
+		b := make([]string, 0, 32)
+		return &b
+	},
+}
+
+func init() {
+	collector.RegisterStates(prefix+"admin_up", map[string]int{"down": 0, "up": 1})
+	collector.RegisterStates(prefix+"up", map[string]int{"down": 0, "up": 1})
+}
+
+// NewCollector creates a new collector. When utilization is set the utilization of the physical interfaces since the previous collection is exported.
+// If topN is greater than 0 only the topN logical interfaces with the highest byte rate since the previous collection are exported.
+func NewCollector(labels *interfacelabels.DynamicLabels, utilization bool, topN int) collector.RPCCollector {
 	c := &interfaceCollector{
-		labels: labels,
+		labels:      labels,
+		utilization: utilization,
+		topN:        topN,
 	}
 	c.init()
 
@@ -63,7 +111,7 @@ func (*interfaceCollector) Name() string {
 }
 
 func (c *interfaceCollector) init() {
-	l := []string{"target", "name", "description", "mac"}
+	l := []string{"target", "name", "description", "mac", "parent", "unit", "vlan"}
 	l = append(l, c.labels.LabelNames()...)
 
 	c.receiveBytesDesc = prometheus.NewDesc(prefix+"receive_bytes", "Received data in bytes", l, nil)
@@ -95,6 +143,32 @@ func (c *interfaceCollector) init() {
 	c.fecNccwCountDesc = prometheus.NewDesc(prefix+"fec_nccw_count", "Number FEC Uncorrected Errors", l, nil)
 	c.fecCcwErrorRateDesc = prometheus.NewDesc(prefix+"fec_ccw_error_rate", "Number FEC Corrected Errors Rate", l, nil)
 	c.fecNccwErrorRateDesc = prometheus.NewDesc(prefix+"fec_nccw_error_rate", "Number FEC Uncorrected Errors Rate", l, nil)
+	c.receiveFramingErrorsDesc = prometheus.NewDesc(prefix+"receive_errors_framing_total", "Number of incoming packets with framing errors", l, nil)
+	c.receiveRuntsDesc = prometheus.NewDesc(prefix+"receive_errors_runts_total", "Number of incoming frames smaller than the runt threshold", l, nil)
+	c.receiveGiantsDesc = prometheus.NewDesc(prefix+"receive_errors_giants_total", "Number of incoming frames exceeding the giant threshold", l, nil)
+	c.receivePolicedDiscardsDesc = prometheus.NewDesc(prefix+"receive_drops_policed_total", "Number of incoming packets discarded by the policer (e.g. unknown protocols)", l, nil)
+	c.receiveL3IncompletesDesc = prometheus.NewDesc(prefix+"receive_errors_l3_incompletes_total", "Number of incoming packets failing layer 3 sanity checks", l, nil)
+	c.receiveL2ChannelErrorsDesc = prometheus.NewDesc(prefix+"receive_errors_l2_channel_total", "Number of incoming packets for which no layer 2 channel could be found", l, nil)
+	c.receiveL2MismatchTimeoutsDesc = prometheus.NewDesc(prefix+"receive_errors_l2_mismatch_timeouts_total", "Number of malformed or short incoming packets causing a layer 2 mismatch timeout", l, nil)
+	c.receiveFifoErrorsDesc = prometheus.NewDesc(prefix+"receive_errors_fifo_total", "Number of incoming FIFO errors", l, nil)
+	c.receiveResourceErrorsDesc = prometheus.NewDesc(prefix+"receive_errors_resource_total", "Number of incoming resource errors", l, nil)
+	c.transmitCarrierTransitionsDesc = prometheus.NewDesc(prefix+"transmit_carrier_transitions_total", "Number of times the interface went from down to up", l, nil)
+	c.transmitCollisionsDesc = prometheus.NewDesc(prefix+"transmit_errors_collisions_total", "Number of output collisions", l, nil)
+	c.transmitAgedPacketsDesc = prometheus.NewDesc(prefix+"transmit_drops_aged_total", "Number of outgoing packets aged out in shared packet memory", l, nil)
+	c.transmitMtuErrorsDesc = prometheus.NewDesc(prefix+"transmit_errors_mtu_total", "Number of outgoing packets exceeding the MTU", l, nil)
+	c.transmitHsLinkCrcErrorsDesc = prometheus.NewDesc(prefix+"transmit_errors_hs_link_crc_total", "Number of CRC errors on the high speed links between ASICs", l, nil)
+	c.transmitFifoErrorsDesc = prometheus.NewDesc(prefix+"transmit_errors_fifo_total", "Number of outgoing FIFO errors", l, nil)
+	c.transmitResourceErrorsDesc = prometheus.NewDesc(prefix+"transmit_errors_resource_total", "Number of outgoing resource errors", l, nil)
+	c.scrapedDesc = prometheus.NewDesc("junos_interfaces_scraped", "Number of interfaces (physical and logical) found in the output of the device", []string{"target"}, nil)
+	c.removedDesc = prometheus.NewDesc("junos_interfaces_removed_total", "Number of interfaces (physical and logical) disappeared from the output of the device since the exporter started", []string{"target"}, nil)
+	c.utilizationDesc = prometheus.NewDesc(prefix+"utilization_ratio", "Utilization of the interface speed since the previous collection (0-1)", append(l[:len(l):len(l)], "direction"), nil)
+	c.descriptionInfoDesc = prometheus.NewDesc(prefix+"description_info", "Current description of the interface", []string{"target", "name", "description"}, nil)
+	c.descriptionChangesDesc = prometheus.NewDesc(prefix+"description_changes_total", "Number of changes of the interface description observed by the exporter", []string{"target", "name"}, nil)
+	c.counterSourceDesc = prometheus.NewDesc(prefix+"counter_source_info", "Source of the traffic counters of the interface (traffic, lag, transit_local or none)", append(l[:len(l):len(l)], "source"), nil)
+	c.othersCountDesc = prometheus.NewDesc(prefix+"others_count", "Number of logical interfaces not exported because they are not among the busiest (top N mode)", []string{"target"}, nil)
+	c.othersReceiveDesc = prometheus.NewDesc(prefix+"others_receive_bytes_per_second", "Received bytes per second of all logical interfaces not exported since the previous collection (top N mode)", []string{"target"}, nil)
+	c.othersTransmitDesc = prometheus.NewDesc(prefix+"others_transmit_bytes_per_second", "Transmitted bytes per second of all logical interfaces not exported since the previous collection (top N mode)", []string{"target"}, nil)
+	c.discontinuityDesc = prometheus.NewDesc(prefix+"counter_discontinuity_total", "Number of times the counters of the interface were reset (e.g. device reboot, cleared statistics) or the SNMP index of the interface changed", append(l[:len(l):len(l)], "reason"), nil)
 }
 
 // Describe describes the metrics
@@ -128,6 +202,32 @@ func (c *interfaceCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.fecNccwCountDesc
 	ch <- c.fecCcwErrorRateDesc
 	ch <- c.fecNccwErrorRateDesc
+	ch <- c.receiveFramingErrorsDesc
+	ch <- c.receiveRuntsDesc
+	ch <- c.receiveGiantsDesc
+	ch <- c.receivePolicedDiscardsDesc
+	ch <- c.receiveL3IncompletesDesc
+	ch <- c.receiveL2ChannelErrorsDesc
+	ch <- c.receiveL2MismatchTimeoutsDesc
+	ch <- c.receiveFifoErrorsDesc
+	ch <- c.receiveResourceErrorsDesc
+	ch <- c.transmitCarrierTransitionsDesc
+	ch <- c.transmitCollisionsDesc
+	ch <- c.transmitAgedPacketsDesc
+	ch <- c.transmitMtuErrorsDesc
+	ch <- c.transmitHsLinkCrcErrorsDesc
+	ch <- c.transmitFifoErrorsDesc
+	ch <- c.transmitResourceErrorsDesc
+	ch <- c.scrapedDesc
+	ch <- c.removedDesc
+	ch <- c.discontinuityDesc
+	ch <- c.utilizationDesc
+	ch <- c.descriptionInfoDesc
+	ch <- c.descriptionChangesDesc
+	ch <- c.counterSourceDesc
+	ch <- c.othersCountDesc
+	ch <- c.othersReceiveDesc
+	ch <- c.othersTransmitDesc
 }
 
 // Collect collects metrics from JunOS
@@ -137,10 +237,46 @@ func (c *interfaceCollector) Collect(client *rpc.Client, ch chan<- prometheus.Me
 		return err
 	}
 
-	for _, s := range stats {
-		c.collectForInterface(s, client.Device(), ch, labelValues)
+	target := strings.Join(labelValues, ",")
+	if c.system != "" {
+		stats, err = c.systemInterfaces(client, stats)
+		if err != nil {
+			return err
+		}
+
+		// the trackers must not mix the interfaces of the system with those of the master
+		target += "," + c.system
+	}
+
+	d, shifted := discontinuities.update(target, stats)
+	if shifted && indexShiftHandler != nil {
+		indexShiftHandler(labelValues[0])
+	}
+	dc := descriptions.update(target, stats)
+	removed := removals.update(target, stats)
+
+	var r map[string]*byteRates
+	if c.utilization {
+		r = rates.update(target, stats, time.Now())
+	}
+
+	exported := stats
+	if c.topN > 0 {
+		var others *otherInterfaces
+		exported, others = selectTop(stats, logicalRates.update(target, stats, time.Now()), c.topN)
+
+		ch <- prometheus.MustNewConstMetric(c.othersCountDesc, prometheus.GaugeValue, float64(others.count), labelValues...)
+		ch <- prometheus.MustNewConstMetric(c.othersReceiveDesc, prometheus.GaugeValue, others.receive, labelValues...)
+		ch <- prometheus.MustNewConstMetric(c.othersTransmitDesc, prometheus.GaugeValue, others.transmit, labelValues...)
+	}
+
+	for _, s := range exported {
+		c.collectForInterface(s, d[s.Name], dc[s.Name], r[s.Name], client.Device(), ch, labelValues)
 	}
 
+	ch <- prometheus.MustNewConstMetric(c.scrapedDesc, prometheus.GaugeValue, float64(len(stats)), labelValues...)
+	ch <- prometheus.MustNewConstMetric(c.removedDesc, prometheus.CounterValue, float64(removed), labelValues...)
+
 	return nil
 }
 
@@ -154,39 +290,57 @@ func (c *interfaceCollector) interfaceStats(client *rpc.Client) ([]*InterfaceSta
 	stats := make([]*InterfaceStats, 0)
 	for _, phy := range x.Information.Interfaces {
 		s := &InterfaceStats{
-			IsPhysical:          true,
-			Name:                phy.Name,
-			AdminStatus:         phy.AdminStatus == "up",
-			OperStatus:          phy.OperStatus == "up",
-			ErrorStatus:         !(phy.AdminStatus == phy.OperStatus),
-			Description:         phy.Description,
-			Mac:                 phy.MacAddress,
-			ReceiveDrops:        float64(phy.InputErrors.Drops),
-			ReceiveErrors:       float64(phy.InputErrors.Errors),
-			ReceiveBytes:        float64(phy.Stats.InputBytes),
-			ReceivePackets:      float64(phy.Stats.InputPackets),
-			Speed:               phy.Speed,
-			TransmitDrops:       float64(phy.OutputErrors.Drops),
-			TransmitErrors:      float64(phy.OutputErrors.Errors),
-			TransmitBytes:       float64(phy.Stats.OutputBytes),
-			TransmitPackets:     float64(phy.Stats.OutputPackets),
-			IPv6ReceiveBytes:    float64(phy.Stats.IPv6Traffic.InputBytes),
-			IPv6ReceivePackets:  float64(phy.Stats.IPv6Traffic.InputPackets),
-			IPv6TransmitBytes:   float64(phy.Stats.IPv6Traffic.OutputBytes),
-			IPv6TransmitPackets: float64(phy.Stats.IPv6Traffic.OutputPackets),
-			LastFlapped:         -1,
-			ReceiveUnicasts:     float64(phy.EthernetMacStatistics.InputUnicasts),
-			ReceiveBroadcasts:   float64(phy.EthernetMacStatistics.InputBroadcasts),
-			ReceiveMulticasts:   float64(phy.EthernetMacStatistics.InputMulticasts),
-			ReceiveCrcErrors:    float64(phy.EthernetMacStatistics.InputCrcErrors),
-			TransmitUnicasts:    float64(phy.EthernetMacStatistics.OutputUnicasts),
-			TransmitBroadcasts:  float64(phy.EthernetMacStatistics.OutputBroadcasts),
-			TransmitMulticasts:  float64(phy.EthernetMacStatistics.OutputMulticasts),
-			TransmitCrcErrors:   float64(phy.EthernetMacStatistics.OutputCrcErrors),
-			FecCcwCount:         float64(phy.EthernetFecStatistics.NumberfecCcwCount),
-			FecNccwCount:        float64(phy.EthernetFecStatistics.NumberfecNccwCount),
-			FecCcwErrorRate:     float64(phy.EthernetFecStatistics.NumberfecCcwErrorRate),
-			FecNccwErrorRate:    float64(phy.EthernetFecStatistics.NumberfecNccwErrorRate),
+			IsPhysical:                 true,
+			Name:                       phy.Name,
+			CounterSource:              counterSourceTraffic,
+			SnmpIndex:                  phy.SnmpIndex,
+			AdminStatus:                phy.AdminStatus == "up",
+			OperStatus:                 phy.OperStatus == "up",
+			ErrorStatus:                !(phy.AdminStatus == phy.OperStatus),
+			Description:                phy.Description,
+			Mac:                        phy.MacAddress,
+			ReceiveDrops:               float64(phy.InputErrors.Drops),
+			ReceiveErrors:              float64(phy.InputErrors.Errors),
+			ReceiveBytes:               float64(phy.Stats.InputBytes),
+			ReceivePackets:             float64(phy.Stats.InputPackets),
+			Speed:                      phy.Speed,
+			TransmitDrops:              float64(phy.OutputErrors.Drops),
+			TransmitErrors:             float64(phy.OutputErrors.Errors),
+			TransmitBytes:              float64(phy.Stats.OutputBytes),
+			TransmitPackets:            float64(phy.Stats.OutputPackets),
+			IPv6ReceiveBytes:           float64(phy.Stats.IPv6Traffic.InputBytes),
+			IPv6ReceivePackets:         float64(phy.Stats.IPv6Traffic.InputPackets),
+			IPv6TransmitBytes:          float64(phy.Stats.IPv6Traffic.OutputBytes),
+			IPv6TransmitPackets:        float64(phy.Stats.IPv6Traffic.OutputPackets),
+			LastFlapped:                -1,
+			ReceiveUnicasts:            float64(phy.EthernetMacStatistics.InputUnicasts),
+			ReceiveBroadcasts:          float64(phy.EthernetMacStatistics.InputBroadcasts),
+			ReceiveMulticasts:          float64(phy.EthernetMacStatistics.InputMulticasts),
+			ReceiveCrcErrors:           float64(phy.EthernetMacStatistics.InputCrcErrors),
+			TransmitUnicasts:           float64(phy.EthernetMacStatistics.OutputUnicasts),
+			TransmitBroadcasts:         float64(phy.EthernetMacStatistics.OutputBroadcasts),
+			TransmitMulticasts:         float64(phy.EthernetMacStatistics.OutputMulticasts),
+			TransmitCrcErrors:          float64(phy.EthernetMacStatistics.OutputCrcErrors),
+			FecCcwCount:                float64(phy.EthernetFecStatistics.NumberfecCcwCount),
+			FecNccwCount:               float64(phy.EthernetFecStatistics.NumberfecNccwCount),
+			FecCcwErrorRate:            float64(phy.EthernetFecStatistics.NumberfecCcwErrorRate),
+			FecNccwErrorRate:           float64(phy.EthernetFecStatistics.NumberfecNccwErrorRate),
+			ReceiveFramingErrors:       float64(phy.InputErrors.FramingErrors),
+			ReceiveRunts:               float64(phy.InputErrors.Runts),
+			ReceiveGiants:              float64(phy.InputErrors.Giants),
+			ReceivePolicedDiscards:     float64(phy.InputErrors.PolicedDiscards),
+			ReceiveL3Incompletes:       float64(phy.InputErrors.L3Incompletes),
+			ReceiveL2ChannelErrors:     float64(phy.InputErrors.L2ChannelErrors),
+			ReceiveL2MismatchTimeouts:  float64(phy.InputErrors.L2MismatchTimeouts),
+			ReceiveFifoErrors:          float64(phy.InputErrors.FifoErrors),
+			ReceiveResourceErrors:      float64(phy.InputErrors.ResourceErrors),
+			TransmitCarrierTransitions: float64(phy.OutputErrors.CarrierTransitions),
+			TransmitCollisions:         float64(phy.OutputErrors.Collisions),
+			TransmitAgedPackets:        float64(phy.OutputErrors.AgedPackets),
+			TransmitMtuErrors:          float64(phy.OutputErrors.MtuErrors),
+			TransmitHsLinkCrcErrors:    float64(phy.OutputErrors.HsLinkCrcErrors),
+			TransmitFifoErrors:         float64(phy.OutputErrors.FifoErrors),
+			TransmitResourceErrors:     float64(phy.OutputErrors.ResourceErrors),
 		}
 
 		if phy.InterfaceFlapped.Value != "Never" {
@@ -196,15 +350,15 @@ func (c *interfaceCollector) interfaceStats(client *rpc.Client) ([]*InterfaceSta
 		stats = append(stats, s)
 
 		for _, log := range phy.LogicalInterfaces {
-			var s TrafficStat
-			if (log.Stats != TrafficStat{}) {
-				s = log.Stats
-			} else {
-				s = log.LagStats.Stats
-			}
+			s, source := logicalTrafficStats(log)
 			sl := &InterfaceStats{
 				IsPhysical:          false,
 				Name:                log.Name,
+				CounterSource:       source,
+				Parent:              phy.Name,
+				Unit:                unitFromName(log.Name),
+				Vlan:                vlanFromLinkAddress(log.LinkAddress),
+				SnmpIndex:           log.SnmpIndex,
 				Description:         log.Description,
 				Mac:                 phy.MacAddress,
 				ReceiveBytes:        float64(s.InputBytes),
@@ -224,10 +378,27 @@ func (c *interfaceCollector) interfaceStats(client *rpc.Client) ([]*InterfaceSta
 	return stats, nil
 }
 
-func (c *interfaceCollector) collectForInterface(s *InterfaceStats, device *connector.Device, ch chan<- prometheus.Metric, labelValues []string) {
-	l := append(labelValues, []string{s.Name, s.Description, s.Mac}...)
-	l = append(l, c.labels.ValuesForInterface(device, s.Name)...)
+func (c *interfaceCollector) collectForInterface(s *InterfaceStats, d *discontinuityCounts, descriptionChanges uint64, r *byteRates, device *connector.Device, ch chan<- prometheus.Metric, labelValues []string) {
+	// label values are copied when creating a metric, so the buffer can be reused for the next interface
+	buf := labelBuffers.Get().(*[]string)
+	defer labelBuffers.Put(buf)
 
+	l := append((*buf)[:0], labelValues...)
+	l = append(l, s.Name, s.Description, s.Mac, s.Parent, s.Unit, s.Vlan)
+	l = c.labels.AppendValuesForInterface(l, device, s.Name)
+	// reserve space for the additional label of discontinuities, counter source and utilization
+	l = append(l, "")[:len(l)]
+	*buf = l
+
+	ch <- prometheus.MustNewConstMetric(c.descriptionInfoDesc, prometheus.GaugeValue, 1, l[:len(labelValues)+2]...)
+	ch <- prometheus.MustNewConstMetric(c.descriptionChangesDesc, prometheus.CounterValue, float64(descriptionChanges), l[:len(labelValues)+1]...)
+
+	if d != nil {
+		ch <- prometheus.MustNewConstMetric(c.discontinuityDesc, prometheus.CounterValue, float64(d.counterResets), append(l, "counter_reset")...)
+		ch <- prometheus.MustNewConstMetric(c.discontinuityDesc, prometheus.CounterValue, float64(d.indexChanges), append(l, "index_changed")...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.counterSourceDesc, prometheus.GaugeValue, 1, append(l, s.CounterSource)...)
 	ch <- prometheus.MustNewConstMetric(c.receiveBytesDesc, prometheus.CounterValue, s.ReceiveBytes, l...)
 	ch <- prometheus.MustNewConstMetric(c.receivePacketsDesc, prometheus.CounterValue, s.ReceivePackets, l...)
 	ch <- prometheus.MustNewConstMetric(c.transmitBytesDesc, prometheus.CounterValue, s.TransmitBytes, l...)
@@ -284,6 +455,11 @@ func (c *interfaceCollector) collectForInterface(s *InterfaceStats, device *conn
 		ch <- prometheus.MustNewConstMetric(c.receiveDropsDesc, prometheus.CounterValue, s.ReceiveDrops, l...)
 		ch <- prometheus.MustNewConstMetric(c.interfaceSpeedDesc, prometheus.GaugeValue, float64(sp64), l...)
 
+		if r != nil && sp64 > 0 {
+			ch <- prometheus.MustNewConstMetric(c.utilizationDesc, prometheus.GaugeValue, r.receive*8/sp64, append(l, "receive")...)
+			ch <- prometheus.MustNewConstMetric(c.utilizationDesc, prometheus.GaugeValue, r.transmit*8/sp64, append(l, "transmit")...)
+		}
+
 		if s.LastFlapped != 0 {
 			ch <- prometheus.MustNewConstMetric(c.lastFlappedDesc, prometheus.GaugeValue, s.LastFlapped, l...)
 		}
@@ -300,5 +476,21 @@ func (c *interfaceCollector) collectForInterface(s *InterfaceStats, device *conn
 		ch <- prometheus.MustNewConstMetric(c.fecNccwCountDesc, prometheus.CounterValue, s.FecNccwCount, l...)
 		ch <- prometheus.MustNewConstMetric(c.fecCcwErrorRateDesc, prometheus.CounterValue, s.FecCcwErrorRate, l...)
 		ch <- prometheus.MustNewConstMetric(c.fecNccwErrorRateDesc, prometheus.CounterValue, s.FecNccwErrorRate, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveFramingErrorsDesc, prometheus.CounterValue, s.ReceiveFramingErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveRuntsDesc, prometheus.CounterValue, s.ReceiveRunts, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveGiantsDesc, prometheus.CounterValue, s.ReceiveGiants, l...)
+		ch <- prometheus.MustNewConstMetric(c.receivePolicedDiscardsDesc, prometheus.CounterValue, s.ReceivePolicedDiscards, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveL3IncompletesDesc, prometheus.CounterValue, s.ReceiveL3Incompletes, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveL2ChannelErrorsDesc, prometheus.CounterValue, s.ReceiveL2ChannelErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveL2MismatchTimeoutsDesc, prometheus.CounterValue, s.ReceiveL2MismatchTimeouts, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveFifoErrorsDesc, prometheus.CounterValue, s.ReceiveFifoErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.receiveResourceErrorsDesc, prometheus.CounterValue, s.ReceiveResourceErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitCarrierTransitionsDesc, prometheus.CounterValue, s.TransmitCarrierTransitions, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitCollisionsDesc, prometheus.CounterValue, s.TransmitCollisions, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitAgedPacketsDesc, prometheus.CounterValue, s.TransmitAgedPackets, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitMtuErrorsDesc, prometheus.CounterValue, s.TransmitMtuErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitHsLinkCrcErrorsDesc, prometheus.CounterValue, s.TransmitHsLinkCrcErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitFifoErrorsDesc, prometheus.CounterValue, s.TransmitFifoErrors, l...)
+		ch <- prometheus.MustNewConstMetric(c.transmitResourceErrorsDesc, prometheus.CounterValue, s.TransmitResourceErrors, l...)
 	}
 }
@@ -0,0 +1,103 @@
+package interfaces
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/dump"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectForInterfaceDoesNotModifyLabelValues(t *testing.T) {
+	c := NewCollector(interfacelabels.NewDynamicLabels(), false, 0).(*interfaceCollector)
+	device := &connector.Device{Host: "router1"}
+
+	// spare capacity would allow appending to the backing array shared with the caller
+	labelValues := make([]string, 1, 10)
+	labelValues[0] = "router1"
+	backing := labelValues[:10]
+
+	ch := make(chan prometheus.Metric, 100)
+	c.collectForInterface(&InterfaceStats{Name: "xe-0/0/0", Description: "uplink", IsPhysical: true}, &discontinuityCounts{}, 2, nil, device, ch, labelValues)
+	c.collectForInterface(&InterfaceStats{Name: "xe-0/0/1", Description: "customer"}, nil, 0, nil, device, ch, labelValues)
+	close(ch)
+
+	for _, v := range backing[1:] {
+		assert.Empty(t, v)
+	}
+
+	interfaces := make(map[string]bool)
+	for m := range ch {
+		pb := &dto.Metric{}
+		assert.NoError(t, m.Write(pb))
+		for _, l := range pb.Label {
+			if l.GetName() == "name" {
+				interfaces[l.GetValue()] = true
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{"xe-0/0/0": true, "xe-0/0/1": true}, interfaces)
+}
+
+func TestDescLabelsWithDynamicLabels(t *testing.T) {
+	device := &connector.Device{Host: "router1"}
+	labels := interfacelabels.NewDynamicLabels()
+	err := labels.CollectDescriptions(device, rpc.NewClient(dump.NewReplay(device, "testdata")), regexp.MustCompile(`\[([^=\]]+)(=[^\]]+)?\]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCollector(labels, true, 0).(*interfaceCollector)
+
+	// the descs share the base labels, each one has to end with its own additional label
+	for desc, label := range map[*prometheus.Desc]string{
+		c.utilizationDesc:   "direction",
+		c.counterSourceDesc: "source",
+		c.discontinuityDesc: "reason",
+	} {
+		assert.True(t, strings.Contains(desc.String(), "site "+label+"]"), desc.String())
+	}
+}
+
+func BenchmarkCollectForInterface(b *testing.B) {
+	c := NewCollector(interfacelabels.NewDynamicLabels(), false, 0).(*interfaceCollector)
+	device := &connector.Device{Host: "router1"}
+	labelValues := []string{"router1"}
+
+	stats := make([]*InterfaceStats, 10000)
+	for i := range stats {
+		stats[i] = &InterfaceStats{
+			Name:        fmt.Sprintf("xe-%d/%d/%d.%d", i/1000, i/100%10, i%100, i),
+			Description: fmt.Sprintf("customer %d", i),
+			IsPhysical:  i%10 == 0,
+			Speed:       "10Gbps",
+		}
+	}
+
+	ch := make(chan prometheus.Metric, 1024)
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range stats {
+			c.collectForInterface(s, nil, 0, nil, device, ch, labelValues)
+		}
+	}
+	b.StopTimer()
+
+	close(ch)
+	<-done
+}
@@ -0,0 +1,46 @@
+package interfaces
+
+const (
+	counterSourceTraffic      = "traffic"
+	counterSourceLag          = "lag"
+	counterSourceTransitLocal = "transit_local"
+	counterSourceNone         = "none"
+)
+
+// logicalTrafficStats selects the source of the traffic counters of a logical interface.
+// Depending on platform and interface type only some of the statistics are populated (e.g. units of aggregated interfaces only report LAG statistics),
+// so the first populated source is used: total traffic statistics, LAG bundle statistics or the sum of transit and local statistics.
+func logicalTrafficStats(l LogInterface) (TrafficStat, string) {
+	if populated(l.Stats) {
+		return l.Stats, counterSourceTraffic
+	}
+
+	if populated(l.LagStats.Stats) {
+		return l.LagStats.Stats, counterSourceLag
+	}
+
+	if populated(l.TransitStats) || populated(l.LocalStats) {
+		return sumTrafficStats(l.TransitStats, l.LocalStats), counterSourceTransitLocal
+	}
+
+	return TrafficStat{}, counterSourceNone
+}
+
+func populated(s TrafficStat) bool {
+	return s != TrafficStat{}
+}
+
+func sumTrafficStats(a, b TrafficStat) TrafficStat {
+	return TrafficStat{
+		InputBytes:    a.InputBytes + b.InputBytes,
+		InputPackets:  a.InputPackets + b.InputPackets,
+		OutputBytes:   a.OutputBytes + b.OutputBytes,
+		OutputPackets: a.OutputPackets + b.OutputPackets,
+		IPv6Traffic: IPv6Stat{
+			InputBytes:    a.IPv6Traffic.InputBytes + b.IPv6Traffic.InputBytes,
+			InputPackets:  a.IPv6Traffic.InputPackets + b.IPv6Traffic.InputPackets,
+			OutputBytes:   a.IPv6Traffic.OutputBytes + b.IPv6Traffic.OutputBytes,
+			OutputPackets: a.IPv6Traffic.OutputPackets + b.IPv6Traffic.OutputPackets,
+		},
+	}
+}
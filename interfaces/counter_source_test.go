@@ -0,0 +1,77 @@
+package interfaces
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalTrafficStats(t *testing.T) {
+	body := `<rpc-reply>
+  <interface-information>
+    <physical-interface>
+      <name>ae0</name>
+      <logical-interface>
+        <name>ae0.0</name>
+        <traffic-statistics>
+          <input-bytes>0</input-bytes>
+        </traffic-statistics>
+        <lag-traffic-statistics>
+          <lag-bundle>
+            <input-bytes>1000</input-bytes>
+            <input-packets>10</input-packets>
+          </lag-bundle>
+        </lag-traffic-statistics>
+      </logical-interface>
+      <logical-interface>
+        <name>ae0.100</name>
+        <transit-traffic-statistics>
+          <input-bytes>500</input-bytes>
+          <output-bytes>200</output-bytes>
+        </transit-traffic-statistics>
+        <local-traffic-statistics>
+          <input-bytes>50</input-bytes>
+          <output-bytes>20</output-bytes>
+        </local-traffic-statistics>
+      </logical-interface>
+      <logical-interface>
+        <name>ae0.200</name>
+        <traffic-statistics>
+          <input-bytes>300</input-bytes>
+        </traffic-statistics>
+        <transit-traffic-statistics>
+          <input-bytes>250</input-bytes>
+        </transit-traffic-statistics>
+      </logical-interface>
+      <logical-interface>
+        <name>ae0.32767</name>
+      </logical-interface>
+    </physical-interface>
+  </interface-information>
+</rpc-reply>`
+
+	x := InterfaceRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	units := x.Information.Interfaces[0].LogicalInterfaces
+
+	s, source := logicalTrafficStats(units[0])
+	assert.Equal(t, counterSourceLag, source, units[0].Name)
+	assert.Equal(t, uint64(1000), s.InputBytes, units[0].Name)
+
+	s, source = logicalTrafficStats(units[1])
+	assert.Equal(t, counterSourceTransitLocal, source, units[1].Name)
+	assert.Equal(t, uint64(550), s.InputBytes, units[1].Name)
+	assert.Equal(t, uint64(220), s.OutputBytes, units[1].Name)
+
+	s, source = logicalTrafficStats(units[2])
+	assert.Equal(t, counterSourceTraffic, source, units[2].Name)
+	assert.Equal(t, uint64(300), s.InputBytes, units[2].Name)
+
+	_, source = logicalTrafficStats(units[3])
+	assert.Equal(t, counterSourceNone, source, units[3].Name)
+}
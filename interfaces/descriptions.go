@@ -0,0 +1,51 @@
+package interfaces
+
+import "sync"
+
+// descriptions keeps track of changes of the interface descriptions between scrapes
+var descriptions = newDescriptionTracker()
+
+type descriptionState struct {
+	description string
+	changes     uint64
+}
+
+type descriptionTracker struct {
+	targets map[string]map[string]*descriptionState
+	mu      sync.Mutex
+}
+
+func newDescriptionTracker() *descriptionTracker {
+	return &descriptionTracker{
+		targets: make(map[string]map[string]*descriptionState),
+	}
+}
+
+// update compares the descriptions with the ones of the previous scrape of the target and returns the number of changes observed by interface name
+func (t *descriptionTracker) update(target string, stats []*InterfaceStats) map[string]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.targets[target]
+	current := make(map[string]*descriptionState, len(stats))
+	result := make(map[string]uint64, len(stats))
+
+	for _, s := range stats {
+		st := &descriptionState{description: s.Description}
+
+		if p, found := prev[s.Name]; found {
+			st.changes = p.changes
+
+			if p.description != st.description {
+				st.changes++
+			}
+		}
+
+		current[s.Name] = st
+		result[s.Name] = st.changes
+	}
+
+	t.targets[target] = current
+
+	return result
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptionChanges(t *testing.T) {
+	tr := newDescriptionTracker()
+
+	c := tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", Description: "customer-a"},
+		{Name: "xe-0/0/1", Description: "customer-b"},
+	})
+	assert.Equal(t, map[string]uint64{"xe-0/0/0": 0, "xe-0/0/1": 0}, c)
+
+	c = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", Description: "customer-c"},
+		{Name: "xe-0/0/1", Description: "customer-b"},
+	})
+	assert.Equal(t, map[string]uint64{"xe-0/0/0": 1, "xe-0/0/1": 0}, c, "changed")
+
+	c = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", Description: ""},
+	})
+	assert.Equal(t, map[string]uint64{"xe-0/0/0": 2}, c, "removed description")
+
+	c = tr.update("router2", []*InterfaceStats{
+		{Name: "xe-0/0/0", Description: "uplink"},
+	})
+	assert.Equal(t, map[string]uint64{"xe-0/0/0": 0}, c, "targets are tracked separately")
+}
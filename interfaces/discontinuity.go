@@ -0,0 +1,87 @@
+package interfaces
+
+import "sync"
+
+// discontinuities keeps track of counter discontinuities between scrapes.
+// Collectors are created per scrape, so the state has to outlive the collector instance.
+var discontinuities = newDiscontinuityTracker()
+
+var indexShiftHandler func(target string)
+
+// SetIndexShiftHandler sets the function called when SNMP indexes of interfaces of a target changed (e.g. renumbered after an FPC restart).
+// It allows invalidating cached results depending on the interfaces of the target.
+func SetIndexShiftHandler(f func(target string)) {
+	indexShiftHandler = f
+}
+
+type discontinuityCounts struct {
+	counterResets uint64
+	indexChanges  uint64
+}
+
+type interfaceState struct {
+	snmpIndex uint64
+	counters  [4]float64
+	counts    *discontinuityCounts
+}
+
+type discontinuityTracker struct {
+	targets map[string]map[string]*interfaceState
+	mu      sync.Mutex
+}
+
+func newDiscontinuityTracker() *discontinuityTracker {
+	return &discontinuityTracker{
+		targets: make(map[string]map[string]*interfaceState),
+	}
+}
+
+// update compares the stats with the ones of the previous scrape of the target and returns the discontinuities by interface name
+// and whether the SNMP index of any interface changed since the previous scrape.
+// A decreasing counter indicates a reset (e.g. device reboot or cleared statistics), a changed SNMP index indicates that the name is used by a different interface now.
+func (t *discontinuityTracker) update(target string, stats []*InterfaceStats) (map[string]*discontinuityCounts, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.targets[target]
+	current := make(map[string]*interfaceState, len(stats))
+	result := make(map[string]*discontinuityCounts, len(stats))
+	shifted := false
+
+	for _, s := range stats {
+		st := &interfaceState{
+			snmpIndex: s.SnmpIndex,
+			counters:  [4]float64{s.ReceiveBytes, s.ReceivePackets, s.TransmitBytes, s.TransmitPackets},
+			counts:    &discontinuityCounts{},
+		}
+
+		if p, found := prev[s.Name]; found {
+			c := *p.counts
+			st.counts = &c
+
+			if p.snmpIndex != st.snmpIndex {
+				st.counts.indexChanges++
+				shifted = true
+			} else if countersDecreased(p.counters, st.counters) {
+				st.counts.counterResets++
+			}
+		}
+
+		current[s.Name] = st
+		result[s.Name] = st.counts
+	}
+
+	t.targets[target] = current
+
+	return result, shifted
+}
+
+func countersDecreased(prev, current [4]float64) bool {
+	for i := range prev {
+		if current[i] < prev[i] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscontinuities(t *testing.T) {
+	tr := newDiscontinuityTracker()
+
+	d, _ := tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", SnmpIndex: 500, ReceiveBytes: 1000, TransmitBytes: 2000},
+		{Name: "xe-0/0/1", SnmpIndex: 501, ReceiveBytes: 1000, TransmitBytes: 2000},
+	})
+	assert.Equal(t, &discontinuityCounts{}, d["xe-0/0/0"])
+
+	d, shifted := tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", SnmpIndex: 500, ReceiveBytes: 100, TransmitBytes: 2500},
+		{Name: "xe-0/0/1", SnmpIndex: 502, ReceiveBytes: 1500, TransmitBytes: 2500},
+	})
+	assert.True(t, shifted, "index shift detected")
+	assert.Equal(t, &discontinuityCounts{counterResets: 1}, d["xe-0/0/0"], "counter reset")
+	assert.Equal(t, &discontinuityCounts{indexChanges: 1}, d["xe-0/0/1"], "index changed")
+
+	d, shifted = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", SnmpIndex: 500, ReceiveBytes: 200, TransmitBytes: 3000},
+	})
+	assert.False(t, shifted)
+	assert.Equal(t, &discontinuityCounts{counterResets: 1}, d["xe-0/0/0"], "counts are kept")
+
+	d, _ = tr.update("router2", []*InterfaceStats{
+		{Name: "xe-0/0/0", SnmpIndex: 600},
+	})
+	assert.Equal(t, &discontinuityCounts{}, d["xe-0/0/0"], "targets are tracked separately")
+}
@@ -1,37 +1,58 @@
 package interfaces
 
 type InterfaceStats struct {
-	Name                string
-	AdminStatus         bool
-	OperStatus          bool
-	ErrorStatus         bool
-	Description         string
-	Mac                 string
-	IsPhysical          bool
-	Speed               string
-	ReceiveBytes        float64
-	ReceivePackets      float64
-	ReceiveErrors       float64
-	ReceiveDrops        float64
-	TransmitBytes       float64
-	TransmitPackets     float64
-	TransmitErrors      float64
-	TransmitDrops       float64
-	IPv6ReceiveBytes    float64
-	IPv6ReceivePackets  float64
-	IPv6TransmitBytes   float64
-	IPv6TransmitPackets float64
-	LastFlapped         float64
-	ReceiveUnicasts     float64
-	ReceiveBroadcasts   float64
-	ReceiveMulticasts   float64
-	ReceiveCrcErrors    float64
-	TransmitUnicasts    float64
-	TransmitBroadcasts  float64
-	TransmitMulticasts  float64
-	TransmitCrcErrors   float64
-	FecCcwCount         float64
-	FecNccwCount        float64
-	FecCcwErrorRate     float64
-	FecNccwErrorRate    float64
+	Name                       string
+	Parent                     string
+	Unit                       string
+	Vlan                       string
+	SnmpIndex                  uint64
+	AdminStatus                bool
+	OperStatus                 bool
+	ErrorStatus                bool
+	Description                string
+	Mac                        string
+	IsPhysical                 bool
+	Speed                      string
+	CounterSource              string
+	ReceiveBytes               float64
+	ReceivePackets             float64
+	ReceiveErrors              float64
+	ReceiveDrops               float64
+	TransmitBytes              float64
+	TransmitPackets            float64
+	TransmitErrors             float64
+	TransmitDrops              float64
+	IPv6ReceiveBytes           float64
+	IPv6ReceivePackets         float64
+	IPv6TransmitBytes          float64
+	IPv6TransmitPackets        float64
+	LastFlapped                float64
+	ReceiveUnicasts            float64
+	ReceiveBroadcasts          float64
+	ReceiveMulticasts          float64
+	ReceiveCrcErrors           float64
+	TransmitUnicasts           float64
+	TransmitBroadcasts         float64
+	TransmitMulticasts         float64
+	TransmitCrcErrors          float64
+	FecCcwCount                float64
+	FecNccwCount               float64
+	FecCcwErrorRate            float64
+	FecNccwErrorRate           float64
+	ReceiveFramingErrors       float64
+	ReceiveRunts               float64
+	ReceiveGiants              float64
+	ReceivePolicedDiscards     float64
+	ReceiveL3Incompletes       float64
+	ReceiveL2ChannelErrors     float64
+	ReceiveL2MismatchTimeouts  float64
+	ReceiveFifoErrors          float64
+	ReceiveResourceErrors      float64
+	TransmitCarrierTransitions float64
+	TransmitCollisions         float64
+	TransmitAgedPackets        float64
+	TransmitMtuErrors          float64
+	TransmitHsLinkCrcErrors    float64
+	TransmitFifoErrors         float64
+	TransmitResourceErrors     float64
 }
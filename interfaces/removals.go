@@ -0,0 +1,40 @@
+package interfaces
+
+import "sync"
+
+// removals keeps track of interfaces disappearing from the device between scrapes
+var removals = newRemovalTracker()
+
+type removalTracker struct {
+	targets map[string]map[string]struct{}
+	removed map[string]uint64
+	mu      sync.Mutex
+}
+
+func newRemovalTracker() *removalTracker {
+	return &removalTracker{
+		targets: make(map[string]map[string]struct{}),
+		removed: make(map[string]uint64),
+	}
+}
+
+// update compares the interfaces with the ones of the previous scrape of the target and returns the number of interfaces removed since the first scrape
+func (t *removalTracker) update(target string, stats []*InterfaceStats) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[string]struct{}, len(stats))
+	for _, s := range stats {
+		current[s.Name] = struct{}{}
+	}
+
+	for name := range t.targets[target] {
+		if _, found := current[name]; !found {
+			t.removed[target]++
+		}
+	}
+
+	t.targets[target] = current
+
+	return t.removed[target]
+}
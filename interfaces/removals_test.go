@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovals(t *testing.T) {
+	tr := newRemovalTracker()
+
+	assert.Equal(t, uint64(0), tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0"},
+		{Name: "xe-0/0/0.100"},
+		{Name: "xe-0/0/0.200"},
+	}))
+
+	assert.Equal(t, uint64(2), tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0"},
+	}), "removed units")
+
+	assert.Equal(t, uint64(2), tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0"},
+		{Name: "xe-0/0/0.100"},
+	}), "added unit")
+
+	assert.Equal(t, uint64(3), tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0"},
+	}), "removed again")
+
+	assert.Equal(t, uint64(0), tr.update("router2", []*InterfaceStats{}), "targets are tracked separately")
+}
@@ -8,6 +8,7 @@ type InterfaceRpc struct {
 
 type PhyInterface struct {
 	Name              string         `xml:"name"`
+	SnmpIndex         uint64         `xml:"snmp-index"`
 	AdminStatus       string         `xml:"admin-status"`
 	OperStatus        string         `xml:"oper-status"`
 	Description       string         `xml:"description"`
@@ -16,12 +17,28 @@ type PhyInterface struct {
 	Stats             TrafficStat    `xml:"traffic-statistics"`
 	LogicalInterfaces []LogInterface `xml:"logical-interface"`
 	InputErrors       struct {
-		Drops  uint64 `xml:"input-drops"`
-		Errors uint64 `xml:"input-errors"`
+		Drops              uint64 `xml:"input-drops"`
+		Errors             uint64 `xml:"input-errors"`
+		FramingErrors      uint64 `xml:"framing-errors"`
+		Runts              uint64 `xml:"input-runts"`
+		Giants             uint64 `xml:"input-giants"`
+		PolicedDiscards    uint64 `xml:"input-discards"`
+		L3Incompletes      uint64 `xml:"input-l3-incompletes"`
+		L2ChannelErrors    uint64 `xml:"input-l2-channel-errors"`
+		L2MismatchTimeouts uint64 `xml:"input-l2-mismatch-timeouts"`
+		FifoErrors         uint64 `xml:"input-fifo-errors"`
+		ResourceErrors     uint64 `xml:"input-resource-errors"`
 	} `xml:"input-error-list"`
 	OutputErrors struct {
-		Drops  uint64 `xml:"output-drops"`
-		Errors uint64 `xml:"output-errors"`
+		Drops              uint64 `xml:"output-drops"`
+		Errors             uint64 `xml:"output-errors"`
+		CarrierTransitions uint64 `xml:"carrier-transitions"`
+		Collisions         uint64 `xml:"output-collisions"`
+		AgedPackets        uint64 `xml:"aged-packets"`
+		MtuErrors          uint64 `xml:"mtu-errors"`
+		HsLinkCrcErrors    uint64 `xml:"hs-link-crc-errors"`
+		FifoErrors         uint64 `xml:"output-fifo-errors"`
+		ResourceErrors     uint64 `xml:"output-resource-errors"`
 	} `xml:"output-error-list"`
 	InterfaceFlapped struct {
 		Seconds uint64 `xml:"seconds,attr"`
@@ -32,10 +49,14 @@ type PhyInterface struct {
 }
 
 type LogInterface struct {
-	Name        string         `xml:"name"`
-	Description string         `xml:"description"`
-	Stats       TrafficStat    `xml:"traffic-statistics"`
-	LagStats    LagTrafficStat `xml:"lag-traffic-statistics"`
+	Name         string         `xml:"name"`
+	SnmpIndex    uint64         `xml:"snmp-index"`
+	Description  string         `xml:"description"`
+	LinkAddress  string         `xml:"link-address"`
+	Stats        TrafficStat    `xml:"traffic-statistics"`
+	LagStats     LagTrafficStat `xml:"lag-traffic-statistics"`
+	TransitStats TrafficStat    `xml:"transit-traffic-statistics"`
+	LocalStats   TrafficStat    `xml:"local-traffic-statistics"`
 }
 
 type TrafficStat struct {
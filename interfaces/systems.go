@@ -0,0 +1,70 @@
+package interfaces
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/czerwonk/junos_exporter/rpc"
+)
+
+type systemInterfacesRPC struct {
+	LogicalSystemInterfaces []systemInterface `xml:"configuration>logical-systems>interfaces>interface"`
+	TenantInterfaces        []systemInterface `xml:"configuration>tenants>interfaces>interface"`
+}
+
+type systemInterface struct {
+	Name  string `xml:"name"`
+	Units []struct {
+		Name string `xml:"name"`
+	} `xml:"unit"`
+}
+
+// LogicalSystemHierarchy returns the configuration hierarchy of a logical system
+func LogicalSystemHierarchy(name string) string {
+	return "logical-systems " + name
+}
+
+// TenantSystemHierarchy returns the configuration hierarchy of a tenant system
+func TenantSystemHierarchy(name string) string {
+	return "tenants " + name
+}
+
+// NewSystemCollector creates a new collector exporting the logical interfaces assigned to a logical system or tenant system.
+// hierarchy is the configuration hierarchy of the system (see LogicalSystemHierarchy and TenantSystemHierarchy).
+func NewSystemCollector(labels *interfacelabels.DynamicLabels, hierarchy string) collector.RPCCollector {
+	c := &interfaceCollector{
+		labels: labels,
+		system: hierarchy,
+	}
+	c.init()
+
+	return c
+}
+
+// systemInterfaces returns the statistics of the logical interfaces configured in the system
+func (c *interfaceCollector) systemInterfaces(client *rpc.Client, stats []*InterfaceStats) ([]*InterfaceStats, error) {
+	var x systemInterfacesRPC
+	err := client.RunCommandAndParse("show configuration "+c.system+" interfaces", &x)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, i := range append(x.LogicalSystemInterfaces, x.TenantInterfaces...) {
+		for _, u := range i.Units {
+			names[i.Name+"."+u.Name] = true
+		}
+	}
+
+	return filterLogicalInterfaces(stats, names), nil
+}
+
+func filterLogicalInterfaces(stats []*InterfaceStats, names map[string]bool) []*InterfaceStats {
+	res := make([]*InterfaceStats, 0, len(names))
+	for _, s := range stats {
+		if !s.IsPhysical && names[s.Name] {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,58 @@
+package interfaces
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSystemInterfaces(t *testing.T) {
+	body := `<rpc-reply>
+<configuration>
+<logical-systems>
+<name>customer1</name>
+<interfaces>
+<interface>
+<name>ge-0/0/1</name>
+<unit>
+<name>100</name>
+<vlan-id>100</vlan-id>
+</unit>
+<unit>
+<name>200</name>
+<vlan-id>200</vlan-id>
+</unit>
+</interface>
+<interface>
+<name>lt-0/0/0</name>
+<unit>
+<name>1</name>
+</unit>
+</interface>
+</interfaces>
+</logical-systems>
+</configuration>
+</rpc-reply>`
+
+	var x systemInterfacesRPC
+	err := xml.Unmarshal([]byte(body), &x)
+	assert.NoError(t, err)
+
+	assert.Len(t, x.LogicalSystemInterfaces, 2)
+	assert.Len(t, x.LogicalSystemInterfaces[0].Units, 2)
+	assert.Equal(t, "200", x.LogicalSystemInterfaces[0].Units[1].Name)
+	assert.Empty(t, x.TenantInterfaces)
+}
+
+func TestFilterLogicalInterfaces(t *testing.T) {
+	stats := []*InterfaceStats{
+		{Name: "ge-0/0/1", IsPhysical: true},
+		{Name: "ge-0/0/1.100"},
+		{Name: "ge-0/0/1.300"},
+		{Name: "lt-0/0/0.1"},
+	}
+
+	res := filterLogicalInterfaces(stats, map[string]bool{"ge-0/0/1.100": true, "ge-0/0/1.200": true, "lt-0/0/0.1": true})
+	assert.Equal(t, []*InterfaceStats{stats[1], stats[3]}, res)
+}
@@ -0,0 +1,56 @@
+package interfaces
+
+import "sort"
+
+// otherInterfaces aggregates the logical interfaces not exported in top N mode
+type otherInterfaces struct {
+	count    int
+	receive  float64
+	transmit float64
+}
+
+// selectTop returns the stats of all physical interfaces and of the n logical interfaces with the highest byte rate since the previous collection.
+// Logical interfaces without a known rate (e.g. first collection) are ranked last, ties are broken by name.
+func selectTop(stats []*InterfaceStats, r map[string]*byteRates, n int) ([]*InterfaceStats, *otherInterfaces) {
+	selected := make([]*InterfaceStats, 0, len(stats))
+	logical := make([]*InterfaceStats, 0, len(stats))
+
+	for _, s := range stats {
+		if s.IsPhysical {
+			selected = append(selected, s)
+		} else {
+			logical = append(logical, s)
+		}
+	}
+
+	total := func(s *InterfaceStats) float64 {
+		if rate, found := r[s.Name]; found {
+			return rate.receive + rate.transmit
+		}
+
+		return -1
+	}
+
+	sort.SliceStable(logical, func(i, j int) bool {
+		ti, tj := total(logical[i]), total(logical[j])
+		if ti != tj {
+			return ti > tj
+		}
+
+		return logical[i].Name < logical[j].Name
+	})
+
+	if len(logical) <= n {
+		return append(selected, logical...), &otherInterfaces{}
+	}
+
+	others := &otherInterfaces{count: len(logical) - n}
+	for _, s := range logical[n:] {
+		if rate, found := r[s.Name]; found {
+			others.receive += rate.receive
+			others.transmit += rate.transmit
+		}
+	}
+
+	return append(selected, logical[:n]...), others
+}
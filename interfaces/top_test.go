@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTop(t *testing.T) {
+	stats := []*InterfaceStats{
+		{Name: "xe-0/0/0", IsPhysical: true},
+		{Name: "xe-0/0/0.1"},
+		{Name: "xe-0/0/0.2"},
+		{Name: "xe-0/0/0.3"},
+		{Name: "xe-0/0/0.4"},
+	}
+	r := map[string]*byteRates{
+		"xe-0/0/0.1": {receive: 10, transmit: 10},
+		"xe-0/0/0.2": {receive: 500, transmit: 100},
+		"xe-0/0/0.3": {receive: 1, transmit: 2},
+	}
+
+	selected, others := selectTop(stats, r, 2)
+
+	names := make([]string, len(selected))
+	for i, s := range selected {
+		names[i] = s.Name
+	}
+	assert.Equal(t, []string{"xe-0/0/0", "xe-0/0/0.2", "xe-0/0/0.1"}, names, "physical and busiest logical interfaces")
+	assert.Equal(t, &otherInterfaces{count: 2, receive: 1, transmit: 2}, others, "aggregate of the rest")
+
+	selected, others = selectTop(stats, nil, 10)
+	assert.Len(t, selected, 5, "all interfaces within limit")
+	assert.Equal(t, 0, others.count)
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"regexp"
+	"strings"
+)
+
+// vlanTagRegex matches the tags in the link address of a logical interface (e.g. [ 0x8100.100 ] or [ 0x8100.100 0x8100.200 ] for stacked tags)
+var vlanTagRegex = regexp.MustCompile(`0x[0-9a-fA-F]+\.(\d+)`)
+
+// unitFromName returns the unit number of a logical interface (e.g. 100 for xe-0/0/0.100)
+func unitFromName(name string) string {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return ""
+	}
+
+	return name[i+1:]
+}
+
+// vlanFromLinkAddress returns the VLAN ID of a logical interface. Stacked tags are joined by a dot (outer.inner)
+func vlanFromLinkAddress(linkAddress string) string {
+	matches := vlanTagRegex.FindAllStringSubmatch(linkAddress, -1)
+
+	tags := make([]string, len(matches))
+	for i, m := range matches {
+		tags[i] = m[1]
+	}
+
+	return strings.Join(tags, ".")
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitFromName(t *testing.T) {
+	assert.Equal(t, "100", unitFromName("xe-0/0/0.100"))
+	assert.Equal(t, "0", unitFromName("ae1.0"))
+	assert.Equal(t, "", unitFromName("xe-0/0/0"))
+}
+
+func TestVlanFromLinkAddress(t *testing.T) {
+	assert.Equal(t, "100", vlanFromLinkAddress("[ 0x8100.100 ] "))
+	assert.Equal(t, "100.200", vlanFromLinkAddress("[ 0x88a8.100 0x8100.200 ] "))
+	assert.Equal(t, "", vlanFromLinkAddress(""))
+}
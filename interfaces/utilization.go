@@ -0,0 +1,83 @@
+package interfaces
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// rates keeps the byte counters of the physical interfaces of the previous collection to derive the current rates
+	rates = newRateTracker(true)
+
+	// logicalRates keeps the byte counters of the logical interfaces of the previous collection to determine the busiest ones
+	logicalRates = newRateTracker(false)
+)
+
+type byteRates struct {
+	receive  float64
+	transmit float64
+}
+
+type byteCounters struct {
+	snmpIndex uint64
+	receive   float64
+	transmit  float64
+	timestamp time.Time
+}
+
+type rateTracker struct {
+	physical bool
+	targets  map[string]map[string]*byteCounters
+	mu       sync.Mutex
+}
+
+func newRateTracker(physical bool) *rateTracker {
+	return &rateTracker{
+		physical: physical,
+		targets:  make(map[string]map[string]*byteCounters),
+	}
+}
+
+// update stores the byte counters of the physical (or logical) interfaces and returns the rates in bytes per second since the previous collection of the target.
+// Interfaces seen for the first time, with counters reset or with a changed SNMP index (e.g. renumbered after an FPC restart) since are omitted.
+func (t *rateTracker) update(target string, stats []*InterfaceStats, now time.Time) map[string]*byteRates {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.targets[target]
+	current := make(map[string]*byteCounters, len(stats))
+	result := make(map[string]*byteRates)
+
+	for _, s := range stats {
+		if s.IsPhysical != t.physical {
+			continue
+		}
+
+		c := &byteCounters{
+			snmpIndex: s.SnmpIndex,
+			receive:   s.ReceiveBytes,
+			transmit:  s.TransmitBytes,
+			timestamp: now,
+		}
+		current[s.Name] = c
+
+		p, found := prev[s.Name]
+		if !found || c.snmpIndex != p.snmpIndex || c.receive < p.receive || c.transmit < p.transmit {
+			continue
+		}
+
+		d := now.Sub(p.timestamp).Seconds()
+		if d <= 0 {
+			continue
+		}
+
+		result[s.Name] = &byteRates{
+			receive:  (c.receive - p.receive) / d,
+			transmit: (c.transmit - p.transmit) / d,
+		}
+	}
+
+	t.targets[target] = current
+
+	return result
+}
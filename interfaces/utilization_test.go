@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRates(t *testing.T) {
+	tr := newRateTracker(true)
+	now := time.Now()
+
+	r := tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", IsPhysical: true, ReceiveBytes: 1000, TransmitBytes: 2000},
+		{Name: "xe-0/0/0.0", ReceiveBytes: 1000, TransmitBytes: 2000},
+	}, now)
+	assert.Empty(t, r, "first collection")
+
+	r = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", IsPhysical: true, ReceiveBytes: 2000, TransmitBytes: 8000},
+		{Name: "xe-0/0/0.0", ReceiveBytes: 2000, TransmitBytes: 8000},
+	}, now.Add(10*time.Second))
+	assert.Equal(t, map[string]*byteRates{"xe-0/0/0": {receive: 100, transmit: 600}}, r)
+
+	r = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", IsPhysical: true, ReceiveBytes: 10, TransmitBytes: 20},
+	}, now.Add(20*time.Second))
+	assert.Empty(t, r, "counter reset")
+
+	r = tr.update("router1", []*InterfaceStats{
+		{Name: "xe-0/0/0", IsPhysical: true, SnmpIndex: 600, ReceiveBytes: 1000, TransmitBytes: 2000},
+	}, now.Add(30*time.Second))
+	assert.Empty(t, r, "index changed")
+}
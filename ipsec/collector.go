@@ -22,6 +22,7 @@ func init() {
 	l := []string{"target", "re_name", "description", "name"}
 
 	blockState = prometheus.NewDesc(prefix+"state", "State of the Security Association", l, nil)
+	collector.RegisterStates(prefix+"state", map[string]int{"down": 0, "up": 1})
 	activeTunnels = prometheus.NewDesc(prefix+"active_tunnels", "Total active tunnels", l, nil)
 	configuredTunnels = prometheus.NewDesc("junos_ipsec_configured_tunnels", "Total configured tunnels", l, nil)
 }
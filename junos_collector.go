@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"regexp"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/config"
 	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/dump"
 	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/czerwonk/junos_exporter/ping"
 	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/czerwonk/junos_exporter/tracing"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const prefix = "junos_"
@@ -18,34 +27,128 @@ var (
 	scrapeCollectorDurationDesc *prometheus.Desc
 	scrapeDurationDesc          *prometheus.Desc
 	upDesc                      *prometheus.Desc
+	reachableDesc               *prometheus.Desc
+	collectionCompleteDesc      *prometheus.Desc
+	commandAbortsDesc           *prometheus.Desc
+	credentialIndexDesc         *prometheus.Desc
+	icmpReachableDesc           *prometheus.Desc
+	icmpRTTDesc                 *prometheus.Desc
+	dnsSuccessDesc              *prometheus.Desc
+	dnsFailureReasonDesc        *prometheus.Desc
+	dnsAddressesDesc            *prometheus.Desc
+	duplicateTargetDesc         *prometheus.Desc
+	lastSuccessDesc             *prometheus.Desc
+	maintenanceDesc             *prometheus.Desc
+	pausedDesc                  *prometheus.Desc
+	pausedUntilDesc             *prometheus.Desc
+	sampleLimitExceededDesc     *prometheus.Desc
 	defaultIfDescReg            *regexp.Regexp
 )
 
 func init() {
 	upDesc = prometheus.NewDesc(prefix+"up", "Scrape of target was successful", []string{"target"}, nil)
+	reachableDesc = prometheus.NewDesc(prefix+"reachable", "Target responded to the connection attempt (1 = session established)", []string{"target"}, nil)
+	collectionCompleteDesc = prometheus.NewDesc(prefix+"collection_complete", "All collectors of the target succeeded (1) or at least one failed or samples were dropped (0)", []string{"target"}, nil)
 	scrapeDurationDesc = prometheus.NewDesc(prefix+"collector_duration_seconds", "Duration of a collector scrape for one target", []string{"target"}, nil)
 	scrapeCollectorDurationDesc = prometheus.NewDesc(prefix+"collect_duration_seconds", "Duration of a scrape by collector and target", []string{"target", "collector"}, nil)
+	commandAbortsDesc = prometheus.NewDesc(prefix+"command_aborts_total", "Number of commands aborted because of exceeding the command timeout or max. output size", []string{"target", "reason"}, nil)
+	credentialIndexDesc = prometheus.NewDesc(prefix+"auth_credential_index", "Index of the password in the configured password list used to authenticate", []string{"target"}, nil)
+	icmpReachableDesc = prometheus.NewDesc(prefix+"icmp_reachable", "Target answered the ICMP echo request sent before connecting (1 = reachable)", []string{"target"}, nil)
+	icmpRTTDesc = prometheus.NewDesc(prefix+"icmp_rtt_seconds", "Round trip time of the ICMP echo request sent before connecting", []string{"target"}, nil)
+	dnsSuccessDesc = prometheus.NewDesc(prefix+"dns_resolution_success", "Latest name resolution of the target was successful (only for targets specified by name)", []string{"target"}, nil)
+	dnsFailureReasonDesc = prometheus.NewDesc(prefix+"dns_resolution_failure_reason", "Reason the latest name resolution of the target failed", []string{"target", "reason"}, nil)
+	dnsAddressesDesc = prometheus.NewDesc(prefix+"dns_addresses_count", "Number of addresses the target resolved to", []string{"target"}, nil)
+	duplicateTargetDesc = prometheus.NewDesc(prefix+"duplicate_target", "Target reports the same serial number (or host name) as another target", []string{"target", "duplicate_of"}, nil)
+	lastSuccessDesc = prometheus.NewDesc(prefix+"last_successful_scrape_timestamp_seconds", "Time of the last scrape the target was reachable (also exported while the target is down)", []string{"target"}, nil)
+	maintenanceDesc = prometheus.NewDesc(prefix+"maintenance", "Target is in a configured maintenance window (1 = maintenance)", []string{"target"}, nil)
+	pausedDesc = prometheus.NewDesc(prefix+"paused", "Scraping of the target is paused via the /-/pause API (1 = paused)", []string{"target"}, nil)
+	pausedUntilDesc = prometheus.NewDesc(prefix+"paused_until_timestamp_seconds", "Time the pause of the target expires", []string{"target"}, nil)
+	sampleLimitExceededDesc = prometheus.NewDesc(prefix+"sample_limit_exceeded", "Samples of the target were dropped because of exceeding -scrape.sample-limit (1 = exceeded)", []string{"target"}, nil)
 	defaultIfDescReg = regexp.MustCompile(`\[([^=\]]+)(=[^\]]+)?\]`)
 }
 
 type junosCollector struct {
-	devices    []*connector.Device
-	clients    map[*connector.Device]*rpc.Client
-	collectors *collectors
+	ctx         context.Context
+	devices     []*connector.Device
+	clients     map[*connector.Device]*rpc.Client
+	probes      map[*connector.Device]*probeResult
+	nextHops    map[*connector.Device][]*nextHopProbeResult
+	maintenance map[*connector.Device]*config.MaintenanceWindow
+	paused      map[*connector.Device]time.Time
+	audits      scrapeAudits
+	collectors  *collectors
+	// deadline after which no further collectors are started (zero = no deadline)
+	deadline time.Time
+	// shared is set for collectors of logical systems and tenant systems using the client of the master (see collectLogicalSystems)
+	shared bool
 }
 
-func newJunosCollector(devices []*connector.Device, connectionManager *connector.SSHConnectionManager, logicalSystem string) *junosCollector {
+// probeResult is the result of the ICMP echo request sent before connecting
+type probeResult struct {
+	reachable bool
+	rtt       time.Duration
+	err       error
+}
+
+func newJunosCollector(ctx context.Context, devices []*connector.Device, connectionManager *connector.SSHConnectionManager, logicalSystem string) *junosCollector {
 	l := interfacelabels.NewDynamicLabels()
 
 	clients := make(map[*connector.Device]*rpc.Client)
+	probes := make(map[*connector.Device]*probeResult)
+	maintenance := make(map[*connector.Device]*config.MaintenanceWindow)
+	paused := pausedDevices(devices, time.Now())
+	audits := newScrapeAudits(devices)
+
+	// next hops are probed while connecting to the devices (once per device, not per logical system)
+	var nextHops map[*connector.Device][]*nextHopProbeResult
+	nextHopsDone := make(chan struct{})
+	go func() {
+		defer close(nextHopsDone)
+		if logicalSystem == "" {
+			nextHops = runNextHopProbes(ctx, unpausedDevices(devices, paused), cfg.NextHopProbesForDevice)
+		}
+	}()
+
+	for _, d := range devices {
+		if _, found := paused[d]; found {
+			log.Debugf("Skipping %s (paused)", d)
+			continue
+		}
+
+		if w := cfg.MaintenanceForDevice(d.Host, time.Now()); w != nil {
+			maintenance[d] = w
+			if !w.Scrape {
+				log.Debugf("Skipping %s (maintenance window %s)", d, w.Schedule)
+				continue
+			}
+		}
+
+		if cfg.GNMIModeForDevice(d.Host) == config.GNMIOnly {
+			continue
+		}
+
+		if *icmpEnabled {
+			p := probe(ctx, d)
+			probes[d] = p
+			if !p.reachable {
+				audits.recordError(d, "", p.err)
+				continue
+			}
+		}
 
-	for index, d := range devices {
+		_, span := tracing.Start(ctx, "connect", attribute.String("target", d.Host))
 		cl, err := clientForDevice(d, connManager)
 		if err != nil {
 			log.Errorf("Could not connect to %s: %s", d, err)
+			status.recordError(d.Host, "", err)
+			audits.recordError(d, "", err)
+			tracing.RecordError(span, err)
+			span.End()
 			continue
 		}
+		span.End()
 
+		cl.SetContext(ctx)
 		clients[d] = cl
 
 		if *dynamicIfaceLabels {
@@ -56,10 +159,10 @@ func newJunosCollector(devices []*connector.Device, connectionManager *connector
 				        log.Errorf("Global dynamic label regex invalid: %s", cfg.IfDescReg)
 				        regex = defaultIfDescReg
 				}
-			} else if !(*ignoreConfigTargets) && index < len(cfg.Devices) && cfg.Devices[index].IfDescReg != "" {
-				regex, err = regexp.Compile(cfg.Devices[index].IfDescReg)
+			} else if r := cfg.IfDescRegForDevice(d.Host); !(*ignoreConfigTargets) && r != "" {
+				regex, err = regexp.Compile(r)
 				if err != nil {
-				     log.Errorf("Device specific dynamic label regex invalid: %s", cfg.Devices[index].IfDescReg)
+				     log.Errorf("Device specific dynamic label regex invalid: %s", r)
 				     regex = defaultIfDescReg
 				}
 			}
@@ -73,15 +176,38 @@ func newJunosCollector(devices []*connector.Device, connectionManager *connector
 		}
 	}
 
+	<-nextHopsDone
+
 	return &junosCollector{
-		devices:    devices,
-		collectors: collectorsForDevices(devices, cfg, logicalSystem, l),
-		clients:    clients,
+		ctx:         ctx,
+		devices:     devices,
+		collectors:  collectorsForDevices(devices, cfg, logicalSystem, l),
+		clients:     clients,
+		probes:      probes,
+		nextHops:    nextHops,
+		maintenance: maintenance,
+		paused:      paused,
+		audits:      audits,
 	}
 }
 
+func probe(ctx context.Context, device *connector.Device) *probeResult {
+	_, span := tracing.Start(ctx, "ping", attribute.String("target", device.Host))
+	defer span.End()
+
+	rtt, err := ping.Ping(device.Host, *icmpTimeout, *icmpPrivileged)
+	if err != nil {
+		log.Errorf("%s is not reachable: %s", device, err)
+		status.recordError(device.Host, "", err)
+		tracing.RecordError(span, err)
+		return &probeResult{err: err}
+	}
+
+	return &probeResult{reachable: true, rtt: rtt}
+}
+
 func clientForDevice(device *connector.Device, connManager *connector.SSHConnectionManager) (*rpc.Client, error) {
-	conn, err := connManager.Connect(device)
+	conn, err := connectionForDevice(device, connManager)
 	if err != nil {
 		return nil, err
 	}
@@ -96,14 +222,62 @@ func clientForDevice(device *connector.Device, connManager *connector.SSHConnect
 		c.EnableSatellite()
 	}
 
+	if *shareOutputs {
+		c.EnableOutputSharing()
+	}
+
+	if auditLog != nil {
+		c.EnableCommandLog()
+	}
+
+	if l := rateLimiters.get(device.Host); l != nil {
+		c.SetRateLimiter(l)
+	}
+
 	return c, nil
 }
 
+func connectionForDevice(device *connector.Device, connManager *connector.SSHConnectionManager) (rpc.Connection, error) {
+	if *replayDir != "" {
+		return dump.NewReplay(device, *replayDir), nil
+	}
+
+	conn, err := connManager.Connect(device)
+	if err != nil {
+		return nil, err
+	}
+
+	if *recordDir != "" {
+		return dump.NewRecorder(conn, *recordDir)
+	}
+
+	return conn, nil
+}
+
 // Describe implements prometheus.Collector interface
 func (c *junosCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- upDesc
+	ch <- reachableDesc
+	ch <- collectionCompleteDesc
 	ch <- scrapeDurationDesc
 	ch <- scrapeCollectorDurationDesc
+	ch <- commandAbortsDesc
+	ch <- credentialIndexDesc
+	ch <- icmpReachableDesc
+	ch <- icmpRTTDesc
+	ch <- nextHopProbeSuccessDesc
+	ch <- nextHopProbeDurationDesc
+	ch <- dnsSuccessDesc
+	ch <- dnsFailureReasonDesc
+	ch <- dnsAddressesDesc
+	ch <- duplicateTargetDesc
+	ch <- lastSuccessDesc
+	ch <- maintenanceDesc
+	ch <- pausedDesc
+	ch <- pausedUntilDesc
+	ch <- sampleLimitExceededDesc
+	ch <- rateLimitDesc
+	ch <- rateLimitWaitDesc
 
 	for _, col := range c.collectors.allEnabledCollectors() {
 		col.Describe(ch)
@@ -122,32 +296,319 @@ func (c *junosCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
+func (c *junosCollector) collectResolution(device *connector.Device, ch chan<- prometheus.Metric, l []string) {
+	if connManager == nil {
+		return
+	}
+
+	r, found := connManager.Resolution(device.Host)
+	if !found {
+		return
+	}
+
+	success := 1
+	if r.FailureReason != "" {
+		success = 0
+		ch <- prometheus.MustNewConstMetric(dnsFailureReasonDesc, prometheus.GaugeValue, 1, append(l, r.FailureReason)...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(dnsSuccessDesc, prometheus.GaugeValue, float64(success), l...)
+	ch <- prometheus.MustNewConstMetric(dnsAddressesDesc, prometheus.GaugeValue, float64(len(r.Addresses)), l...)
+}
+
+// parallelism returns the max. number of collectors running concurrently per target
+func (c *junosCollector) parallelism() int {
+	if *collectorParallelism < 1 {
+		return 1
+	}
+
+	return *collectorParallelism
+}
+
+// collectGNMIOnly exports the state of devices configured for gNMI only (no SSH connection is established)
+func (c *junosCollector) collectGNMIOnly(device *connector.Device, ch chan<- prometheus.Metric, l []string) {
+	up := 0
+	if gnmiStore.Connected(device.Host) {
+		up = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, float64(up), l...)
+	ch <- prometheus.MustNewConstMetric(reachableDesc, prometheus.GaugeValue, float64(up), l...)
+	status.recordScrape(device.Host, up == 1, 0)
+	c.audits[device].result(up == 1, 0, nil)
+}
+
+// runCollector runs a collector and returns its duration and whether it failed.
+// Collectors with an interval configured are only run if their latest result is outdated, otherwise it is sent again.
+func (c *junosCollector) runCollector(ctx context.Context, device *connector.Device, col collector.RPCCollector, client *rpc.Client, ch chan<- prometheus.Metric, l []string) (time.Duration, bool) {
+	interval := c.collectors.interval(col)
+	if interval == 0 {
+		return c.runCollectorNow(ctx, device, col, client, ch, l)
+	}
+
+	key := collectorResultKey{host: device.Host, logicalSystem: c.collectors.logicalSystem, tenantSystem: c.collectors.tenantSystem, collector: col.Name()}
+	if metrics, found := intervalResults.get(key, interval, time.Now()); found {
+		for _, m := range metrics {
+			ch <- m
+		}
+
+		return 0, false
+	}
+
+	rec, metrics := recordingChannel(ch)
+	d, failed := c.runCollectorNow(ctx, device, col, client, rec, l)
+	result := metrics()
+
+	if !failed {
+		intervalResults.set(key, result, time.Now())
+	}
+
+	return d, failed
+}
+
+func (c *junosCollector) runCollectorNow(ctx context.Context, device *connector.Device, col collector.RPCCollector, client *rpc.Client, ch chan<- prometheus.Metric, l []string) (time.Duration, bool) {
+	ct := time.Now()
+	colCtx, colSpan := tracing.Start(ctx, "collector", attribute.String("collector", col.Name()))
+	// the capacity is limited so that collectors appending label values can not modify the values shared by all collectors
+	err := col.Collect(client.WithContext(colCtx), ch, l[:len(l):len(l)])
+
+	failed := err != nil && err.Error() != "EOF"
+	if failed {
+		log.Errorln(col.Name() + ": " + err.Error())
+		status.recordError(device.Host, col.Name(), err)
+		c.audits.recordError(device, col.Name(), err)
+		tracing.RecordError(colSpan, err)
+	}
+	colSpan.End()
+
+	return time.Since(ct), failed
+}
+
 func (c *junosCollector) collectForHost(device *connector.Device, ch chan<- prometheus.Metric, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	l := []string{device.Host}
 
+	ctx, span := tracing.Start(c.ctx, "target", attribute.String("target", device.Host))
+	defer span.End()
+
+	if c.shared {
+		if client, found := c.clients[device]; found {
+			c.collectSystem(ctx, device, client, ch, l)
+		}
+
+		return
+	}
+
+	audit := c.audits[device]
 	t := time.Now()
 	defer func() {
 		ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(t).Seconds(), l...)
+		observeScrapeDuration(device.Host, time.Since(t), span)
+		c.audits.finish(device, time.Since(t))
+
+		if ts, found := status.lastSuccess(device.Host); found {
+			ch <- prometheus.MustNewConstMetric(lastSuccessDesc, prometheus.GaugeValue, float64(ts.UnixNano())/1e9, l...)
+		}
 	}()
 
+	if until, found := c.paused[device]; found {
+		ch <- prometheus.MustNewConstMetric(pausedDesc, prometheus.GaugeValue, 1, l...)
+		ch <- prometheus.MustNewConstMetric(pausedUntilDesc, prometheus.GaugeValue, float64(until.Unix()), l...)
+		audit.skip("paused")
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(pausedDesc, prometheus.GaugeValue, 0, l...)
+
+	if cfg.HasMaintenanceWindows() {
+		w, found := c.maintenance[device]
+		if !found {
+			ch <- prometheus.MustNewConstMetric(maintenanceDesc, prometheus.GaugeValue, 0, l...)
+		} else {
+			ch <- prometheus.MustNewConstMetric(maintenanceDesc, prometheus.GaugeValue, 1, l...)
+
+			if !w.Scrape {
+				audit.skip("maintenance")
+				return
+			}
+		}
+	}
+
+	if *syslogListenAddress != "" && c.collectors.master() {
+		syslogStore.Collect(device.Host, ch, l)
+	}
+
+	if r, found := c.nextHops[device]; found && c.collectors.master() {
+		collectNextHopProbes(r, ch, l)
+	}
+
+	if mode := cfg.GNMIModeForDevice(device.Host); mode != "" && c.collectors.master() {
+		gnmiStore.Collect(device.Host, ch, l)
+
+		if mode == config.GNMIOnly {
+			c.collectGNMIOnly(device, ch, l)
+			return
+		}
+	}
+
+	c.collectResolution(device, ch, l)
+
+	if p, found := c.probes[device]; found {
+		reachable := 0
+		if p.reachable {
+			reachable = 1
+			ch <- prometheus.MustNewConstMetric(icmpRTTDesc, prometheus.GaugeValue, p.rtt.Seconds(), l...)
+		}
+
+		ch <- prometheus.MustNewConstMetric(icmpReachableDesc, prometheus.GaugeValue, float64(reachable), l...)
+	}
+
 	rpc, found := c.clients[device]
 	if !found {
 		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0, l...)
+		ch <- prometheus.MustNewConstMetric(reachableDesc, prometheus.GaugeValue, 0, l...)
+		ch <- prometheus.MustNewConstMetric(collectionCompleteDesc, prometheus.GaugeValue, 0, l...)
+		status.recordScrape(device.Host, false, 0)
+		audit.result(false, 0, nil)
 		return
 	}
 
 	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, l...)
+	ch <- prometheus.MustNewConstMetric(reachableDesc, prometheus.GaugeValue, 1, l...)
+
+	if device.PasswordFallback != nil {
+		ch <- prometheus.MustNewConstMetric(credentialIndexDesc, prometheus.GaugeValue, float64(device.PasswordFallback.Index()), l...)
+	}
 
+	if rl := rateLimiters.get(device.Host); rl != nil {
+		defer collectRateLimit(rl, ch, l)
+	}
+
+	rpc.SetContext(ctx)
+	status.detectPlatform(device.Host, rpc)
+
+	if dup := status.duplicateOf(device.Host, devices); dup != "" {
+		ch <- prometheus.MustNewConstMetric(duplicateTargetDesc, prometheus.GaugeValue, 1, append(l, dup)...)
+
+		if *dedupeTargets {
+			log.Debugf("Skipping collection of %s (duplicate of %s)", device.Host, dup)
+			status.recordScrape(device.Host, true, 0)
+			audit.skip("duplicate")
+			audit.result(true, 0, rpc)
+			return
+		}
+	}
+
+	limiter := newSampleLimiter(*sampleLimit)
+	series, failed := c.runCollectors(ctx, device, rpc, ch, l, limiter)
+
+	if limiter != nil {
+		exceeded := 0
+		if limiter.exceeded() {
+			exceeded = 1
+			log.Errorf("%s: %d samples exceed the limit of %d (-scrape.sample-limit), dropping all samples", device.Host, series, *sampleLimit)
+			limitExceeded.WithLabelValues("samples").Inc()
+			audit.recordError("", fmt.Errorf("%d samples exceed the sample limit of %d", series, *sampleLimit))
+			failed++
+		}
+
+		limiter.flush(ch)
+		ch <- prometheus.MustNewConstMetric(sampleLimitExceededDesc, prometheus.GaugeValue, float64(exceeded), l...)
+	}
+	status.recordScrape(device.Host, true, series)
+	audit.result(true, series, rpc)
+
+	complete := 1
+	if failed > 0 {
+		complete = 0
+	}
+	ch <- prometheus.MustNewConstMetric(collectionCompleteDesc, prometheus.GaugeValue, float64(complete), l...)
+
+	aborts := rpc.CommandAborts()
+	for _, reason := range []string{connector.AbortReasonTimeout, connector.AbortReasonOutputSize} {
+		ch <- prometheus.MustNewConstMetric(commandAbortsDesc, prometheus.CounterValue, float64(aborts[reason]), append(l, reason)...)
+	}
+}
+
+// runCollectors runs the collectors of the device applying the metric filter and the sample limit (limiter is nil if disabled).
+// It returns the number of series and the number of failed collectors.
+func (c *junosCollector) runCollectors(ctx context.Context, device *connector.Device, client *rpc.Client, ch chan<- prometheus.Metric, l []string, limiter *sampleLimiter) (int, int32) {
+	filter := cfg.MetricFilterForDevice(device.Host)
+	series := 0
+	colCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	emit := func(m prometheus.Metric, name string) {
+		if filter != nil && !filter.Allowed(name) {
+			return
+		}
+
+		series++
+		if limiter != nil {
+			limiter.add(m)
+			return
+		}
+
+		ch <- m
+	}
+	go func() {
+		for m := range colCh {
+			name := metricName(m)
+			emit(m, name)
+
+			if *stateInfoEnabled {
+				if info := stateInfo(m, name); info != nil {
+					emit(info, name+"_info")
+				}
+			}
+		}
+		close(done)
+	}()
+
+	var failed int32
+	sem := make(chan struct{}, c.parallelism())
+	colWg := &sync.WaitGroup{}
 	for _, col := range c.collectors.collectorsForDevice(device) {
-		ct := time.Now()
-		err := col.Collect(rpc, ch, l)
+		sem <- struct{}{}
+		if !c.deadline.IsZero() && time.Now().After(c.deadline) {
+			<-sem
+			log.Warnf("%s: skipping collector %s (timeout of the scrape exceeded)", device.Host, col.Name())
+			atomic.AddInt32(&failed, 1)
+			continue
+		}
+		colWg.Add(1)
+
+		go func(col collector.RPCCollector) {
+			defer func() {
+				<-sem
+				colWg.Done()
+			}()
+
+			d, colFailed := c.runCollector(ctx, device, col, client, colCh, l)
+			if colFailed {
+				atomic.AddInt32(&failed, 1)
+			}
+			ch <- prometheus.MustNewConstMetric(scrapeCollectorDurationDesc, prometheus.GaugeValue, d.Seconds(), append(l, col.Name())...)
+		}(col)
+	}
+	colWg.Wait()
+
+	close(colCh)
+	<-done
+
+	return series, failed
+}
+
+// collectSystem runs the collectors of a logical system or tenant system. Per target metrics (e.g. junos_up) are only exported by the collector of the master.
+func (c *junosCollector) collectSystem(ctx context.Context, device *connector.Device, client *rpc.Client, ch chan<- prometheus.Metric, l []string) {
+	limiter := newSampleLimiter(*sampleLimit)
+	series, _ := c.runCollectors(ctx, device, client, ch, l, limiter)
 
-		if err != nil && err.Error() != "EOF" {
-			log.Errorln(col.Name() + ": " + err.Error())
+	if limiter != nil {
+		if limiter.exceeded() {
+			log.Errorf("%s: %d samples of %s%s exceed the limit of %d (-scrape.sample-limit), dropping all samples", device.Host, series, c.collectors.logicalSystem, c.collectors.tenantSystem, *sampleLimit)
+			limitExceeded.WithLabelValues("samples").Inc()
 		}
 
-		ch <- prometheus.MustNewConstMetric(scrapeCollectorDurationDesc, prometheus.GaugeValue, time.Since(ct).Seconds(), append(l, col.Name())...)
+		limiter.flush(ch)
 	}
 }
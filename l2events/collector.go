@@ -0,0 +1,124 @@
+package l2events
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_l2_"
+
+var (
+	macMovesDesc         *prometheus.Desc
+	interfaceBlockedDesc *prometheus.Desc
+)
+
+// blockingFlags maps the interface flags of show ethernet-switching interface to the reason label
+var blockingFlags = map[string]string{
+	"SCTL": "storm_control",
+	"MMAS": "mac_move",
+	"MI":   "mac_limit",
+}
+
+func init() {
+	macMovesDesc = prometheus.NewDesc(prefix+"mac_moves", "Number of MAC moves to the interface in the MAC move buffer of the device", []string{"target", "vlan", "interface"}, nil)
+	interfaceBlockedDesc = prometheus.NewDesc(prefix+"interface_blocked", "Interface was shut down by storm control, MAC move or MAC limit action (1 = blocked)", []string{"target", "interface", "reason"}, nil)
+}
+
+type l2EventsCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &l2EventsCollector{}
+}
+
+// Name returns the name of the collector
+func (*l2EventsCollector) Name() string {
+	return "L2 Events"
+}
+
+// Describe describes the metrics
+func (*l2EventsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- macMovesDesc
+	ch <- interfaceBlockedDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *l2EventsCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	err := c.collectMacMoves(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	return c.collectInterfaces(client, ch, labelValues)
+}
+
+func (c *l2EventsCollector) collectMacMoves(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = macMoveBufferRpc{}
+	err := client.RunCommandAndParse("show l2-learning mac-move-buffer", &x)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range countMacMoves(x.Information.Entries) {
+		ch <- prometheus.MustNewConstMetric(macMovesDesc, prometheus.GaugeValue, float64(v), append(labelValues, k.vlan, k.iface)...)
+	}
+
+	return nil
+}
+
+func (c *l2EventsCollector) collectInterfaces(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = switchingInterfaceRpc{}
+	err := client.RunCommandAndParse("show ethernet-switching interface", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range x.Information.Interfaces {
+		if i.Name == "" {
+			continue
+		}
+
+		flags := strings.Split(i.Flags, ",")
+		for flag, reason := range blockingFlags {
+			ch <- prometheus.MustNewConstMetric(interfaceBlockedDesc, prometheus.GaugeValue, boolToFloat(hasFlag(flags, flag)), append(labelValues, i.Name, reason)...)
+		}
+	}
+
+	return nil
+}
+
+type macMoveKey struct {
+	vlan  string
+	iface string
+}
+
+func countMacMoves(entries []macMoveEntry) map[macMoveKey]int {
+	counts := make(map[macMoveKey]int)
+	for _, e := range entries {
+		counts[macMoveKey{vlan: e.Vlan, iface: e.NewInterface}]++
+	}
+
+	return counts
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if strings.TrimSpace(f) == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
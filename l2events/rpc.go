@@ -0,0 +1,25 @@
+package l2events
+
+type macMoveBufferRpc struct {
+	Information struct {
+		Entries []macMoveEntry `xml:"mac-move-entry"`
+	} `xml:"l2ald-mac-move-buffer-information"`
+}
+
+type macMoveEntry struct {
+	Vlan         string `xml:"vlan-name"`
+	Mac          string `xml:"mac-address"`
+	OldInterface string `xml:"old-interface"`
+	NewInterface string `xml:"new-interface"`
+}
+
+type switchingInterfaceRpc struct {
+	Information struct {
+		Interfaces []switchingInterface `xml:"l2ng-l2ald-iff-interface-entry"`
+	} `xml:"l2ng-l2ald-iff-interface-information"`
+}
+
+type switchingInterface struct {
+	Name  string `xml:"l2iff-interface-name"`
+	Flags string `xml:"l2iff-interface-flags"`
+}
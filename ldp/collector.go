@@ -27,6 +27,7 @@ func init() {
 	ldpSessionCountDesc = prometheus.NewDesc(ldprefix+"session_count", "Number of LDP Sessions", l, nil)
 
 	ldpSessionDesc = prometheus.NewDesc(ldprefix+"session_state", "State of LDP Sessions", lSession, nil)
+	collector.RegisterStates(ldprefix+"session_state", ldpStateMap)
 }
 
 // Collector collects ldpv3 metrics
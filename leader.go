@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	leaderDesc *prometheus.Desc
+	election   *leaderElection
+)
+
+func init() {
+	leaderDesc = prometheus.NewDesc(prefix+"ha_leader", "Instance is the active instance collecting the targets (1 = active, 0 = standby)", []string{"id"}, nil)
+}
+
+// leaderElection elects the active instance of multiple exporter instances sharing a lease file (e.g. on a shared volume).
+// The active instance renews the lease periodically, standby instances take over after the lease expired.
+type leaderElection struct {
+	path     string
+	id       string
+	duration time.Duration
+	leader   bool
+	mu       sync.RWMutex
+}
+
+func newLeaderElection(path, id string, duration time.Duration) *leaderElection {
+	return &leaderElection{
+		path:     path,
+		id:       id,
+		duration: duration,
+	}
+}
+
+func (e *leaderElection) start() {
+	e.update()
+
+	go func() {
+		for {
+			time.Sleep(e.duration / 3)
+			e.update()
+		}
+	}()
+}
+
+func (e *leaderElection) isLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leader
+}
+
+func (e *leaderElection) update() {
+	leader, err := e.acquire()
+	if err != nil {
+		log.Errorf("Could not acquire lease %s: %v", e.path, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if leader != e.leader {
+		log.Infof("HA state changed (active: %v)", leader)
+	}
+	e.leader = leader
+}
+
+// acquire takes or renews the lease if it is held by this instance or expired
+func (e *leaderElection) acquire() (bool, error) {
+	holder, expires, err := e.read()
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	if err == nil && holder != e.id && time.Now().Before(expires) {
+		return false, nil
+	}
+
+	err = e.write()
+	if err != nil {
+		return false, err
+	}
+
+	// read again to detect another instance taking the lease at the same time
+	holder, _, err = e.read()
+	if err != nil {
+		return false, err
+	}
+
+	return holder == e.id, nil
+}
+
+func (e *leaderElection) read() (string, time.Time, error) {
+	b, err := ioutil.ReadFile(e.path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(string(b)), " ", 2)
+	if len(parts) != 2 {
+		// invalid leases are treated as expired
+		return "", time.Time{}, nil
+	}
+
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, nil
+	}
+
+	return parts[0], time.Unix(0, expires), nil
+}
+
+func (e *leaderElection) write() error {
+	tmp, err := ioutil.TempFile(filepath.Dir(e.path), ".lease")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = fmt.Fprintf(tmp, "%s %d\n", e.id, time.Now().Add(e.duration).UnixNano())
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), e.path)
+}
+
+// leaderCollector exports the HA state of the instance
+type leaderCollector struct {
+	election *leaderElection
+}
+
+// Describe implements prometheus.Collector interface
+func (c *leaderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- leaderDesc
+}
+
+// Collect implements prometheus.Collector interface
+func (c *leaderCollector) Collect(ch chan<- prometheus.Metric) {
+	v := 0
+	if c.election.isLeader() {
+		v = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(leaderDesc, prometheus.GaugeValue, float64(v), c.election.id)
+}
+
+func defaultInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderElection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+
+	a := newLeaderElection(path, "a", 100*time.Millisecond)
+	b := newLeaderElection(path, "b", 100*time.Millisecond)
+
+	a.update()
+	b.update()
+	assert.True(t, a.isLeader(), "a acquires lease")
+	assert.False(t, b.isLeader(), "b is standby")
+
+	a.update()
+	assert.True(t, a.isLeader(), "a renews lease")
+
+	time.Sleep(150 * time.Millisecond)
+	b.update()
+	a.update()
+	assert.True(t, b.isLeader(), "b takes over expired lease")
+	assert.False(t, a.isLeader(), "a is standby")
+}
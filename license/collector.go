@@ -0,0 +1,102 @@
+package license
+
+import (
+	"strings"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_license_"
+
+var (
+	installedDesc *prometheus.Desc
+	licensedDesc  *prometheus.Desc
+	usedDesc      *prometheus.Desc
+	neededDesc    *prometheus.Desc
+	expiryDesc    *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target"}
+	installedDesc = prometheus.NewDesc(prefix+"installed_count", "Number of installed licenses", l, nil)
+
+	l = append(l, "feature", "description")
+	licensedDesc = prometheus.NewDesc(prefix+"feature_licensed_count", "Licensed capacity of the feature", l, nil)
+	usedDesc = prometheus.NewDesc(prefix+"feature_used_count", "Used capacity of the feature", l, nil)
+	neededDesc = prometheus.NewDesc(prefix+"feature_needed_count", "Capacity of the feature in use without a license", l, nil)
+	expiryDesc = prometheus.NewDesc(prefix+"feature_expiry_seconds", "Seconds until the license of the feature expires (only date based licenses)", l, nil)
+}
+
+type licenseCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &licenseCollector{}
+}
+
+// Name returns the name of the collector
+func (*licenseCollector) Name() string {
+	return "License"
+}
+
+// Describe describes the metrics
+func (*licenseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- installedDesc
+	ch <- licensedDesc
+	ch <- usedDesc
+	ch <- neededDesc
+	ch <- expiryDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *licenseCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var i = licenseInstalledRpc{}
+	err := client.RunCommandAndParse("show system license installed", &i)
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(installedDesc, prometheus.GaugeValue, float64(len(i.Information.Licenses)), labelValues...)
+
+	var x = licenseUsageRpc{}
+	err = client.RunCommandAndParse("show system license usage", &x)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, f := range x.Information.Features {
+		l := append(labelValues, f.Name, f.Description)
+		ch <- prometheus.MustNewConstMetric(licensedDesc, prometheus.GaugeValue, float64(f.Licensed), l...)
+		ch <- prometheus.MustNewConstMetric(usedDesc, prometheus.GaugeValue, float64(f.UsedLicensed), l...)
+		ch <- prometheus.MustNewConstMetric(neededDesc, prometheus.GaugeValue, float64(f.Needed), l...)
+
+		if exp, ok := secondsUntilExpiry(f, now); ok {
+			ch <- prometheus.MustNewConstMetric(expiryDesc, prometheus.GaugeValue, exp, l...)
+		}
+	}
+
+	return nil
+}
+
+func secondsUntilExpiry(f featureSummary, now time.Time) (float64, bool) {
+	if f.EndDate.Seconds > 0 {
+		return time.Unix(f.EndDate.Seconds, 0).Sub(now).Seconds(), true
+	}
+
+	v := strings.TrimSpace(f.EndDate.Value)
+	if v == "" || strings.EqualFold(v, "permanent") {
+		return 0, false
+	}
+
+	end, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return 0, false
+	}
+
+	return end.Sub(now).Seconds(), true
+}
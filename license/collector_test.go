@@ -0,0 +1,56 @@
+package license
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLicenseUsage(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/20.4R3/junos">
+    <license-usage-summary xmlns="http://xml.juniper.net/junos/20.4R3/junos-license">
+        <feature-summary>
+            <name>scale-subscriber</name>
+            <description>Scale Subscriber</description>
+            <licensed>1000</licensed>
+            <used-licensed>850</used-licensed>
+            <needed>0</needed>
+            <validity-type>permanent</validity-type>
+        </feature-summary>
+        <feature-summary>
+            <name>idp-sig</name>
+            <description>IDP Signature</description>
+            <licensed>1</licensed>
+            <used-licensed>1</used-licensed>
+            <needed>0</needed>
+            <end-date junos:format="2022-09-30">2022-09-30</end-date>
+        </feature-summary>
+    </license-usage-summary>
+    <cli>
+        <banner></banner>
+    </cli>
+</rpc-reply>`
+
+	x := licenseUsageRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(x.Information.Features), "features")
+
+	f := x.Information.Features[0]
+	assert.Equal(t, "scale-subscriber", f.Name, "name")
+	assert.Equal(t, int64(1000), f.Licensed, "licensed")
+	assert.Equal(t, int64(850), f.UsedLicensed, "used-licensed")
+
+	now := time.Date(2022, 9, 20, 0, 0, 0, 0, time.UTC)
+	_, ok := secondsUntilExpiry(f, now)
+	assert.False(t, ok, "permanent license should not expire")
+
+	exp, ok := secondsUntilExpiry(x.Information.Features[1], now)
+	assert.True(t, ok, "date based license should expire")
+	assert.Equal(t, float64(10*24*3600), exp, "expiry")
+}
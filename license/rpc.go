@@ -0,0 +1,28 @@
+package license
+
+type licenseUsageRpc struct {
+	Information struct {
+		Features []featureSummary `xml:"feature-summary"`
+	} `xml:"license-usage-summary"`
+}
+
+type featureSummary struct {
+	Name         string `xml:"name"`
+	Description  string `xml:"description"`
+	Licensed     int64  `xml:"licensed"`
+	UsedLicensed int64  `xml:"used-licensed"`
+	Needed       int64  `xml:"needed"`
+	ValidityType string `xml:"validity-type"`
+	EndDate      struct {
+		Seconds int64  `xml:"seconds,attr"`
+		Value   string `xml:",chardata"`
+	} `xml:"end-date"`
+}
+
+type licenseInstalledRpc struct {
+	Information struct {
+		Licenses []struct {
+			Name string `xml:"name"`
+		} `xml:"license"`
+	} `xml:"license-information"`
+}
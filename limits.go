@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var limitExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "junos_exporter_limit_exceeded_total",
+	Help: "Number of times a safety limit (-targets.limit, -scrape.sample-limit) was exceeded",
+}, []string{"limit"})
+
+func init() {
+	exporterRegistry.MustRegister(limitExceeded)
+}
+
+// checkTargetsLimit fails if more targets are configured than allowed by -targets.limit
+func checkTargetsLimit(count int) error {
+	if *targetsLimit <= 0 || count <= *targetsLimit {
+		return nil
+	}
+
+	limitExceeded.WithLabelValues("targets").Inc()
+	return errors.Errorf("%d targets configured, exceeding the limit of %d (-targets.limit)", count, *targetsLimit)
+}
+
+// sampleLimiter holds back the samples of a target until the collection has finished,
+// so no samples of the target are exported at all if it exceeds -scrape.sample-limit (like Prometheus sample_limit)
+type sampleLimiter struct {
+	limit    int
+	count    int
+	buffered []prometheus.Metric
+}
+
+func newSampleLimiter(limit int) *sampleLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &sampleLimiter{limit: limit}
+}
+
+func (s *sampleLimiter) add(m prometheus.Metric) {
+	s.count++
+
+	if s.exceeded() {
+		s.buffered = nil
+		return
+	}
+
+	s.buffered = append(s.buffered, m)
+}
+
+func (s *sampleLimiter) exceeded() bool {
+	return s.count > s.limit
+}
+
+func (s *sampleLimiter) flush(ch chan<- prometheus.Metric) {
+	for _, m := range s.buffered {
+		ch <- m
+	}
+
+	s.buffered = nil
+}
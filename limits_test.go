@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleLimiter(t *testing.T) {
+	assert.Nil(t, newSampleLimiter(0), "no limit")
+
+	m := prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, "router1")
+
+	s := newSampleLimiter(2)
+	s.add(m)
+	s.add(m)
+	assert.False(t, s.exceeded(), "within limit")
+
+	ch := make(chan prometheus.Metric, 2)
+	s.flush(ch)
+	assert.Len(t, ch, 2, "flushed")
+
+	s = newSampleLimiter(2)
+	s.add(m)
+	s.add(m)
+	s.add(m)
+	assert.True(t, s.exceeded(), "exceeded")
+
+	ch = make(chan prometheus.Metric, 3)
+	s.flush(ch)
+	assert.Len(t, ch, 0, "dropped")
+}
+
+func TestCheckTargetsLimit(t *testing.T) {
+	*targetsLimit = 2
+	defer func() { *targetsLimit = 0 }()
+
+	assert.NoError(t, checkTargetsLimit(2))
+	assert.Error(t, checkTargetsLimit(3))
+}
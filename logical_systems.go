@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// collectLogicalSystems collects the logical systems and tenant systems configured for a device using the collectors supporting them.
+// The client of the master is reused, so the device is neither connected nor probed again.
+// All metrics get a logical_system (or tenant_system) label to distinguish them from metrics of the master.
+func collectLogicalSystems(master *junosCollector, device *connector.Device) []prometheus.Metric {
+	metrics := make([]prometheus.Metric, 0)
+
+	client, found := master.clients[device]
+	if !found {
+		return metrics
+	}
+
+	for _, ls := range cfg.LogicalSystemsForDevice(device.Host) {
+		c := master.systemCollector(device, client, logicalSystemCollectorsForDevices([]*connector.Device{device}, cfg, ls))
+
+		for _, m := range collectMetrics(c) {
+			metrics = append(metrics, withLabel(m, "logical_system", ls))
+		}
+	}
+
+	for _, ts := range cfg.TenantSystemsForDevice(device.Host) {
+		c := master.systemCollector(device, client, tenantSystemCollectorsForDevices([]*connector.Device{device}, cfg, ts))
+
+		for _, m := range collectMetrics(c) {
+			metrics = append(metrics, withLabel(m, "tenant_system", ts))
+		}
+	}
+
+	return metrics
+}
+
+// systemCollector creates a collector for a logical system or tenant system of the device using the client of the master
+func (c *junosCollector) systemCollector(device *connector.Device, client *rpc.Client, cols *collectors) *junosCollector {
+	return &junosCollector{
+		ctx:        c.ctx,
+		devices:    []*connector.Device{device},
+		clients:    map[*connector.Device]*rpc.Client{device: client},
+		collectors: cols,
+		deadline:   c.deadline,
+		shared:     true,
+	}
+}
+
+// labeledMetric adds label pairs to a metric
+type labeledMetric struct {
+	prometheus.Metric
+	labels []*dto.LabelPair
+}
+
+func withLabel(m prometheus.Metric, name, value string) prometheus.Metric {
+	return &labeledMetric{
+		Metric: m,
+		labels: []*dto.LabelPair{{Name: &name, Value: &value}},
+	}
+}
+
+// Write implements prometheus.Metric interface
+func (m *labeledMetric) Write(out *dto.Metric) error {
+	err := m.Metric.Write(out)
+	if err != nil {
+		return err
+	}
+
+	out.Label = append(out.Label, m.labels...)
+	sort.Slice(out.Label, func(i, j int) bool {
+		return out.Label[i].GetName() < out.Label[j].GetName()
+	})
+
+	return nil
+}
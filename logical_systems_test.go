@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLabel(t *testing.T) {
+	desc := prometheus.NewDesc("junos_test", "test", []string{"target", "asn"}, nil)
+	m := withLabel(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "router1", "65000"), "logical_system", "customer1")
+
+	out := &dto.Metric{}
+	err := m.Write(out)
+	assert.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, l := range out.Label {
+		names = append(names, l.GetName())
+	}
+	assert.Equal(t, []string{"asn", "logical_system", "target"}, names)
+	assert.Equal(t, "customer1", out.Label[1].GetValue())
+}
+
+func TestSystemCollectors(t *testing.T) {
+	c := &config.Config{
+		Features: config.FeatureConfig{
+			BGP:        true,
+			Interfaces: true,
+			Alarm:      true,
+		},
+	}
+	devices := []*connector.Device{{Host: "router1"}}
+
+	ls := logicalSystemCollectorsForDevices(devices, c, "customer1")
+	assert.Len(t, ls.collectorsForDevice(devices[0]), 2, "BGP and interfaces")
+	assert.False(t, ls.master())
+
+	ts := tenantSystemCollectorsForDevices(devices, c, "tenant1")
+	cols := ts.collectorsForDevice(devices[0])
+	if assert.Len(t, cols, 1) {
+		assert.Equal(t, "Interfaces", cols[0].Name())
+	}
+	assert.False(t, ts.master())
+}
+
+type fakeSystemCollector struct{}
+
+func (*fakeSystemCollector) Name() string {
+	return "Fake"
+}
+
+func (*fakeSystemCollector) Describe(ch chan<- *prometheus.Desc) {
+}
+
+func (*fakeSystemCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	ch <- prometheus.MustNewConstMetric(prometheus.NewDesc("junos_fake", "fake", []string{"target"}, nil), prometheus.GaugeValue, 1, labelValues...)
+	return nil
+}
+
+func TestSystemCollectorSkipsTargetMetrics(t *testing.T) {
+	prev := cfg
+	cfg = &config.Config{}
+	defer func() {
+		cfg = prev
+	}()
+
+	device := &connector.Device{Host: "router1"}
+	cols := &collectors{
+		tenantSystem: "tenant1",
+		devices:      map[string][]collector.RPCCollector{device.Host: {&fakeSystemCollector{}}},
+	}
+	master := &junosCollector{ctx: context.Background()}
+
+	names := make([]string, 0)
+	for _, m := range collectMetrics(master.systemCollector(device, rpc.NewClient(nil), cols)) {
+		names = append(names, metricName(m))
+	}
+
+	assert.ElementsMatch(t, []string{"junos_fake", "junos_collect_duration_seconds"}, names, "no per target metrics like junos_up")
+}
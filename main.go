@@ -2,23 +2,30 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/czerwonk/junos_exporter/connector"
 
 	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/discovery"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/czerwonk/junos_exporter/mibwalk"
+	"github.com/czerwonk/junos_exporter/systemd"
+	"github.com/czerwonk/junos_exporter/tracing"
+	"github.com/czerwonk/junos_exporter/vault"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const version string = "0.9.15"
@@ -28,6 +35,9 @@ var (
 	ignoreConfigTargets         = flag.Bool("config.ignore-targets", false, "Ignore check if target is specified in config")
 	listenAddress               = flag.String("web.listen-address", ":9326", "Address on which to expose metrics and web interface.")
 	metricsPath                 = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+	exporterMetricsPath         = flag.String("web.exporter-telemetry-path", "/exporter-metrics", "Path under which to expose metrics about the exporter itself (empty = disabled)")
+	goMetricsEnabled            = flag.Bool("exporter-metrics.go", true, "Expose Go runtime metrics of the exporter")
+	processMetricsEnabled       = flag.Bool("exporter-metrics.process", true, "Expose process metrics (CPU, memory, file descriptors) of the exporter")
 	sshHosts                    = flag.String("ssh.targets", "", "Hosts to scrape")
 	sshUsername                 = flag.String("ssh.user", "junos_exporter", "Username to use when connecting to junos devices using ssh")
 	sshKeyFile                  = flag.String("ssh.keyfile", "", "Public key file to use when connecting to junos devices using ssh")
@@ -35,36 +45,111 @@ var (
 	sshReconnectInterval        = flag.Duration("ssh.reconnect-interval", 30*time.Second, "Duration to wait before reconnecting to a device after connection got lost")
 	sshKeepAliveInterval        = flag.Duration("ssh.keep-alive-interval", 10*time.Second, "Duration to wait between keep alive messages")
 	sshKeepAliveTimeout         = flag.Duration("ssh.keep-alive-timeout", 15*time.Second, "Duration to wait for keep alive message response")
+	sshIdleTimeout              = flag.Duration("ssh.idle-timeout", 0, "Duration after which connections to devices not used by any scrape are closed (0 = keep connections open)")
+	dnsCacheTTL                 = flag.Duration("dns.cache-ttl", 0, "Duration resolved addresses of a target are reused for new connections (0 = resolve on each connection attempt)")
+	sshCommandTimeout           = flag.Duration("ssh.command-timeout", 0, "Duration after which a command is aborted (0 = no limit)")
+	sshMaxOutputSize            = flag.Int("ssh.max-output-size", 0, "Max. number of bytes a command may return before it is aborted (0 = no limit)")
+	shareOutputs                = flag.Bool("commands.share-outputs", false, "Run commands needed by multiple collectors only once per scrape of a target and share the output")
+	collectorParallelism        = flag.Int("collectors.parallelism", 1, "Max. number of collectors running concurrently per target (each using its own SSH session on the same connection)")
+	backgroundInterval          = flag.Duration("background.interval", 0, "Interval in which metrics are collected in background and served from memory (0 = disabled)")
+	backgroundTimestamps        = flag.Bool("background.timestamps", false, "Attach the time of the background collection to the served samples, so Prometheus records the observation time instead of the scrape time")
+	influxURL                   = flag.String("influx.url", "", "Write the results of the background collection to this InfluxDB write endpoint, e.g. http://localhost:8086/write?db=junos (empty = disabled)")
+	influxToken                 = flag.String("influx.token", "", "Token used to authenticate with InfluxDB")
+	graphiteAddress             = flag.String("graphite.address", "", "Write the results of the background collection to this Graphite plaintext listener, e.g. localhost:2003 (empty = disabled)")
+	graphitePrefix              = flag.String("graphite.prefix", "junos", "Prefix of the paths written to Graphite")
+	graphiteInterval            = flag.Duration("graphite.interval", 0, "Interval in which the latest results are written to Graphite (0 = after each background collection)")
+	pushgatewayURL              = flag.String("pushgateway.url", "", "Push the results of the background collection to this Prometheus Pushgateway, e.g. http://localhost:9091 (empty = disabled)")
+	pushgatewayJob              = flag.String("pushgateway.job", "junos", "Job name used as grouping key when pushing to the Pushgateway")
+	pushTimeout                 = flag.Duration("push.timeout", 10*time.Second, "Timeout for pushing the results of a target to an output")
+	haLeaseFile                 = flag.String("ha.lease-file", "", "Path of a lease file shared by multiple instances (e.g. on a shared volume). Only the instance holding the lease collects the targets")
+	haLeaseDuration             = flag.Duration("ha.lease-duration", 30*time.Second, "Duration after which a standby instance takes over if the lease was not renewed")
+	haID                        = flag.String("ha.id", "", "ID of this instance in the lease file (default: hostname and PID)")
+	shardDefinition             = flag.String("shard", "", "Only collect the part of the configured targets assigned to this instance in the format index/count (e.g. 2/5)")
+	targetsLimit                = flag.Int("targets.limit", 0, "Max. number of targets this instance collects. Loading a config exceeding the limit fails (0 = no limit)")
+	scrapeMaxTimeout            = flag.Duration("scrape.max-timeout", time.Minute, "Upper bound of the timeout parameter of ad-hoc scrapes")
+	rpmProvisioning             = flag.Bool("rpm.provisioning", false, "Provision the RPM tests configured for devices via NETCONF during background collection (changes the configuration of the devices)")
+	sampleLimit                 = flag.Int("scrape.sample-limit", 0, "Max. number of samples per target and scrape. All samples of a target exceeding the limit are dropped (0 = no limit)")
+	auditFile                   = flag.String("audit.file", "", "Write an audit record (JSON) of each scrape of a target to this file (empty = disabled)")
+	auditFileMaxSize            = flag.Int64("audit.file-max-size", 100, "Max. size of the audit file in MB before it is rotated")
+	auditFileBackups            = flag.Int("audit.file-backups", 3, "Number of rotated audit files to keep")
+	auditSyslog                 = flag.Bool("audit.syslog", false, "Write an audit record (JSON) of each scrape of a target to syslog")
+	dedupeTargets               = flag.Bool("dedupe-targets", false, "Skip collection of targets reporting the same serial number (or host name) as another target")
+	discoverySubnets            = flag.String("discovery.subnets", "", "Comma separated list of management subnets periodically swept for Juniper devices (SNMP sysObjectID) to add as targets, e.g. 192.168.0.0/24 (empty = disabled)")
+	discoveryInterval           = flag.Duration("discovery.interval", 10*time.Minute, "Interval in which the management subnets are swept")
+	discoveryCommunity          = flag.String("discovery.community", "public", "SNMPv2c community used to probe the management subnets")
+	discoveryTimeout            = flag.Duration("discovery.timeout", time.Second, "Timeout of a single SNMP probe")
+	stateInfoEnabled            = flag.Bool("state-info.enabled", false, "Emit an info metric (<metric>_info) labeled with the name of the current state for numeric state metrics (e.g. junos_vrrp_state_info{state=\"master\"})")
+	syslogListenAddress         = flag.String("syslog.listen-address", "", "Address to receive syslog messages (UDP) from the targets on to count the configured events, e.g. :5514 (empty = disabled)")
+	reverseDNSEnabled           = flag.Bool("reverse-dns.enabled", false, "Add target_name label with the name the target IP resolves to (reverse DNS) to all metrics")
+	reverseDNSRefreshInterval   = flag.Duration("reverse-dns.refresh-interval", time.Hour, "Interval in which names of target IPs are resolved again")
+	icmpEnabled                 = flag.Bool("icmp.enabled", false, "Ping targets before connecting to distinguish unreachable devices from SSH/NETCONF issues (unreachable devices are not scraped)")
+	icmpTimeout                 = flag.Duration("icmp.timeout", time.Second, "Duration to wait for an ICMP echo reply")
+	icmpPrivileged              = flag.Bool("icmp.privileged", false, "Use raw sockets for ICMP (requires root or CAP_NET_RAW), otherwise unprivileged ICMP sockets are used")
+	rateLimit                   = flag.Float64("rate-limit", 0, "Max. number of commands per second run on a target if not configured per device, e.g. 0.5 for fragile devices (0 = unlimited)")
+	cacheTTL                    = flag.Duration("cache.ttl", 0, "Duration the results of a target are served from cache to avoid querying devices multiple times for redundant Prometheus servers (0 = disabled)")
+	tracingEndpoint             = flag.String("tracing.endpoint", "", "OTLP/HTTP endpoint to export traces of scrapes to, e.g. localhost:4318 (empty = disabled)")
+	tracingInsecure             = flag.Bool("tracing.insecure", false, "Use HTTP instead of HTTPS to export traces")
+	tracingSampleRatio          = flag.Float64("tracing.sample-ratio", 1, "Ratio of scrapes to trace")
+	recordDir                   = flag.String("record.dir", "", "Directory to write the output of all commands run on the targets to (one subdirectory per target), e.g. to debug missing metrics offline")
+	replayDir                   = flag.String("replay.dir", "", "Directory containing recorded command outputs (see -record.dir) to serve the targets from instead of connecting to them")
+	dryRunEnabled               = flag.Bool("dry-run", false, "Collect all targets once, print the metrics and exit. Combined with -replay.dir the metrics produced from recorded outputs are shown without connecting to the devices")
+	generateFile                = flag.String("generate.file", "", "Generate a custom collector definition from a file containing the XML output of a command (e.g. show chassis routing-engine | display xml), print it and exit")
+	generateName                = flag.String("generate.name", "custom", "Name of the generated custom collector")
+	generateCommand             = flag.String("generate.command", "", "Command of the generated custom collector")
+	snmpMIBDir                  = flag.String("snmp.mib-dir", "", "Directory of MIB files used to resolve numeric OIDs (objects returned by MIB walks, sysObjectIDs of discovered devices)")
 	debug                       = flag.Bool("debug", false, "Show verbose debug output in log")
 	alarmEnabled                = flag.Bool("alarm.enabled", true, "Scrape Alarm metrics")
 	bgpEnabled                  = flag.Bool("bgp.enabled", true, "Scrape BGP metrics")
+	commitEnabled               = flag.Bool("commit.enabled", false, "Scrape configuration commit metrics")
+	componentsEnabled           = flag.Bool("components.enabled", false, "Scrape all numeric values of the jnxOperatingTable and jnxFruTable (requires SNMP to be enabled on the device)")
+	craftEnabled                = flag.Bool("craft.enabled", false, "Scrape LED and alarm relay states of the craft interface")
+	hostResourcesEnabled        = flag.Bool("host-resources.enabled", false, "Scrape storage and processor metrics of the HOST-RESOURCES-MIB (requires SNMP to be enabled on the device)")
+	snmpCharset                 = flag.String("snmp.charset", "utf-8", "Character set of octet strings returned by MIB walks not being valid UTF-8 (utf-8 or latin1)")
 	ospfEnabled                 = flag.Bool("ospf.enabled", true, "Scrape OSPFv3 metrics")
 	isisEnabled                 = flag.Bool("isis.enabled", false, "Scrape ISIS metrics")
 	l2circuitEnabled            = flag.Bool("l2circuit.enabled", false, "Scrape l2circuit metrics")
+	l2EventsEnabled             = flag.Bool("l2-events.enabled", false, "Scrape MAC moves and interfaces blocked by storm control, MAC move or MAC limit actions")
 	natEnabled                  = flag.Bool("nat.enabled", false, "Scrape NAT metrics")
 	nat2Enabled                 = flag.Bool("nat2.enabled", false, "Scrape NAT2 metrics")
 	ldpEnabled                  = flag.Bool("ldp.enabled", true, "Scrape ldp metrics")
+	licenseEnabled              = flag.Bool("license.enabled", false, "Scrape license metrics")
 	routingEngineEnabled        = flag.Bool("routingengine.enabled", true, "Scrape Routing Engine metrics")
 	routesEnabled               = flag.Bool("routes.enabled", true, "Scrape routing table metrics")
 	environmentEnabled          = flag.Bool("environment.enabled", true, "Scrape environment metrics")
+	fabricEnabled               = flag.Bool("fabric.enabled", false, "Scrape fabric plane state and link status on MX and PTX platforms")
 	firewallEnabled             = flag.Bool("firewall.enabled", true, "Scrape Firewall count metrics")
+	mclagEnabled                = flag.Bool("mclag.enabled", false, "Scrape MC-LAG metrics (ICCP peers, MC-AE interfaces and inter-chassis links)")
 	interfacesEnabled           = flag.Bool("interfaces.enabled", true, "Scrape interface metrics")
+	queueBufferOccupancy        = flag.Bool("interface-queue.buffer-occupancy", false, "Export the peak buffer occupancy of interface queues (show interfaces queue buffer-occupancy, QFX/EX) to analyze microbursts")
+	interfaceTopN               = flag.Int("interfaces.top-n", 0, "Export only the N logical interfaces with the highest byte rate since the previous collection per target and an aggregate of the others (0 = all interfaces)")
+	interfaceUtilization        = flag.Bool("interfaces.utilization", false, "Export the utilization of physical interfaces since the previous collection (requires background collection)")
 	interfaceDiagnosticsEnabled = flag.Bool("ifdiag.enabled", true, "Scrape optical interface diagnostic metrics")
 	ipsecEnabled                = flag.Bool("ipsec.enabled", false, "Scrape IPSec metrics")
 	securityEnabled             = flag.Bool("security.enabled", false, "Scrape security metrics")
+	securityServicesEnabled     = flag.Bool("security-services.enabled", false, "Scrape IDP attack, UTM anti-virus and web filtering counters (SRX)")
+	algEnabled                  = flag.Bool("alg.enabled", false, "Scrape ALG status, session and SIP error counters (SRX)")
 	storageEnabled              = flag.Bool("storage.enabled", true, "Scrape system storage metrics")
 	fpcEnabled                  = flag.Bool("fpc.enabled", true, "Scrape line card metrics")
+	fpcPFEStatistics            = flag.Bool("fpc.pfe-statistics", false, "Scrape traffic and discard statistics of the packet forwarding engines per line card (one command per line card)")
 	accountingEnabled           = flag.Bool("accounting.enabled", false, "Scrape accounting flow metrics")
 	interfaceQueuesEnabled      = flag.Bool("queues.enabled", false, "Scrape interface queue metrics")
 	rpkiEnabled                 = flag.Bool("rpki.enabled", false, "Scrape rpki metrics")
+	rpdEnabled                  = flag.Bool("rpd.enabled", false, "Scrape routing protocol daemon (task memory, kernel routing table queue) metrics")
 	satelliteEnabled            = flag.Bool("satellite.enabled", false, "Scrape metrics from satellite devices")
+	snmpEnabled                 = flag.Bool("snmp.enabled", false, "Scrape statistics of the SNMP agent of the device")
+	sflowEnabled                = flag.Bool("sflow.enabled", false, "Scrape sFlow metrics")
+	transceiverEnabled          = flag.Bool("transceiver.enabled", false, "Scrape transceiver presence and type metrics")
+	tunnelEnabled               = flag.Bool("tunnel.enabled", false, "Scrape tunnel interface (gr, ip, lt) metrics")
+	vpnEnabled                  = flag.Bool("vpn.enabled", false, "Scrape VPN (routing instance) metrics")
 	systemEnabled               = flag.Bool("system.enabled", false, "Scrape system metrics")
 	macEnabled                  = flag.Bool("mac.enabled", false, "Scrape MAC address table metrics")
 	alarmFilter                 = flag.String("alarms.filter", "", "Regex to filter for alerts to ignore")
 	configFile                  = flag.String("config.file", "", "Path to config file")
 	dynamicIfaceLabels          = flag.Bool("dynamic-interface-labels", true, "Parse interface descriptions to get labels dynamicly")
+	dynamicIfaceLabelsRefresh   = flag.Duration("dynamic-interface-labels.refresh-interval", 0, "Interval in which interface descriptions are retrieved again to update dynamic labels (0 = on every scrape)")
 	interfaceDescriptionRegex   = flag.String("interface-description-regex", "", "give a regex to retrieve the interface description labels")
 	lsEnabled                   = flag.Bool("logical-systems.enabled", false, "Enable logical systems support")
+	policerEnabled              = flag.Bool("policer.enabled", false, "Scrape out of spec counters of all policers (including interface policers)")
 	powerEnabled                = flag.Bool("power.enabled", true, "Scrape power metrics")
 	lacpEnabled                 = flag.Bool("lacp.enabled", false, "Scrape LACP metrics")
 	bfdEnabled                  = flag.Bool("bfd.enabled", false, "Scrape BFD metrics")
@@ -72,8 +157,13 @@ var (
 	mpls_lspEnabled             = flag.Bool("mpls_lsp.enabled", false, "Scrape MPLS LSP metrics")
 	cfg                         *config.Config
 	devices                     []*connector.Device
+	configDevices               []*config.DeviceConfig
 	connManager                 *connector.SSHConnectionManager
+	vaultClient                 *vault.Client
+	reverseNames                *reverseDNSCache
+	cache                       = newMetricCache()
 	reloadCh                    chan chan error
+	pauseToken                  = flag.String("pause.token", "", "Bearer token required to pause and resume targets via /-/pause and /-/resume if no tenants are configured (empty = disabled)")
 	configMu                    sync.RWMutex
 )
 
@@ -93,41 +183,130 @@ func main() {
 		os.Exit(0)
 	}
 
-	err := initialize()
+	if *generateFile != "" {
+		err := generateCustomCollector(*generateFile, *generateName, *generateCommand, os.Stdout)
+		if err != nil {
+			log.Fatalf("could not generate custom collector. %v", err)
+		}
+		os.Exit(0)
+	}
+
+	err := mibwalk.SetCharset(*snmpCharset)
+	if err != nil {
+		log.Fatalf("invalid value for -snmp.charset. %v", err)
+	}
+
+	if *snmpMIBDir != "" {
+		err = mibwalk.LoadMIBs(*snmpMIBDir)
+		if err != nil {
+			log.Fatalf("could not load MIBs from %s. %v", *snmpMIBDir, err)
+		}
+	}
+
+	interfacelabels.SetRefreshInterval(*dynamicIfaceLabelsRefresh)
+
+	err = initAuditLog()
+	if err != nil {
+		log.Fatalf("could not initialize audit log. %v", err)
+	}
+
+	err = initialize()
 	if err != nil {
 		log.Fatalf("could not initialize exporter. %v", err)
 	}
 
+	if *dryRunEnabled {
+		err := dryRun(os.Stdout)
+		if err != nil {
+			log.Fatalf("could not collect metrics. %v", err)
+		}
+		os.Exit(0)
+	}
+
 	initChannels()
 
+	if *tracingEndpoint != "" {
+		shutdown, err := tracing.Init(*tracingEndpoint, *tracingInsecure, *tracingSampleRatio, version)
+		if err != nil {
+			log.Fatalf("could not initialize tracing. %v", err)
+		}
+		defer shutdown(context.Background())
+	}
+
+	if *haLeaseFile != "" {
+		id := *haID
+		if id == "" {
+			id = defaultInstanceID()
+		}
+
+		election = newLeaderElection(*haLeaseFile, id, *haLeaseDuration)
+		election.start()
+	}
+
+	err = initOutputs()
+	if err != nil {
+		log.Fatalf("could not initialize outputs. %v", err)
+	}
+
+	if *interfaceUtilization && *backgroundInterval == 0 {
+		log.Fatal("interface utilization requires background collection (-background.interval)")
+	}
+
+	if *rpmProvisioning && *backgroundInterval == 0 {
+		log.Fatal("RPM provisioning requires background collection (-background.interval)")
+	}
+
+	if *backgroundInterval > 0 {
+		startBackgroundCollection(*backgroundInterval)
+	}
+
+	if *discoverySubnets != "" {
+		subnets, err := discovery.ParseSubnets(*discoverySubnets)
+		if err != nil {
+			log.Fatalf("invalid value for -discovery.subnets. %v", err)
+		}
+
+		startDiscovery(subnets, *discoveryInterval)
+	}
+
+	if *syslogListenAddress != "" {
+		err = startSyslogListener(*syslogListenAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	startServer()
 }
 
 func initChannels() {
 	hup := make(chan os.Signal, 1)
-	signal.Notify(hup, syscall.SIGHUP)
-
 	term := make(chan os.Signal, 1)
-	signal.Notify(term, syscall.SIGTERM)
+	notifySignals(hup, term)
 
 	reloadCh = make(chan chan error)
 	go func() {
 		for {
 			select {
 			case <-hup:
-				log.Infoln("Reload signal received as SIGHUP")
+				log.Infoln("Reload signal received")
+				notifySystemd(systemd.Reloading)
 				if err := reinitialize(); err != nil {
 					log.Errorf("Error reloading config: %s", err)
 				}
+				notifySystemd(systemd.Ready)
 			case rc := <-reloadCh:
 				log.Infoln("Reload signal received via POST")
+				notifySystemd(systemd.Reloading)
 				if err := reinitialize(); err != nil {
 					log.Errorf("Error reloading config: %s", err)
 					rc <- err
 				} else {
 					rc <- nil
 				}
+				notifySystemd(systemd.Ready)
 			case <-term:
+				notifySystemd(systemd.Stopping)
 				log.Infoln("Closing connections to devices")
 				connManager.Close()
 				os.Exit(0)
@@ -149,26 +328,81 @@ func initialize() error {
 		return err
 	}
 
-	devices, err = devicesForConfig(c)
+	hash, err := configHash(c)
+	if err != nil {
+		return err
+	}
+
+	// the credentials of the devices are bound to the vault client, so it has to be replaced while creating them
+	prevVaultClient := vaultClient
+	vaultClient = nil
+	if c.Vault != nil {
+		vaultClient = vault.NewClient(c.Vault)
+	}
+
+	configured := c.Devices
+	devs, err := devicesForNewConfig(c)
+	if err == nil {
+		err = startGNMISubscriptions(c, devs)
+	}
 	if err != nil {
+		if vaultClient != nil {
+			vaultClient.Close()
+		}
+		vaultClient = prevVaultClient
 		return err
 	}
+
+	// all checks passed, the previous state is only replaced now, so a rejected config keeps the exporter running as before
+	if prevVaultClient != nil {
+		prevVaultClient.Close()
+	}
+	if connManager != nil {
+		connManager.Close()
+	}
+
+	devices = devs
 	cfg = c
+	configDevices = configured
+	updateConfigMetrics(c, hash)
 
 	connManager = connectionManager()
+	reverseNames = newReverseDNSCache(*reverseDNSRefreshInterval)
+	responseCache = newMetricCache()
 
 	return nil
 }
 
+// devicesForNewConfig creates the devices of the config and checks them against the shard and the limits
+func devicesForNewConfig(c *config.Config) ([]*connector.Device, error) {
+	devs, err := devicesForConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if *shardDefinition != "" {
+		s, err := parseShard(*shardDefinition)
+		if err != nil {
+			return nil, err
+		}
+
+		total := len(devs)
+		devs = s.filter(devs)
+		log.Infof("Collecting %d of %d targets (shard %s)", len(devs), total, *shardDefinition)
+	}
+
+	err = checkTargetsLimit(len(devs))
+	if err != nil {
+		return nil, err
+	}
+
+	return devs, nil
+}
+
 func reinitialize() error {
 	configMu.Lock()
 	defer configMu.Unlock()
 
-	if connManager != nil {
-		connManager.Close()
-		connManager = nil
-	}
-
 	return initialize()
 }
 
@@ -195,28 +429,45 @@ func loadConfigFromFlags() *config.Config {
 	f := &c.Features
 	f.Alarm = *alarmEnabled
 	f.BGP = *bgpEnabled
+	f.Commit = *commitEnabled
+	f.Components = *componentsEnabled
+	f.Craft = *craftEnabled
 	f.Environment = *environmentEnabled
+	f.Fabric = *fabricEnabled
 	f.Firewall = *firewallEnabled
+	f.HostResources = *hostResourcesEnabled
 	f.Interfaces = *interfacesEnabled
+	f.MCLAG = *mclagEnabled
 	f.InterfaceDiagnostic = *interfaceDiagnosticsEnabled
 	f.InterfaceQueue = *interfaceQueuesEnabled
 	f.IPSec = *ipsecEnabled
 	f.Security = *securityEnabled
+	f.ALG = *algEnabled
+	f.SecurityServices = *securityServicesEnabled
 	f.ISIS = *isisEnabled
 	f.NAT = *natEnabled
 	f.NAT2 = *nat2Enabled
 	f.OSPF = *ospfEnabled
 	f.LDP = *ldpEnabled
+	f.License = *licenseEnabled
 	f.L2Circuit = *l2circuitEnabled
+	f.L2Events = *l2EventsEnabled
 	f.Routes = *routesEnabled
 	f.RoutingEngine = *routingEngineEnabled
 	f.Accounting = *accountingEnabled
 	f.FPC = *fpcEnabled
 	f.RPKI = *rpkiEnabled
+	f.RPD = *rpdEnabled
 	f.Storage = *storageEnabled
 	f.Satellite = *satelliteEnabled
+	f.SFlow = *sflowEnabled
+	f.SNMP = *snmpEnabled
 	f.System = *systemEnabled
+	f.Transceiver = *transceiverEnabled
+	f.Tunnel = *tunnelEnabled
+	f.VPN = *vpnEnabled
 	f.Power = *powerEnabled
+	f.Policer = *policerEnabled
 	f.MAC = *macEnabled
 
 	return c
@@ -227,6 +478,10 @@ func connectionManager() *connector.SSHConnectionManager {
 		connector.WithReconnectInterval(*sshReconnectInterval),
 		connector.WithKeepAliveInterval(*sshKeepAliveInterval),
 		connector.WithKeepAliveTimeout(*sshKeepAliveTimeout),
+		connector.WithCommandTimeout(*sshCommandTimeout),
+		connector.WithMaxOutputSize(*sshMaxOutputSize),
+		connector.WithDNSCacheTTL(*dnsCacheTTL),
+		connector.WithIdleTimeout(*sshIdleTimeout),
 	}
 
 	return connector.NewConnectionManager(opts...)
@@ -240,16 +495,66 @@ func startServer() {
 			<body>
 			<h1>JunOS Exporter</h1>
 			<p><a href="` + *metricsPath + `">Metrics</a></p>
+			<p><a href="/status">Status</a></p>
+			` + exporterMetricsLink() + `
 			<h2>More information:</h2>
 			<p><a href="https://github.com/czerwonk/junos_exporter">github.com/czerwonk/junos_exporter</a></p>
 			</body>
 			</html>`))
 	})
-	http.HandleFunc(*metricsPath, handleMetricsRequest)
+	http.Handle(*metricsPath, instrumentMetricsHandler(handleMetricsRequest))
+	http.HandleFunc("/status", handleStatusRequest)
+
+	if *exporterMetricsPath != "" {
+		http.Handle(*exporterMetricsPath, exporterMetricsHandler())
+	}
 	http.HandleFunc("/-/reload", updateConfiguration)
+	http.HandleFunc("/-/pause", handlePauseRequest)
+	http.HandleFunc("/-/resume", handlePauseRequest)
+
+	l, err := net.Listen("tcp", *listenAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	log.Infof("Listening for %s on %s\n", *metricsPath, *listenAddress)
-	log.Fatal(http.ListenAndServe(*listenAddress, nil))
+	notifySystemd(systemd.Ready)
+	startWatchdog()
+
+	log.Fatal(http.Serve(l, nil))
+}
+
+func notifySystemd(state string) {
+	_, err := systemd.Notify(state)
+	if err != nil {
+		log.Errorf("Could not notify systemd: %v", err)
+	}
+}
+
+// startWatchdog sends keep alives to systemd if the watchdog is enabled for the service.
+// Keep alives are only sent if the config lock can be acquired, so systemd restarts the exporter if it hangs (e.g. while reloading)
+func startWatchdog() {
+	interval := systemd.WatchdogInterval()
+	if interval == 0 {
+		return
+	}
+
+	go func() {
+		for range time.Tick(interval / 2) {
+			configMu.RLock()
+			configMu.RUnlock()
+
+			notifySystemd(systemd.Watchdog)
+		}
+	}()
+}
+
+func exporterMetricsLink() string {
+	if *exporterMetricsPath == "" {
+		return ""
+	}
+
+	return `<p><a href="` + *exporterMetricsPath + `">Exporter Metrics</a></p>`
 }
 
 func updateConfiguration(w http.ResponseWriter, r *http.Request) {
@@ -272,25 +577,74 @@ func handleMetricsRequest(w http.ResponseWriter, r *http.Request) {
 
 	reg := prometheus.NewRegistry()
 
+	tenant, code := tenantForRequest(r)
+	if code != http.StatusOK {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+
 	devs, err := devicesForRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), 400)
 		return
 	}
 
+	devs = devicesForTenant(tenant, devs)
+	if len(devs) == 0 && r.URL.Query().Get("target") != "" {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
 	logicalSystem := r.URL.Query().Get("ls")
 	if !cfg.LSEnabled && logicalSystem != "" {
 		http.Error(w, fmt.Sprintf("Logical systems not enabled but the logical system '%s' in parameters", logicalSystem), 400)
 		return
 	}
 
-	c := newJunosCollector(devs, connManager, logicalSystem)
-	reg.MustRegister(c)
+	if election != nil {
+		reg.MustRegister(&leaderCollector{election: election})
+
+		if !election.isLeader() {
+			serveMetrics(reg, w, r)
+			return
+		}
+	}
+
+	overrides, err := overridesForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	ctx, span := tracing.Start(r.Context(), "scrape", attribute.String("target", r.URL.Query().Get("target")))
+	defer span.End()
+
+	collectorForDevices := func(devs []*connector.Device) prometheus.Collector {
+		if overrides != nil {
+			return newAdHocCollector(ctx, devs, logicalSystem, overrides)
+		}
+
+		if *backgroundInterval > 0 && logicalSystem == "" {
+			return newCachedCollector(ctx, devs)
+		}
+
+		return newCoalescingCollector(ctx, devs, logicalSystem)
+	}
+
+	if *reverseDNSEnabled || cfg.HasDeviceLabels() {
+		registerWithTargetLabels(reg, devs, collectorForDevices)
+	} else {
+		reg.MustRegister(collectorForDevices(devs))
+	}
+
+	serveMetrics(reg, w, r)
+}
 
+func serveMetrics(reg *prometheus.Registry, w http.ResponseWriter, r *http.Request) {
 	l := log.New()
 	l.Level = log.ErrorLevel
 
-	promhttp.HandlerFor(reg, promhttp.HandlerOpts{
+	promhttp.HandlerFor(withMetricNames(withTransformations(reg)), promhttp.HandlerOpts{
 		ErrorLog:      l,
 		ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(w, r)
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectedReloadKeepsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	prevConfigFile := *configFile
+	*configFile = path
+	*targetsLimit = 1
+	defer func() {
+		*configFile = prevConfigFile
+		*targetsLimit = 0
+		if connManager != nil {
+			connManager.Close()
+			connManager = nil
+		}
+	}()
+
+	err := ioutil.WriteFile(path, []byte("password: secret\ndevices:\n  - host: router1\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, reinitialize())
+
+	prevConnManager := connManager
+	prevConfig := cfg
+
+	err = ioutil.WriteFile(path, []byte("password: secret\ndevices:\n  - host: router1\n  - host: router2\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, reinitialize(), "targets limit exceeded")
+
+	if assert.Len(t, devices, 1) {
+		assert.Equal(t, "router1", devices[0].Host)
+	}
+	assert.Same(t, prevConfig, cfg)
+	assert.Same(t, prevConnManager, connManager, "connection manager of the previous config is kept")
+}
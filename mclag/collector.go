@@ -0,0 +1,134 @@
+package mclag
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_mclag_"
+
+var (
+	iccpConnectedDesc      *prometheus.Desc
+	iccpLivenessDesc       *prometheus.Desc
+	iccpBackupLivenessDesc *prometheus.Desc
+	redundancyGroupUpDesc  *prometheus.Desc
+	mcaeLocalUpDesc        *prometheus.Desc
+	mcaePeerUpDesc         *prometheus.Desc
+	mcaeLocalActiveDesc    *prometheus.Desc
+	mcaePeerActiveDesc     *prometheus.Desc
+	iclUpDesc              *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target", "peer"}
+	iccpConnectedDesc = prometheus.NewDesc(prefix+"iccp_peer_connected", "TCP connection of ICCP to the peer is established (1 = established)", l, nil)
+	iccpLivenessDesc = prometheus.NewDesc(prefix+"iccp_peer_liveness_up", "Liveness detection (BFD) of the ICCP peer is up (1 = up)", l, nil)
+	iccpBackupLivenessDesc = prometheus.NewDesc(prefix+"iccp_peer_backup_liveness_up", "Backup liveness detection of the ICCP peer is up (1 = up)", l, nil)
+	redundancyGroupUpDesc = prometheus.NewDesc(prefix+"iccp_redundancy_group_up", "Redundancy group shared with the ICCP peer is up (1 = up)", append(l, "redundancy_group"), nil)
+
+	l = []string{"target", "interface"}
+	mcaeLocalUpDesc = prometheus.NewDesc(prefix+"mcae_local_up", "Local side of the multi-chassis aggregated ethernet interface is up (1 = up)", l, nil)
+	mcaePeerUpDesc = prometheus.NewDesc(prefix+"mcae_peer_up", "Peer side of the multi-chassis aggregated ethernet interface is up (1 = up)", l, nil)
+	mcaeLocalActiveDesc = prometheus.NewDesc(prefix+"mcae_local_active", "Local side of the multi-chassis aggregated ethernet interface is active (1 = active, 0 = standby or unknown)", l, nil)
+	mcaePeerActiveDesc = prometheus.NewDesc(prefix+"mcae_peer_active", "Peer side of the multi-chassis aggregated ethernet interface is active (1 = active, 0 = standby or unknown)", l, nil)
+	iclUpDesc = prometheus.NewDesc(prefix+"icl_up", "Inter-chassis link protecting the logical interface is up (1 = up)", append(l, "peer", "icl"), nil)
+}
+
+type mclagCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &mclagCollector{}
+}
+
+// Name returns the name of the collector
+func (*mclagCollector) Name() string {
+	return "MC-LAG"
+}
+
+// Describe describes the metrics
+func (*mclagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- iccpConnectedDesc
+	ch <- iccpLivenessDesc
+	ch <- iccpBackupLivenessDesc
+	ch <- redundancyGroupUpDesc
+	ch <- mcaeLocalUpDesc
+	ch <- mcaePeerUpDesc
+	ch <- mcaeLocalActiveDesc
+	ch <- mcaePeerActiveDesc
+	ch <- iclUpDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *mclagCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	err := c.collectICCP(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	return c.collectMCAE(client, ch, labelValues)
+}
+
+func (c *mclagCollector) collectICCP(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = iccpRpc{}
+	err := client.RunCommandAndParse("show iccp", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range x.Peers {
+		l := append(labelValues, p.Address)
+
+		ch <- prometheus.MustNewConstMetric(iccpConnectedDesc, prometheus.GaugeValue, stateToFloat(p.TCPConnection, "established"), l...)
+		ch <- prometheus.MustNewConstMetric(iccpLivenessDesc, prometheus.GaugeValue, stateToFloat(p.Liveness, "up"), l...)
+
+		if p.BackupLiveness != "" {
+			ch <- prometheus.MustNewConstMetric(iccpBackupLivenessDesc, prometheus.GaugeValue, stateToFloat(p.BackupLiveness, "up"), l...)
+		}
+
+		for _, g := range p.RedundancyGroups {
+			ch <- prometheus.MustNewConstMetric(redundancyGroupUpDesc, prometheus.GaugeValue, stateToFloat(g.Status, "up"), append(l, g.ID)...)
+		}
+	}
+
+	return nil
+}
+
+func (c *mclagCollector) collectMCAE(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = mcaeRpc{}
+	err := client.RunCommandAndParse("show interfaces mc-ae", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range x.Interfaces {
+		l := append(labelValues, i.Name)
+
+		ch <- prometheus.MustNewConstMetric(mcaeLocalUpDesc, prometheus.GaugeValue, stateToFloat(i.LocalState, "up"), l...)
+		ch <- prometheus.MustNewConstMetric(mcaePeerUpDesc, prometheus.GaugeValue, stateToFloat(i.PeerState, "up"), l...)
+		ch <- prometheus.MustNewConstMetric(mcaeLocalActiveDesc, prometheus.GaugeValue, stateToFloat(i.LocalStatus, "active"), l...)
+		ch <- prometheus.MustNewConstMetric(mcaePeerActiveDesc, prometheus.GaugeValue, stateToFloat(i.PeerStatus, "active"), l...)
+
+		for _, u := range i.Units {
+			if u.ICL == "" {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(iclUpDesc, prometheus.GaugeValue, stateToFloat(u.ICLState, "up"), append(labelValues, u.Name, u.PeerIP, u.ICL)...)
+		}
+	}
+
+	return nil
+}
+
+func stateToFloat(state, expected string) float64 {
+	if strings.EqualFold(strings.TrimSpace(state), expected) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,38 @@
+package mclag
+
+type iccpRpc struct {
+	Peers []iccpPeer `xml:"iccp>iccp-peer"`
+}
+
+type iccpPeer struct {
+	Address          string                `xml:"iccp-peer-address"`
+	TCPConnection    string                `xml:"iccp-tcp-connection-state"`
+	Liveness         string                `xml:"iccp-liveliness-detection-state"`
+	BackupLiveness   string                `xml:"iccp-backup-liveliness-detection-state"`
+	RedundancyGroups []iccpRedundancyGroup `xml:"iccp-redundancy-group"`
+}
+
+type iccpRedundancyGroup struct {
+	ID     string `xml:"iccp-redundancy-group-id"`
+	Status string `xml:"iccp-redundancy-group-status"`
+}
+
+type mcaeRpc struct {
+	Interfaces []mcaeInterface `xml:"mcae-interface-information>mcae-interface"`
+}
+
+type mcaeInterface struct {
+	Name        string                 `xml:"mcae-interface-name"`
+	LocalStatus string                 `xml:"mcae-local-status"`
+	LocalState  string                 `xml:"mcae-local-state"`
+	PeerStatus  string                 `xml:"mcae-peer-status"`
+	PeerState   string                 `xml:"mcae-peer-state"`
+	Units       []mcaeLogicalInterface `xml:"mcae-logical-interface"`
+}
+
+type mcaeLogicalInterface struct {
+	Name     string `xml:"mcae-logical-interface-name"`
+	PeerIP   string `xml:"mcae-peer-ip"`
+	ICL      string `xml:"mcae-icl-interface"`
+	ICLState string `xml:"mcae-icl-state"`
+}
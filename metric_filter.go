@@ -0,0 +1,47 @@
+package main
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxCachedMetricNames limits the names cached, as some collectors create their descs per scrape (e.g. interfaces with dynamic labels)
+const maxCachedMetricNames = 10000
+
+var fqNameRe = regexp.MustCompile(`fqName: "([^"]+)"`)
+
+var metricNames = struct {
+	sync.RWMutex
+	names map[*prometheus.Desc]string
+}{names: make(map[*prometheus.Desc]string)}
+
+// metricName returns the name of a metric. Since the name is not accessible via prometheus.Desc it is parsed from its string representation.
+// Parsed names are cached per desc, as this is done for every metric of every scrape.
+func metricName(m prometheus.Metric) string {
+	desc := m.Desc()
+
+	metricNames.RLock()
+	name, found := metricNames.names[desc]
+	metricNames.RUnlock()
+
+	if found {
+		return name
+	}
+
+	match := fqNameRe.FindStringSubmatch(desc.String())
+	if match != nil {
+		name = match[1]
+	}
+
+	metricNames.Lock()
+	defer metricNames.Unlock()
+
+	if len(metricNames.names) >= maxCachedMetricNames {
+		metricNames.names = make(map[*prometheus.Desc]string)
+	}
+	metricNames.names[desc] = name
+
+	return name
+}
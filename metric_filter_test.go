@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricName(t *testing.T) {
+	desc := prometheus.NewDesc("junos_interface_receive_bytes", "Received data in bytes", []string{"target", "name"}, nil)
+	m := prometheus.MustNewConstMetric(desc, prometheus.CounterValue, 42, "router1", "xe-0/0/0")
+
+	assert.Equal(t, "junos_interface_receive_bytes", metricName(m))
+}
+
+func TestMetricNameCached(t *testing.T) {
+	desc := prometheus.NewDesc("junos_interface_transmit_bytes", "Transmitted data in bytes", []string{"target", "name"}, nil)
+	m := prometheus.MustNewConstMetric(desc, prometheus.CounterValue, 42, "router1", "xe-0/0/0")
+
+	assert.Equal(t, "junos_interface_transmit_bytes", metricName(m))
+
+	metricNames.RLock()
+	defer metricNames.RUnlock()
+	assert.Equal(t, "junos_interface_transmit_bytes", metricNames.names[desc])
+	assert.LessOrEqual(t, len(metricNames.names), maxCachedMetricNames)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// withMetricNames renames the gathered metric families according to the configured naming scheme (see metric_names)
+func withMetricNames(g prometheus.Gatherer) prometheus.Gatherer {
+	names := cfg.MetricNames
+	if names == nil {
+		return g
+	}
+
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := g.Gather()
+		for _, mf := range mfs {
+			mf.Name = proto.String(names.Rename(mf.GetName()))
+		}
+
+		return mfs, err
+	})
+}
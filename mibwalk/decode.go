@@ -0,0 +1,109 @@
+package mibwalk
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var charset = "utf-8"
+
+// SetCharset sets the character set used to decode octet strings (utf-8 or latin1)
+func SetCharset(name string) error {
+	switch strings.ToLower(name) {
+	case "utf-8", "utf8":
+		charset = "utf-8"
+	case "latin1", "iso-8859-1":
+		charset = "latin1"
+	default:
+		return fmt.Errorf("unsupported charset %s", name)
+	}
+
+	return nil
+}
+
+// DecodeString decodes the value of an octet string object (e.g. ifAlias) to be used as label value.
+// Some agents return DisplayStrings as hex encoded octets (e.g. 0x666f6f or 66 6f 6f) or padded with null bytes.
+// Hex encoded values are only decoded if the result is printable text, so identifiers like MAC addresses are kept as is.
+func DecodeString(value string, isHex bool) string {
+	s := strings.TrimSpace(value)
+
+	b, ok := hexOctets(s, isHex)
+	if ok {
+		if text, printable := decodeText(trimNull(b)); printable {
+			return text
+		}
+
+		return s
+	}
+
+	text, _ := decodeText(trimNull([]byte(s)))
+	return text
+}
+
+// hexOctets decodes hex strings prefixed by 0x or octets separated by space or colon.
+// Single octets are only decoded if the agent declared the value as hex.
+func hexOctets(s string, isHex bool) ([]byte, bool) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		b, err := hex.DecodeString(s[2:])
+		return b, err == nil && len(b) > 0
+	}
+
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ':'
+	})
+	if len(parts) == 0 || (len(parts) == 1 && !isHex) {
+		return nil, false
+	}
+
+	b := make([]byte, len(parts))
+	for i, p := range parts {
+		if len(p) != 2 {
+			return nil, false
+		}
+
+		v, err := hex.DecodeString(p)
+		if err != nil {
+			return nil, false
+		}
+
+		b[i] = v[0]
+	}
+
+	return b, true
+}
+
+func trimNull(b []byte) []byte {
+	for len(b) > 0 && b[len(b)-1] == 0 {
+		b = b[:len(b)-1]
+	}
+
+	return b
+}
+
+// decodeText converts the octets to UTF-8 using the configured charset and reports whether the text is printable.
+// Invalid UTF-8 sequences are replaced since label values have to be valid UTF-8.
+func decodeText(b []byte) (string, bool) {
+	var s string
+	if charset == "latin1" && !utf8.Valid(b) {
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		s = string(runes)
+	} else {
+		s = string(b)
+	}
+
+	printable := utf8.ValidString(s)
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			printable = false
+			break
+		}
+	}
+
+	return strings.ToValidUTF8(s, "�"), printable
+}
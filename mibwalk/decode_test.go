@@ -0,0 +1,43 @@
+package mibwalk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		isHex    bool
+		expected string
+	}{
+		{name: "text", value: " uplink to core ", expected: "uplink to core"},
+		{name: "hex with prefix", value: "0x75706c696e6b", expected: "uplink"},
+		{name: "hex octets", value: "75 70 6c 69 6e 6b", expected: "uplink"},
+		{name: "hex with trailing nulls", value: "75 70 00 00", expected: "up"},
+		{name: "single octet declared as hex", value: "41", isHex: true, expected: "A"},
+		{name: "single octet text", value: "41", expected: "41"},
+		{name: "mac address", value: "00:00:5e:00:01:01", expected: "00:00:5e:00:01:01"},
+		{name: "not hex", value: "ae 0 uplink", expected: "ae 0 uplink"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DecodeString(test.value, test.isHex))
+		})
+	}
+}
+
+func TestDecodeStringCharset(t *testing.T) {
+	defer SetCharset("utf-8")
+
+	assert.Equal(t, "4d fc 6e 63 68 65 6e", DecodeString("4d fc 6e 63 68 65 6e", false), "not valid UTF-8")
+
+	assert.NoError(t, SetCharset("latin1"))
+	assert.Equal(t, "München", DecodeString("4d fc 6e 63 68 65 6e", false))
+	assert.Equal(t, "München", DecodeString("München", false), "valid UTF-8 is kept")
+
+	assert.Error(t, SetCharset("ebcdic"))
+}
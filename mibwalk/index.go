@@ -0,0 +1,173 @@
+package mibwalk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IndexType defines how a part of a table index is encoded in the sub-identifiers of an OID
+type IndexType int
+
+const (
+	// Integer is a single sub-identifier (e.g. ifIndex)
+	Integer IndexType = iota
+	// IPv4Address consists of four sub-identifiers
+	IPv4Address
+	// String is an octet string prefixed by its length
+	String
+	// ImpliedString is an octet string without length prefix, it has to be the last part of the index
+	ImpliedString
+)
+
+// IndexPart is a part of a (composite) table index decoded into a label
+type IndexPart struct {
+	Label string
+	Type  IndexType
+}
+
+// Index describes the parts of a table index, e.g. jnxCosQstatTable is indexed by ifIndex and queue number:
+//
+//	mibwalk.Index{{Label: "if_index", Type: mibwalk.Integer}, {Label: "queue", Type: mibwalk.Integer}}
+type Index []IndexPart
+
+// Labels returns the label names of the index parts
+func (idx Index) Labels() []string {
+	labels := make([]string, len(idx))
+	for i, p := range idx {
+		labels[i] = p.Label
+	}
+
+	return labels
+}
+
+// Decode decodes the index of an object (e.g. 544.0) into one label value per index part
+func (idx Index) Decode(index string) ([]string, error) {
+	ids := subIdentifiers(index)
+
+	values := make([]string, len(idx))
+	for i, p := range idx {
+		if p.Type == ImpliedString && i < len(idx)-1 {
+			return nil, fmt.Errorf("implied string %s has to be the last part of the index", p.Label)
+		}
+
+		v, n, err := p.decode(ids)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %s of index %s: %w", p.Label, index, err)
+		}
+
+		values[i] = v
+		ids = ids[n:]
+	}
+
+	if len(ids) > 0 {
+		return nil, fmt.Errorf("index %s has more sub-identifiers than expected", index)
+	}
+
+	return values, nil
+}
+
+// decode decodes the part from the beginning of ids and returns the value and the number of sub-identifiers consumed
+func (p IndexPart) decode(ids []string) (string, int, error) {
+	if len(ids) == 0 {
+		return "", 0, fmt.Errorf("index too short")
+	}
+
+	switch p.Type {
+	case Integer:
+		if _, err := strconv.ParseUint(ids[0], 10, 32); err != nil {
+			return "", 0, err
+		}
+
+		return ids[0], 1, nil
+	case IPv4Address:
+		if len(ids) < 4 {
+			return "", 0, fmt.Errorf("index too short")
+		}
+
+		if _, err := octets(ids[:4]); err != nil {
+			return "", 0, err
+		}
+
+		return strings.Join(ids[:4], "."), 4, nil
+	case String:
+		if s, ok := quoted(ids[0]); ok {
+			return s, 1, nil
+		}
+
+		l, err := strconv.Atoi(ids[0])
+		if err != nil {
+			return "", 0, err
+		}
+
+		if len(ids) < l+1 {
+			return "", 0, fmt.Errorf("index too short")
+		}
+
+		b, err := octets(ids[1 : l+1])
+		if err != nil {
+			return "", 0, err
+		}
+
+		return string(b), l + 1, nil
+	case ImpliedString:
+		if s, ok := quoted(ids[0]); ok && len(ids) == 1 {
+			return s, 1, nil
+		}
+
+		b, err := octets(ids)
+		if err != nil {
+			return "", 0, err
+		}
+
+		return string(b), len(ids), nil
+	}
+
+	return "", 0, fmt.Errorf("unknown index type %d", p.Type)
+}
+
+// subIdentifiers splits an index into its sub-identifiers. Junos displays string indices in quotes when walking with the ascii option
+// (e.g. "probe"."test".1), quoted strings are kept as a single element.
+func subIdentifiers(index string) []string {
+	ids := make([]string, 0)
+	inQuotes := false
+	start := 0
+
+	for i, r := range index {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '.' && !inQuotes:
+			ids = append(ids, index[start:i])
+			start = i + 1
+		}
+	}
+
+	if len(index) > 0 {
+		ids = append(ids, index[start:])
+	}
+
+	return ids
+}
+
+func quoted(id string) (string, bool) {
+	if len(id) < 2 || !strings.HasPrefix(id, `"`) || !strings.HasSuffix(id, `"`) {
+		return "", false
+	}
+
+	return id[1 : len(id)-1], true
+}
+
+func octets(ids []string) ([]byte, error) {
+	b := make([]byte, len(ids))
+	for i, id := range ids {
+		v, err := strconv.ParseUint(id, 10, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		b[i] = byte(v)
+	}
+
+	return b, nil
+}
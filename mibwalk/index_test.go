@@ -0,0 +1,124 @@
+package mibwalk
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    Index
+		value    string
+		expected []string
+		fail     bool
+	}{
+		{
+			name:     "interface and queue",
+			index:    Index{{Label: "if_index", Type: Integer}, {Label: "queue", Type: Integer}},
+			value:    "544.3",
+			expected: []string{"544", "3"},
+		},
+		{
+			name:     "ipv4 address",
+			index:    Index{{Label: "peer", Type: IPv4Address}, {Label: "afi", Type: Integer}},
+			value:    "192.0.2.1.1",
+			expected: []string{"192.0.2.1", "1"},
+		},
+		{
+			name:     "length prefixed strings",
+			index:    Index{{Label: "owner", Type: String}, {Label: "test", Type: String}, {Label: "type", Type: Integer}},
+			value:    "2.112.49.3.116.101.115.1",
+			expected: []string{"p1", "tes", "1"},
+		},
+		{
+			name:     "quoted strings",
+			index:    Index{{Label: "owner", Type: String}, {Label: "test", Type: ImpliedString}},
+			value:    `"probe.a"."test"`,
+			expected: []string{"probe.a", "test"},
+		},
+		{
+			name:     "implied string",
+			index:    Index{{Label: "if_index", Type: Integer}, {Label: "name", Type: ImpliedString}},
+			value:    "10.97.98",
+			expected: []string{"10", "ab"},
+		},
+		{
+			name:  "too short",
+			index: Index{{Label: "if_index", Type: Integer}, {Label: "queue", Type: Integer}},
+			value: "544",
+			fail:  true,
+		},
+		{
+			name:  "too long",
+			index: Index{{Label: "if_index", Type: Integer}},
+			value: "544.3",
+			fail:  true,
+		},
+		{
+			name:  "implied string not last",
+			index: Index{{Label: "name", Type: ImpliedString}, {Label: "queue", Type: Integer}},
+			value: "97.1",
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := test.index.Decode(test.value)
+			if test.fail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <snmp-object-information xmlns="http://xml.juniper.net/junos/21.4R3/junos-snmp">
+        <snmp-object>
+            <name>jnxCosQstatQedPkts.544.0</name>
+            <object-value-type>number</object-value-type>
+            <object-value>100</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>jnxCosQstatQedPkts.544.3</name>
+            <object-value-type>number</object-value-type>
+            <object-value>300</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>jnxCosQstatTailDropPkts.544.3</name>
+            <object-value-type>number</object-value-type>
+            <object-value>7</object-value>
+        </snmp-object>
+        <snmp-object>
+            <name>jnxCosQstatQedPkts.544</name>
+            <object-value-type>number</object-value-type>
+            <object-value>1</object-value>
+        </snmp-object>
+    </snmp-object-information>
+</rpc-reply>`
+
+	x := Result{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := Index{{Label: "if_index", Type: Integer}, {Label: "queue", Type: Integer}}
+	assert.Equal(t, []string{"if_index", "queue"}, idx.Labels())
+
+	rows := idx.Rows(x.Information.Objects)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"544", "0"}, rows[0].Labels)
+	assert.Equal(t, float64(100), rows[0].Float("jnxCosQstatQedPkts"))
+	assert.False(t, rows[0].Has("jnxCosQstatTailDropPkts"))
+	assert.Equal(t, []string{"544", "3"}, rows[1].Labels)
+	assert.Equal(t, float64(7), rows[1].Float("jnxCosQstatTailDropPkts"))
+}
@@ -0,0 +1,199 @@
+package mibwalk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	mibStringRe      = regexp.MustCompile(`"[^"]*"`)
+	mibCommentRe     = regexp.MustCompile(`--[^\n]*`)
+	mibAssignmentRe  = regexp.MustCompile(`(?s)\b([a-z][A-Za-z0-9-]*)\s+(?:OBJECT\s+IDENTIFIER|OBJECT-TYPE|MODULE-IDENTITY|OBJECT-IDENTITY|NOTIFICATION-TYPE|OBJECT-GROUP|NOTIFICATION-GROUP|MODULE-COMPLIANCE|AGENT-CAPABILITIES)\b.*?::=\s*\{([^}]*)\}`)
+	mibNamedNumberRe = regexp.MustCompile(`^([a-z][A-Za-z0-9-]*)\((\d+)\)$`)
+
+	// wellKnownOIDs are the roots of the registration tree, so MIBs can be resolved without loading SNMPv2-SMI
+	wellKnownOIDs = map[string]string{
+		"ccitt":           "0",
+		"iso":             "1",
+		"joint-iso-ccitt": "2",
+		"org":             "1.3",
+		"dod":             "1.3.6",
+		"internet":        "1.3.6.1",
+		"directory":       "1.3.6.1.1",
+		"mgmt":            "1.3.6.1.2",
+		"mib-2":           "1.3.6.1.2.1",
+		"transmission":    "1.3.6.1.2.1.10",
+		"experimental":    "1.3.6.1.3",
+		"private":         "1.3.6.1.4",
+		"enterprises":     "1.3.6.1.4.1",
+		"security":        "1.3.6.1.5",
+		"snmpV2":          "1.3.6.1.6",
+		"snmpDomains":     "1.3.6.1.6.1",
+		"snmpProxys":      "1.3.6.1.6.2",
+		"snmpModules":     "1.3.6.1.6.3",
+	}
+
+	names *mibNames
+)
+
+// mibNames maps the OIDs of the objects defined in the loaded MIBs to their names
+type mibNames struct {
+	byOID  map[string]string
+	byName map[string]string
+}
+
+type mibDefinition struct {
+	parent string
+	ids    []string
+}
+
+// LoadMIBs loads the MIB files in the directory, so numeric object names returned by MIB walks are resolved (e.g. jnxOperatingCPU.9.1.0.0)
+func LoadMIBs(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	defs := make(map[string]*mibDefinition)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+
+		parseMIB(string(b), defs)
+	}
+
+	n, err := resolveMIBNames(defs)
+	if err != nil {
+		return err
+	}
+
+	names = n
+	return nil
+}
+
+// parseMIB adds the OID assignments of the MIB module to defs (the first definition of a name wins)
+func parseMIB(s string, defs map[string]*mibDefinition) {
+	s = mibStringRe.ReplaceAllString(s, `""`)
+	s = mibCommentRe.ReplaceAllString(s, "")
+
+	for _, m := range mibAssignmentRe.FindAllStringSubmatch(s, -1) {
+		tokens := strings.Fields(m[2])
+		if len(tokens) < 2 {
+			continue
+		}
+
+		parent := tokens[0]
+		ids := make([]string, 0, 1)
+		valid := true
+		for _, t := range tokens[1:] {
+			if nm := mibNamedNumberRe.FindStringSubmatch(t); nm != nil && len(ids) == 0 {
+				// e.g. { iso org(3) dod(6) 1 } also defines org and dod
+				if _, found := defs[nm[1]]; !found {
+					defs[nm[1]] = &mibDefinition{parent: parent, ids: []string{nm[2]}}
+				}
+				parent = nm[1]
+				continue
+			}
+
+			if _, err := strconv.ParseUint(t, 10, 32); err != nil {
+				valid = false
+				break
+			}
+			ids = append(ids, t)
+		}
+
+		if _, found := defs[m[1]]; valid && len(ids) > 0 && !found {
+			defs[m[1]] = &mibDefinition{parent: parent, ids: ids}
+		}
+	}
+}
+
+func resolveMIBNames(defs map[string]*mibDefinition) (*mibNames, error) {
+	n := &mibNames{byOID: make(map[string]string), byName: make(map[string]string)}
+	for name, oid := range wellKnownOIDs {
+		n.byName[name] = oid
+	}
+
+	var resolve func(name string, depth int) (string, bool)
+	resolve = func(name string, depth int) (string, bool) {
+		if oid, found := n.byName[name]; found {
+			return oid, true
+		}
+
+		def, found := defs[name]
+		if !found || depth > len(defs) {
+			return "", false
+		}
+
+		parent, ok := resolve(def.parent, depth+1)
+		if !ok {
+			return "", false
+		}
+
+		oid := parent + "." + strings.Join(def.ids, ".")
+		n.byName[name] = oid
+		return oid, true
+	}
+
+	sorted := make([]string, 0, len(defs))
+	for name := range defs {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		resolve(name, 0)
+	}
+
+	if len(n.byName) == len(wellKnownOIDs) {
+		return nil, fmt.Errorf("no object definitions found")
+	}
+
+	for _, name := range sorted {
+		if oid, found := n.byName[name]; found {
+			if _, exists := n.byOID[oid]; !exists {
+				n.byOID[oid] = name
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// ObjectName returns the name of a numeric OID with the remaining sub-identifiers as suffix (e.g. sysObjectID.0).
+// The OID is returned unchanged if no MIBs are loaded or no object of the loaded MIBs is a prefix of it.
+func ObjectName(oid string) string {
+	if names == nil {
+		return oid
+	}
+
+	oid = strings.TrimPrefix(oid, ".")
+	for prefix := oid; prefix != ""; {
+		if name, found := names.byOID[prefix]; found {
+			return name + strings.TrimPrefix(oid, prefix)
+		}
+
+		i := strings.LastIndex(prefix, ".")
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+
+	return oid
+}
+
+func isNumericOID(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
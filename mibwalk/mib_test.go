@@ -0,0 +1,42 @@
+package mibwalk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMIBs(t *testing.T) {
+	defer func() {
+		names = nil
+	}()
+
+	assert.Equal(t, "1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0", ObjectName("1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0"), "no MIBs loaded")
+
+	if !assert.NoError(t, LoadMIBs("testdata/mibs")) {
+		return
+	}
+
+	tests := []struct {
+		oid      string
+		expected string
+	}{
+		{oid: "1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0", expected: "jnxOperatingCPU.9.1.0.0"},
+		{oid: ".1.3.6.1.4.1.2636.3.1.13", expected: "jnxOperatingTable"},
+		{oid: "1.3.6.1.4.1.2636.1.1.1.2.21", expected: "jnxProducts.1.1.2.21"},
+		{oid: "1.3.6.1.2.1.1.2.0", expected: "1.3.6.1.2.1.1.2.0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.oid, func(t *testing.T) {
+			assert.Equal(t, test.expected, ObjectName(test.oid))
+		})
+	}
+
+	o := Object{Name: "1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0"}
+	col, index := o.Column()
+	assert.Equal(t, "jnxOperatingCPU", col)
+	assert.Equal(t, "9.1.0.0", index)
+
+	assert.Error(t, LoadMIBs("testdata"), "no MIB files")
+}
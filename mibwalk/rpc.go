@@ -0,0 +1,55 @@
+package mibwalk
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Result is the output of "show snmp mib walk <object>"
+type Result struct {
+	Information struct {
+		Objects []Object `xml:"snmp-object"`
+	} `xml:"snmp-object-information"`
+}
+
+// Object is a single object instance returned by the walk
+type Object struct {
+	Name      string `xml:"name"`
+	Value     string `xml:"object-value"`
+	ValueType string `xml:"object-value-type"`
+}
+
+// Column splits the name of an object (e.g. hrStorageDescr.1) into column name and index.
+// Numeric names (objects of MIBs unknown to the device) are resolved using the MIBs loaded by LoadMIBs.
+func (o *Object) Column() (string, string) {
+	name := o.Name
+	if isNumericOID(name) {
+		name = ObjectName(name)
+	}
+
+	i := strings.Index(name, ".")
+	if i < 0 {
+		return name, ""
+	}
+
+	return name[:i], name[i+1:]
+}
+
+// Float returns the value of the object as number (0 for non numeric values)
+func (o *Object) Float() float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(o.Value), 64)
+	if err != nil {
+		return 0
+	}
+
+	return f
+}
+
+// String returns the value of the object decoded as text (see DecodeString)
+func (o *Object) String() string {
+	if o.ValueType == "number" {
+		return strings.TrimSpace(o.Value)
+	}
+
+	return DecodeString(o.Value, o.ValueType == "hex")
+}
@@ -0,0 +1,67 @@
+package mibwalk
+
+// Row is a row of a table with the values of its columns
+type Row struct {
+	// Index is the raw index of the row (e.g. 544.0)
+	Index string
+	// Labels are the decoded values of the index parts
+	Labels  []string
+	columns map[string]*Object
+}
+
+// Float returns the numeric value of a column (0 if the column is missing)
+func (r *Row) Float(column string) float64 {
+	o, found := r.columns[column]
+	if !found {
+		return 0
+	}
+
+	return o.Float()
+}
+
+// String returns the value of a column (empty if the column is missing)
+func (r *Row) String(column string) string {
+	o, found := r.columns[column]
+	if !found {
+		return ""
+	}
+
+	return o.String()
+}
+
+// Has returns true if the row contains a value for the column
+func (r *Row) Has(column string) bool {
+	_, found := r.columns[column]
+	return found
+}
+
+// Rows groups the objects of a walk by index in order of appearance and decodes the index of each row.
+// Objects without index or with an index not matching idx are skipped.
+func (idx Index) Rows(objects []Object) []*Row {
+	rows := make([]*Row, 0)
+	byIndex := make(map[string]*Row)
+
+	for i := range objects {
+		o := &objects[i]
+		col, index := o.Column()
+		if index == "" {
+			continue
+		}
+
+		r, found := byIndex[index]
+		if !found {
+			labels, err := idx.Decode(index)
+			if err != nil {
+				continue
+			}
+
+			r = &Row{Index: index, Labels: labels, columns: make(map[string]*Object)}
+			byIndex[index] = r
+			rows = append(rows, r)
+		}
+
+		r.columns[col] = o
+	}
+
+	return rows
+}
@@ -22,9 +22,11 @@ var (
 func init() {
 	ls := []string{"target", "lspname", "lspsrc", "lspdst"}
 	mpls_lspState = prometheus.NewDesc(prefix+"state", "mpls_lsp state (0: down, 1:up)", ls, nil)
+	collector.RegisterStates(prefix+"state", mpls_lspStateMap)
 
 	lps := []string{"target", "lspname", "lspsrc", "lspdst", "title", "name"}
 	mpls_lspPathState = prometheus.NewDesc(prefix+"path_state", "mpls_lsp pathstate (0: down, 1:up)", lps, nil)
+	collector.RegisterStates(prefix+"path_state", mpls_lspStateMap)
 	mpls_lspPathFlapCount = prometheus.NewDesc(prefix+"path_flapcount", "mpls_lsp path flap count", lps, nil)
 }
 
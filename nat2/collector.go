@@ -1,6 +1,8 @@
 package nat2
 
 import (
+	"log"
+
 	"github.com/czerwonk/junos_exporter/collector"
 	"github.com/czerwonk/junos_exporter/rpc"
 	"github.com/prometheus/client_golang/prometheus"
@@ -88,6 +90,11 @@ var (
 
 	serviceSetCpuUtilizationDesc                         *prometheus.Desc
 
+	RuleTranslationHitsDesc                              *prometheus.Desc
+	RuleSuccessfulHitsDesc                               *prometheus.Desc
+	RuleFailedHitsDesc                                   *prometheus.Desc
+	RuleConcurrentHitsDesc                               *prometheus.Desc
+
 )
 
 func init() {
@@ -97,6 +104,7 @@ func init() {
 	lar := []string{"target", "interface", "service_set", "pool_name", "pool_id", "address_range_low", "address_range_high"}
 
 	lservicesets := []string{"target", "interface", "service_set"}
+	lrule := []string{"target", "type", "rule_set", "rule"}
 
 	natTotalSessionInterestDesc = prometheus.NewDesc(prefix+"nat_total_session_interest", "Total Session Interest events", l, nil)
 
@@ -176,6 +184,11 @@ func init() {
 
 	serviceSetCpuUtilizationDesc = prometheus.NewDesc(prefix+"service_set_cpu_utilization", "CPU utilization for the Service Set", lservicesets, nil)
 
+	RuleTranslationHitsDesc = prometheus.NewDesc(prefix+"rule_translation_hits", "Translation hits of the NAT rule", lrule, nil)
+	RuleSuccessfulHitsDesc = prometheus.NewDesc(prefix+"rule_successful_hits", "Successful translations of the NAT rule", lrule, nil)
+	RuleFailedHitsDesc = prometheus.NewDesc(prefix+"rule_failed_hits", "Failed translations of the NAT rule", lrule, nil)
+	RuleConcurrentHitsDesc = prometheus.NewDesc(prefix+"rule_concurrent_hits", "Concurrent sessions translated by the NAT rule", lrule, nil)
+
 }
 
 type natCollector struct {
@@ -220,6 +233,9 @@ func (c *natCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric,
 		return err
 	}
 
+	c.collectRules(client, ch, labelValues, "source")
+	c.collectRules(client, ch, labelValues, "destination")
+
 	return nil
 }
 
@@ -414,3 +430,28 @@ func (c *natCollector) collectForServiceSetsCpuInterface(s *ServiceSetsCpuInterf
 
 	ch <- prometheus.MustNewConstMetric(serviceSetCpuUtilizationDesc, prometheus.GaugeValue, float64(s.CpuUtilizationPercent), l...)
 }
+
+// collectRules collects the hit counters of all source or destination NAT rules.
+// Failures are only logged, as the command is not supported by all platforms and releases supporting the statistics.
+func (c *natCollector) collectRules(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string, natType string) {
+	var x = NatRuleRpc{}
+	err := client.RunCommandAndParse("show services nat "+natType+" rule all", &x)
+	if err != nil {
+		log.Printf("could not get %s NAT rules of %s: %s", natType, client.Device().Host, err)
+		return
+	}
+
+	for _, r := range x.rules(natType) {
+		l := append(labelValues, []string{natType, r.RuleSetName, r.RuleName}...)
+
+		hits := r.SourceHits
+		if natType == "destination" {
+			hits = r.DestinationHits
+		}
+
+		ch <- prometheus.MustNewConstMetric(RuleTranslationHitsDesc, prometheus.CounterValue, float64(hits.TranslationHits), l...)
+		ch <- prometheus.MustNewConstMetric(RuleSuccessfulHitsDesc, prometheus.CounterValue, float64(hits.SuccessfulHits), l...)
+		ch <- prometheus.MustNewConstMetric(RuleFailedHitsDesc, prometheus.CounterValue, float64(hits.FailedHits), l...)
+		ch <- prometheus.MustNewConstMetric(RuleConcurrentHitsDesc, prometheus.GaugeValue, float64(hits.ConcurrentHits), l...)
+	}
+}
@@ -111,3 +111,34 @@ type ServiceSetsCpuInterface struct {
 	ServiceSetName        string  `xml:"service-set-name"`
 	CpuUtilizationPercent float64 `xml:"cpu-utilization-percent"`
 }
+
+type NatRuleRpc struct {
+	Source struct {
+		Rules []NatRule `xml:"source-nat-rule-entry"`
+	} `xml:"source-nat-rule-detail-information"`
+	Destination struct {
+		Rules []NatRule `xml:"destination-nat-rule-entry"`
+	} `xml:"destination-nat-rule-detail-information"`
+}
+
+func (r *NatRuleRpc) rules(natType string) []NatRule {
+	if natType == "destination" {
+		return r.Destination.Rules
+	}
+
+	return r.Source.Rules
+}
+
+type NatRule struct {
+	RuleName        string      `xml:"rule-name"`
+	RuleSetName     string      `xml:"rule-set-name"`
+	SourceHits      NatRuleHits `xml:"source-nat-rule-hits-entry"`
+	DestinationHits NatRuleHits `xml:"destination-nat-rule-hits-entry"`
+}
+
+type NatRuleHits struct {
+	TranslationHits int64 `xml:"rule-translation-hits"`
+	SuccessfulHits  int64 `xml:"succ-hits"`
+	FailedHits      int64 `xml:"failed-hits"`
+	ConcurrentHits  int64 `xml:"concurrent-hits"`
+}
@@ -0,0 +1,39 @@
+package nat2
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDestinationRules(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <destination-nat-rule-detail-information>
+        <destination-nat-rule-entry>
+            <rule-name>web</rule-name>
+            <rule-set-name>inbound</rule-set-name>
+            <destination-nat-rule-hits-entry>
+                <rule-translation-hits>12</rule-translation-hits>
+                <succ-hits>10</succ-hits>
+                <failed-hits>2</failed-hits>
+                <concurrent-hits>3</concurrent-hits>
+            </destination-nat-rule-hits-entry>
+        </destination-nat-rule-entry>
+    </destination-nat-rule-detail-information>
+</rpc-reply>`
+
+	x := NatRuleRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, x.rules("source"))
+
+	rules := x.rules("destination")
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "inbound", rules[0].RuleSetName)
+	assert.Equal(t, "web", rules[0].RuleName)
+	assert.Equal(t, NatRuleHits{TranslationHits: 12, SuccessfulHits: 10, FailedHits: 2, ConcurrentHits: 3}, rules[0].DestinationHits)
+}
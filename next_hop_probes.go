@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/ping"
+	"github.com/czerwonk/junos_exporter/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	nextHopProbeSuccessDesc  *prometheus.Desc
+	nextHopProbeDurationDesc *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target", "address", "module"}
+	nextHopProbeSuccessDesc = prometheus.NewDesc(prefix+"next_hop_probe_success", "Address configured as next hop of the target answered the probe (1 = reachable)", l, nil)
+	nextHopProbeDurationDesc = prometheus.NewDesc(prefix+"next_hop_probe_duration_seconds", "Round trip time (icmp) or time to connect (tcp) of the next hop probe", l, nil)
+}
+
+// nextHopProbeResult is the result of a probe sent to an address configured as next hop of a target
+type nextHopProbeResult struct {
+	probe    *config.NextHopProbe
+	success  bool
+	duration time.Duration
+}
+
+// runNextHopProbes probes the next hops of all devices concurrently.
+// The probes are sent by the exporter, so they are independent of the reachability of the device itself.
+func runNextHopProbes(ctx context.Context, devices []*connector.Device, probes func(host string) []*config.NextHopProbe) map[*connector.Device][]*nextHopProbeResult {
+	results := make(map[*connector.Device][]*nextHopProbeResult)
+	wg := &sync.WaitGroup{}
+
+	for _, d := range devices {
+		ps := probes(d.Host)
+		if len(ps) == 0 {
+			continue
+		}
+
+		res := make([]*nextHopProbeResult, len(ps))
+		results[d] = res
+
+		for i, p := range ps {
+			wg.Add(1)
+			go func(i int, device *connector.Device, p *config.NextHopProbe) {
+				defer wg.Done()
+				res[i] = runNextHopProbe(ctx, device, p)
+			}(i, d, p)
+		}
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func runNextHopProbe(ctx context.Context, device *connector.Device, p *config.NextHopProbe) *nextHopProbeResult {
+	_, span := tracing.Start(ctx, "next_hop_probe", attribute.String("target", device.Host), attribute.String("address", p.Address))
+	defer span.End()
+
+	var d time.Duration
+	var err error
+	if p.Module == "tcp" {
+		d, err = connectTCP(nextHopAddress(p), *icmpTimeout)
+	} else {
+		d, err = ping.Ping(p.Address, *icmpTimeout, *icmpPrivileged)
+	}
+
+	if err != nil {
+		log.Debugf("Next hop %s (%s) of %s is not reachable: %s", nextHopAddress(p), p.Module, device, err)
+		tracing.RecordError(span, err)
+		return &nextHopProbeResult{probe: p}
+	}
+
+	return &nextHopProbeResult{probe: p, success: true, duration: d}
+}
+
+// nextHopAddress returns the address label of the probe (including the port for tcp probes)
+func nextHopAddress(p *config.NextHopProbe) string {
+	if p.Module == "tcp" {
+		return net.JoinHostPort(p.Address, strconv.Itoa(p.Port))
+	}
+
+	return p.Address
+}
+
+func connectTCP(addr string, timeout time.Duration) (time.Duration, error) {
+	t := time.Now()
+
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return 0, err
+	}
+	conn.Close()
+
+	return time.Since(t), nil
+}
+
+func collectNextHopProbes(results []*nextHopProbeResult, ch chan<- prometheus.Metric, l []string) {
+	for _, r := range results {
+		ll := append(l[:len(l):len(l)], nextHopAddress(r.probe), r.probe.Module)
+
+		success := 0
+		if r.success {
+			success = 1
+			ch <- prometheus.MustNewConstMetric(nextHopProbeDurationDesc, prometheus.GaugeValue, r.duration.Seconds(), ll...)
+		}
+
+		ch <- prometheus.MustNewConstMetric(nextHopProbeSuccessDesc, prometheus.GaugeValue, float64(success), ll...)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHopProbesTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	d1 := &connector.Device{Host: "pe1"}
+	d2 := &connector.Device{Host: "pe2"}
+	probes := map[string][]*config.NextHopProbe{
+		"pe1": {
+			{Address: "127.0.0.1", Module: "tcp", Port: l.Addr().(*net.TCPAddr).Port},
+			{Address: "127.0.0.1", Module: "tcp", Port: closedPort},
+		},
+	}
+
+	results := runNextHopProbes(context.Background(), []*connector.Device{d1, d2}, func(host string) []*config.NextHopProbe {
+		return probes[host]
+	})
+
+	assert.NotContains(t, results, d2, "no probes configured")
+	if assert.Len(t, results[d1], 2) {
+		assert.True(t, results[d1][0].success, "listening port")
+		assert.False(t, results[d1][1].success, "closed port")
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	collectNextHopProbes(results[d1], ch, []string{"pe1"})
+	close(ch)
+
+	assert.Len(t, ch, 3, "duration only exported for successful probes")
+	assert.Equal(t, "127.0.0.1:"+strconv.Itoa(closedPort), nextHopAddress(probes["pe1"][1]))
+}
@@ -19,7 +19,7 @@ func init() {
 	ospfPrefix := "junos_ospf_"
 	ospf3Prefix := "junos_ospf3_"
 
-	l := []string{"target"}
+	l := []string{"target", "afi"}
 	ospfUpDesc = prometheus.NewDesc(ospfPrefix+"up", "OSPF is up and running (1 = up)", l, nil)
 	ospf3UpDesc = prometheus.NewDesc(ospf3Prefix+"up", "OSPFv3 is up and running (1 = up)", l, nil)
 
@@ -28,7 +28,7 @@ func init() {
 	ospf3NeighborsDesc = prometheus.NewDesc(ospf3Prefix+"neighbors_count", "Number of neighbors", l, nil)
 }
 
-// Collector collects OSPFv3 metrics
+// Collector collects OSPFv2 (afi=ipv4) and OSPFv3 (afi=ipv6) metrics
 type ospfCollector struct {
 	LogicalSystem string
 }
@@ -81,6 +81,7 @@ func (c *ospfCollector) collectOSPFMetrics(client *rpc.Client, ch chan<- prometh
 		up = 1
 	}
 
+	labelValues = append(labelValues, "ipv4")
 	ch <- prometheus.MustNewConstMetric(ospfUpDesc, prometheus.GaugeValue, float64(up), labelValues...)
 
 	for _, a := range areas {
@@ -111,6 +112,7 @@ func (c *ospfCollector) collectOSPFv3Metrics(client *rpc.Client, ch chan<- prome
 		up = 1
 	}
 
+	labelValues = append(labelValues, "ipv6")
 	ch <- prometheus.MustNewConstMetric(ospf3UpDesc, prometheus.GaugeValue, float64(up), labelValues...)
 
 	for _, a := range areas {
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/push"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+)
+
+// outputs receive the results of each background collection
+var outputs []push.Writer
+
+func initOutputs() error {
+	if *influxURL != "" {
+		outputs = append(outputs, push.NewInflux(*influxURL, *influxToken, *pushTimeout))
+	}
+
+	if *pushgatewayURL != "" {
+		outputs = append(outputs, push.NewPushgateway(*pushgatewayURL, *pushgatewayJob, *pushTimeout))
+	}
+
+	var scheduled []push.Writer
+	if *graphiteAddress != "" {
+		g := push.NewGraphite(*graphiteAddress, *graphitePrefix, *pushTimeout)
+		if *graphiteInterval > 0 {
+			scheduled = append(scheduled, g)
+		} else {
+			outputs = append(outputs, g)
+		}
+	}
+
+	if (len(outputs) > 0 || len(scheduled) > 0) && *backgroundInterval == 0 {
+		return errors.New("pushing metrics requires background collection (-background.interval)")
+	}
+
+	if len(scheduled) > 0 {
+		startScheduledPush(scheduled, *graphiteInterval)
+	}
+
+	return nil
+}
+
+// startScheduledPush writes the latest results of the background collection to the writers in an interval independent of the collection
+func startScheduledPush(writers []push.Writer, interval time.Duration) {
+	go func() {
+		for range time.Tick(interval) {
+			if election != nil && !election.isLeader() {
+				continue
+			}
+
+			configMu.RLock()
+			pushResults(devices, writers)
+			configMu.RUnlock()
+		}
+	}()
+}
+
+// pushResults writes the cached results of all devices to the writers
+func pushResults(devs []*connector.Device, writers []push.Writer) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(devs))
+
+	for _, d := range devs {
+		go func(d *connector.Device) {
+			defer wg.Done()
+
+			e, found := cache.get(d.Host)
+			if !found {
+				return
+			}
+
+			mfs, err := gatherDevice(d)
+			if err != nil {
+				log.Errorf("Could not gather metrics of %s: %v", d, err)
+			}
+
+			for _, o := range writers {
+				err = o.Write(d.Host, mfs, e.timestamp)
+				if err != nil {
+					log.Errorf("Could not push metrics of %s to %s: %v", d, o.Name(), err)
+				}
+			}
+		}(d)
+	}
+
+	wg.Wait()
+}
+
+// gatherDevice returns the cached metrics of a device as metric families (including target labels)
+func gatherDevice(d *connector.Device) ([]*dto.MetricFamily, error) {
+	reg := prometheus.NewRegistry()
+	devs := []*connector.Device{d}
+
+	collectorForDevices := func(devs []*connector.Device) prometheus.Collector {
+		return newCachedCollector(context.Background(), devs)
+	}
+
+	if *reverseDNSEnabled || cfg.HasDeviceLabels() {
+		registerWithTargetLabels(reg, devs, collectorForDevices)
+	} else {
+		reg.MustRegister(collectorForDevices(devs))
+	}
+
+	return withMetricNames(withTransformations(reg)).Gather()
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultPauseDuration = time.Hour
+
+var pauses = newPauseTracker()
+
+// pauseTracker keeps track of targets paused at runtime (see /-/pause)
+type pauseTracker struct {
+	until map[string]time.Time
+	mu    sync.Mutex
+}
+
+func newPauseTracker() *pauseTracker {
+	return &pauseTracker{
+		until: make(map[string]time.Time),
+	}
+}
+
+func (p *pauseTracker) pause(host string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.until[host] = until
+}
+
+func (p *pauseTracker) resume(host string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.until, host)
+}
+
+// pausedUntil returns the time the pause of the target expires (false if the target is not paused at t)
+func (p *pauseTracker) pausedUntil(host string, t time.Time) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until, found := p.until[host]
+	if !found {
+		return time.Time{}, false
+	}
+
+	if !t.Before(until) {
+		delete(p.until, host)
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// handlePauseRequest pauses (POST /-/pause?target=...&duration=...) or resumes (POST /-/resume?target=...) scraping of a target
+func handlePauseRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST method expected", http.StatusBadRequest)
+		return
+	}
+
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	host := r.URL.Query().Get("target")
+	code := authorizePauseRequest(r, host)
+	if code != http.StatusOK {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+
+	if !isConfiguredTarget(host) {
+		http.Error(w, fmt.Sprintf("unknown target %s", host), http.StatusNotFound)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/resume") {
+		pauses.resume(host)
+		log.Infof("Resumed scraping of %s", host)
+		fmt.Fprintf(w, "resumed %s\n", host)
+		return
+	}
+
+	d := defaultPauseDuration
+	if s := r.URL.Query().Get("duration"); s != "" {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			http.Error(w, fmt.Sprintf("invalid duration %s", s), http.StatusBadRequest)
+			return
+		}
+	}
+
+	until := time.Now().Add(d)
+	pauses.pause(host, until)
+	log.Infof("Paused scraping of %s until %s", host, until.Format(time.RFC3339))
+	fmt.Fprintf(w, "paused %s until %s\n", host, until.Format(time.RFC3339))
+}
+
+// authorizePauseRequest checks the bearer token of the request. Tenants may only pause their own targets.
+// Without tenants the token set by -pause.token is required (the API is disabled if none is set)
+func authorizePauseRequest(r *http.Request, host string) int {
+	if len(cfg.Tenants) > 0 {
+		t, code := tenantForRequest(r)
+		if code != http.StatusOK {
+			return code
+		}
+
+		if !t.Allowed(host) {
+			return http.StatusForbidden
+		}
+
+		return http.StatusOK
+	}
+
+	if *pauseToken == "" {
+		return http.StatusForbidden
+	}
+
+	token, found := bearerToken(r)
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(*pauseToken)) != 1 {
+		return http.StatusUnauthorized
+	}
+
+	return http.StatusOK
+}
+
+func isConfiguredTarget(host string) bool {
+	for _, d := range devices {
+		if d.Host == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pausedDevices returns the devices paused at t with the expiry of the pause
+func pausedDevices(devs []*connector.Device, t time.Time) map[*connector.Device]time.Time {
+	paused := make(map[*connector.Device]time.Time)
+	for _, d := range devs {
+		if until, found := pauses.pausedUntil(d.Host, t); found {
+			paused[d] = until
+		}
+	}
+
+	return paused
+}
+
+// unpausedDevices returns the devices not contained in paused
+func unpausedDevices(devs []*connector.Device, paused map[*connector.Device]time.Time) []*connector.Device {
+	res := make([]*connector.Device, 0, len(devs))
+	for _, d := range devs {
+		if _, found := paused[d]; !found {
+			res = append(res, d)
+		}
+	}
+
+	return res
+}
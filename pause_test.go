@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseTracker(t *testing.T) {
+	p := newPauseTracker()
+	now := time.Now()
+
+	_, found := p.pausedUntil("router1", now)
+	assert.False(t, found, "not paused")
+
+	p.pause("router1", now.Add(time.Hour))
+	until, found := p.pausedUntil("router1", now)
+	assert.True(t, found, "paused")
+	assert.Equal(t, now.Add(time.Hour), until, "expiry")
+
+	_, found = p.pausedUntil("router1", now.Add(time.Hour))
+	assert.False(t, found, "expired")
+
+	p.pause("router2", now.Add(time.Hour))
+	p.resume("router2")
+	_, found = p.pausedUntil("router2", now)
+	assert.False(t, found, "resumed")
+}
@@ -0,0 +1,117 @@
+package ping
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	protocolICMP     = 1
+	protocolICMPIPv6 = 58
+)
+
+var seq uint32
+
+// Ping sends an ICMP echo request to the host and returns the round trip time.
+// Unless privileged is set unprivileged ICMP sockets are used (on Linux see net.ipv4.ping_group_range).
+// Raw sockets are always used on platforms not supporting unprivileged ICMP sockets (e.g. Windows and FreeBSD).
+func Ping(host string, timeout time.Duration, privileged bool) (time.Duration, error) {
+	privileged = privileged || !unprivilegedSupported()
+
+	addr, err := net.ResolveIPAddr("ip", hostWithoutPort(host))
+	if err != nil {
+		return 0, err
+	}
+
+	network, listenAddr, proto := "udp4", "0.0.0.0", protocolICMP
+	var echoType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if addr.IP.To4() == nil {
+		network, listenAddr, proto = "udp6", "::", protocolICMPIPv6
+		echoType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	var dst net.Addr = &net.UDPAddr{IP: addr.IP, Zone: addr.Zone}
+	if privileged {
+		network = strings.Replace(network, "udp4", "ip4:icmp", 1)
+		network = strings.Replace(network, "udp6", "ip6:ipv6-icmp", 1)
+		dst = addr
+	}
+
+	conn, err := icmp.ListenPacket(network, listenAddr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	id := os.Getpid() & 0xffff
+	s := int(atomic.AddUint32(&seq, 1) & 0xffff)
+	msg := icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: id, Seq: s, Data: []byte("junos_exporter")},
+	}
+
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return 0, err
+	}
+
+	t := time.Now()
+	_, err = conn.WriteTo(b, dst)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, fmt.Errorf("no echo reply from %s: %w", addr, err)
+		}
+
+		reply, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil || reply.Type != replyType {
+			continue
+		}
+
+		echo, ok := reply.Body.(*icmp.Echo)
+		if !ok || echo.Seq != s {
+			continue
+		}
+
+		// the ID is rewritten by the kernel for unprivileged sockets
+		if privileged && echo.ID != id {
+			continue
+		}
+
+		return time.Since(t), nil
+	}
+}
+
+// hostWithoutPort strips the (SSH) port from the host
+func hostWithoutPort(host string) string {
+	h, _, err := net.SplitHostPort(host)
+	if err == nil {
+		return h
+	}
+
+	return strings.Trim(host, "[]")
+}
+
+// unprivilegedSupported returns true if the OS supports unprivileged ICMP sockets (SOCK_DGRAM)
+func unprivilegedSupported() bool {
+	return runtime.GOOS == "linux" || runtime.GOOS == "darwin"
+}
@@ -0,0 +1,23 @@
+package ping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostWithoutPort(t *testing.T) {
+	tests := map[string]string{
+		"router1":          "router1",
+		"router1:2222":     "router1",
+		"192.0.2.1":        "192.0.2.1",
+		"192.0.2.1:22":     "192.0.2.1",
+		"2001:db8::1":      "2001:db8::1",
+		"[2001:db8::1]":    "2001:db8::1",
+		"[2001:db8::1]:22": "2001:db8::1",
+	}
+
+	for host, expected := range tests {
+		assert.Equal(t, expected, hostWithoutPort(host), host)
+	}
+}
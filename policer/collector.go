@@ -0,0 +1,60 @@
+package policer
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_policer_"
+
+var (
+	outOfSpecPacketsDesc *prometheus.Desc
+	outOfSpecBytesDesc   *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target", "filter", "policer", "interface", "family", "direction"}
+	outOfSpecPacketsDesc = prometheus.NewDesc(prefix+"out_of_spec_packets_total", "Number of packets exceeding the policer limits (discarded or marked)", l, nil)
+	outOfSpecBytesDesc = prometheus.NewDesc(prefix+"out_of_spec_bytes_total", "Number of bytes exceeding the policer limits (discarded or marked)", l, nil)
+}
+
+type policerCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &policerCollector{}
+}
+
+// Name returns the name of the collector
+func (*policerCollector) Name() string {
+	return "Policer"
+}
+
+// Describe describes the metrics
+func (*policerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- outOfSpecPacketsDesc
+	ch <- outOfSpecBytesDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *policerCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = policerRpc{}
+	err := client.RunCommandAndParse("show policer", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range x.Information.Filters {
+		for _, p := range f.Policers {
+			n := parseName(p.Name)
+			l := append(labelValues, f.Name, n.name, n.iface, n.family, n.direction)
+
+			ch <- prometheus.MustNewConstMetric(outOfSpecPacketsDesc, prometheus.CounterValue, float64(p.Packets), l...)
+			ch <- prometheus.MustNewConstMetric(outOfSpecBytesDesc, prometheus.CounterValue, float64(p.Bytes), l...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package policer
+
+import "regexp"
+
+// interface specific policers are named <policer>-<interface>-<family>-<direction>, e.g. limit-10m-ge-0/0/0.100-inet-i
+var interfacePolicerRegex = regexp.MustCompile(`^(.+?)-((?:[a-z]+-[0-9]+(?:/[0-9]+)*|[a-z]+[0-9]+)(?:\.[0-9]+)?)-(inet|inet6|mpls|ccc|vpls|bridge|any|ethernet-switching)-(i|o)$`)
+
+type policerName struct {
+	name      string
+	iface     string
+	family    string
+	direction string
+}
+
+func parseName(s string) *policerName {
+	m := interfacePolicerRegex.FindStringSubmatch(s)
+	if m == nil {
+		return &policerName{name: s}
+	}
+
+	direction := "input"
+	if m[4] == "o" {
+		direction = "output"
+	}
+
+	return &policerName{
+		name:      m[1],
+		iface:     m[2],
+		family:    m[3],
+		direction: direction,
+	}
+}
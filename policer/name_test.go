@@ -0,0 +1,37 @@
+package policer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *policerName
+	}{
+		{
+			name:     "limit-10m-ge-0/0/0.100-inet-i",
+			expected: &policerName{name: "limit-10m", iface: "ge-0/0/0.100", family: "inet", direction: "input"},
+		},
+		{
+			name:     "pol1-ae0.0-inet6-o",
+			expected: &policerName{name: "pol1", iface: "ae0.0", family: "inet6", direction: "output"},
+		},
+		{
+			name:     "__default_arp_policer__",
+			expected: &policerName{name: "__default_arp_policer__"},
+		},
+		{
+			name:     "limit-1m-term1",
+			expected: &policerName{name: "limit-1m-term1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseName(test.name))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package policer
+
+type policerRpc struct {
+	Information struct {
+		Filters []filter `xml:"filter-information"`
+	} `xml:"firewall-information"`
+}
+
+type filter struct {
+	Name     string    `xml:"filter-name"`
+	Policers []policer `xml:"policer"`
+}
+
+type policer struct {
+	Name    string `xml:"policer-name"`
+	Packets uint64 `xml:"packet-count"`
+	Bytes   uint64 `xml:"byte-count"`
+}
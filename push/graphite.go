@@ -0,0 +1,90 @@
+package push
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var graphiteUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Graphite writes metrics to Graphite using the plaintext protocol.
+// The path of a metric is <prefix>.<target>.<metric name>.<label>_<value> (one node per non empty label in order of the label names, the target label is omitted)
+type Graphite struct {
+	address string
+	prefix  string
+	timeout time.Duration
+}
+
+// NewGraphite creates a new writer sending to the plaintext listener of Graphite (e.g. localhost:2003)
+func NewGraphite(address, prefix string, timeout time.Duration) *Graphite {
+	return &Graphite{
+		address: address,
+		prefix:  prefix,
+		timeout: timeout,
+	}
+}
+
+// Name returns the name of the system written to
+func (g *Graphite) Name() string {
+	return "Graphite"
+}
+
+// Write writes the metrics of a target collected at time t
+func (g *Graphite) Write(target string, mfs []*dto.MetricFamily, t time.Time) error {
+	conn, err := net.DialTimeout("tcp", g.address, g.timeout)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to Graphite")
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(g.timeout))
+
+	w := bufio.NewWriter(conn)
+	g.writePlaintext(w, target, mfs, t)
+
+	return w.Flush()
+}
+
+func (g *Graphite) writePlaintext(w io.Writer, target string, mfs []*dto.MetricFamily, t time.Time) {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	base := graphiteNode(target)
+	if g.prefix != "" {
+		base = g.prefix + "." + base
+	}
+
+	for _, s := range samples(mfs) {
+		var b strings.Builder
+		b.WriteString(base)
+		b.WriteString(".")
+		b.WriteString(graphiteNode(s.name))
+
+		for _, l := range s.labels {
+			if l.GetName() == "target" || l.GetValue() == "" {
+				continue
+			}
+
+			b.WriteString(".")
+			b.WriteString(graphiteNode(l.GetName() + "_" + l.GetValue()))
+		}
+
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		b.WriteString(" ")
+		b.WriteString(ts)
+		b.WriteString("\n")
+
+		io.WriteString(w, b.String())
+	}
+}
+
+func graphiteNode(s string) string {
+	return graphiteUnsafeChars.ReplaceAllString(s, "_")
+}
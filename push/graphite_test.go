@@ -0,0 +1,20 @@
+package push
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePlaintext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	g := NewGraphite("localhost:2003", "junos", time.Second)
+	g.writePlaintext(buf, "192.0.2.1", testMetricFamilies(), time.Unix(1600000000, 0))
+
+	expected := `junos.192_0_2_1.junos_interface_receive_bytes.description_uplink_provider_a.name_xe-0_0_0 1234 1600000000
+junos.192_0_2_1.junos_up 1 1600000000
+`
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,97 @@
+package push
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// Influx writes metrics to InfluxDB using the line protocol. Each metric is written as measurement with its labels as tags and a field named value
+type Influx struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewInflux creates a new writer sending to the write endpoint of InfluxDB (e.g. http://localhost:8086/write?db=junos or http://localhost:8086/api/v2/write?org=example&bucket=junos)
+func NewInflux(url, token string, timeout time.Duration) *Influx {
+	return &Influx{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the name of the system written to
+func (i *Influx) Name() string {
+	return "InfluxDB"
+}
+
+// Write writes the metrics of a target collected at time t
+func (i *Influx) Write(target string, mfs []*dto.MetricFamily, t time.Time) error {
+	buf := &bytes.Buffer{}
+	writeLineProtocol(buf, mfs, t)
+
+	req, err := http.NewRequest(http.MethodPost, i.url, buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	if i.token != "" {
+		req.Header.Set("Authorization", "Token "+i.token)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not write to InfluxDB")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.Errorf("InfluxDB returned %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
+
+func writeLineProtocol(w io.Writer, mfs []*dto.MetricFamily, t time.Time) {
+	ts := strconv.FormatInt(t.UnixNano(), 10)
+
+	for _, s := range samples(mfs) {
+		var b strings.Builder
+		b.WriteString(influxMeasurementEscaper.Replace(s.name))
+
+		for _, l := range s.labels {
+			if l.GetValue() == "" {
+				continue
+			}
+
+			b.WriteString(",")
+			b.WriteString(influxTagEscaper.Replace(l.GetName()))
+			b.WriteString("=")
+			b.WriteString(influxTagEscaper.Replace(l.GetValue()))
+		}
+
+		b.WriteString(" value=")
+		b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		b.WriteString(" ")
+		b.WriteString(ts)
+		b.WriteString("\n")
+
+		io.WriteString(w, b.String())
+	}
+}
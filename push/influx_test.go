@@ -0,0 +1,75 @@
+package push
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testMetricFamilies() []*dto.MetricFamily {
+	return []*dto.MetricFamily{
+		{
+			Name: proto.String("junos_interface_receive_bytes"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("description"), Value: proto.String("uplink, provider a")},
+						{Name: proto.String("mac"), Value: proto.String("")},
+						{Name: proto.String("name"), Value: proto.String("xe-0/0/0")},
+						{Name: proto.String("target"), Value: proto.String("router1")},
+					},
+					Counter: &dto.Counter{Value: proto.Float64(1234)},
+				},
+			},
+		},
+		{
+			Name: proto.String("junos_up"),
+			Type: dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{{Name: proto.String("target"), Value: proto.String("router1")}},
+					Gauge: &dto.Gauge{Value: proto.Float64(1)},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteLineProtocol(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writeLineProtocol(buf, testMetricFamilies(), time.Unix(1600000000, 0))
+
+	expected := `junos_interface_receive_bytes,description=uplink\,\ provider\ a,name=xe-0/0/0,target=router1 value=1234 1600000000000000000
+junos_up,target=router1 value=1 1600000000000000000
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestInfluxWrite(t *testing.T) {
+	var body, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	i := NewInflux(srv.URL+"/api/v2/write?org=example&bucket=junos", "secret", time.Second)
+	err := i.Write("router1", testMetricFamilies()[1:], time.Unix(1600000000, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "junos_up,target=router1 value=1 1600000000000000000\n", body)
+	assert.Equal(t, "Token secret", auth)
+
+	i = NewInflux(srv.URL+"/missing", "", time.Second)
+	srv.Config.Handler = http.NotFoundHandler()
+	assert.Error(t, i.Write("router1", testMetricFamilies(), time.Now()))
+}
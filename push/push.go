@@ -0,0 +1,56 @@
+package push
+
+import (
+	"math"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Writer writes the metrics of a target to an external system
+type Writer interface {
+	// Name returns the name of the system written to
+	Name() string
+
+	// Write writes the metrics of a target collected at time t
+	Write(target string, mfs []*dto.MetricFamily, t time.Time) error
+}
+
+type sample struct {
+	name   string
+	labels []*dto.LabelPair
+	value  float64
+}
+
+// samples returns all gauge, counter and untyped values (histograms and summaries are not supported). NaN and infinite values are skipped
+func samples(mfs []*dto.MetricFamily) []*sample {
+	res := make([]*sample, 0)
+
+	for _, mf := range mfs {
+		for _, m := range mf.Metric {
+			var v float64
+			switch {
+			case m.Gauge != nil:
+				v = m.Gauge.GetValue()
+			case m.Counter != nil:
+				v = m.Counter.GetValue()
+			case m.Untyped != nil:
+				v = m.Untyped.GetValue()
+			default:
+				continue
+			}
+
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+
+			res = append(res, &sample{
+				name:   mf.GetName(),
+				labels: m.Label,
+				value:  v,
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,75 @@
+package push
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prompush "github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Pushgateway pushes the metrics of each target to a group (grouping key: job and target) of a Prometheus Pushgateway.
+// Metrics of a target not contained in the latest push are removed from the group
+type Pushgateway struct {
+	url    string
+	job    string
+	client *http.Client
+}
+
+// NewPushgateway creates a new writer pushing to the Pushgateway at url (e.g. http://localhost:9091)
+func NewPushgateway(url, job string, timeout time.Duration) *Pushgateway {
+	return &Pushgateway{
+		url:    url,
+		job:    job,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the name of the system written to
+func (p *Pushgateway) Name() string {
+	return "Pushgateway"
+}
+
+// Write writes the metrics of a target collected at time t
+func (p *Pushgateway) Write(target string, mfs []*dto.MetricFamily, t time.Time) error {
+	g := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return withoutLabel(mfs, "target"), nil
+	})
+
+	return prompush.New(p.url, p.job).
+		Client(p.client).
+		Grouping("target", target).
+		Gatherer(g).
+		Push()
+}
+
+// withoutLabel returns copies of the metric families without the label (the Pushgateway adds labels of the grouping key itself)
+func withoutLabel(mfs []*dto.MetricFamily, name string) []*dto.MetricFamily {
+	res := make([]*dto.MetricFamily, len(mfs))
+
+	for i, mf := range mfs {
+		c := &dto.MetricFamily{
+			Name:   mf.Name,
+			Help:   mf.Help,
+			Type:   mf.Type,
+			Metric: make([]*dto.Metric, len(mf.Metric)),
+		}
+
+		for j, m := range mf.Metric {
+			cm := *m
+			cm.Label = make([]*dto.LabelPair, 0, len(m.Label))
+			for _, l := range m.Label {
+				if l.GetName() != name {
+					cm.Label = append(cm.Label, l)
+				}
+			}
+
+			c.Metric[j] = &cm
+		}
+
+		res[i] = c
+	}
+
+	return res
+}
@@ -0,0 +1,31 @@
+package push
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushgatewayWrite(t *testing.T) {
+	var method, path string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := NewPushgateway(srv.URL, "junos", time.Second)
+	err := p.Write("router1", testMetricFamilies(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/junos/target/router1", path)
+	assert.NotContains(t, string(body), "target", "target label removed")
+	assert.Contains(t, string(body), "xe-0/0/0")
+}
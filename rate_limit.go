@@ -0,0 +1,55 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	rateLimitDesc     *prometheus.Desc
+	rateLimitWaitDesc *prometheus.Desc
+
+	rateLimiters = &rateLimiterRegistry{limiters: make(map[string]*rpc.RateLimiter)}
+)
+
+func init() {
+	rateLimitDesc = prometheus.NewDesc(prefix+"rate_limit_commands_per_second", "Max. number of commands per second run on the target", []string{"target"}, nil)
+	rateLimitWaitDesc = prometheus.NewDesc(prefix+"rate_limit_wait_seconds_total", "Total time commands were held back to not exceed the rate limit of the target", []string{"target"}, nil)
+}
+
+// rateLimiterRegistry keeps the rate limiter of each target across scrapes, so consecutive scrapes are paced as well
+type rateLimiterRegistry struct {
+	limiters map[string]*rpc.RateLimiter
+	mu       sync.Mutex
+}
+
+// get returns the rate limiter of the target (nil if no limit is configured)
+func (r *rateLimiterRegistry) get(host string) *rpc.RateLimiter {
+	limit := cfg.RateLimitForDevice(host)
+	if limit == 0 {
+		limit = *rateLimit
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if limit <= 0 {
+		delete(r.limiters, host)
+		return nil
+	}
+
+	l, found := r.limiters[host]
+	if !found || l.Limit() != limit {
+		l = rpc.NewRateLimiter(limit)
+		r.limiters[host] = l
+	}
+
+	return l
+}
+
+func collectRateLimit(l *rpc.RateLimiter, ch chan<- prometheus.Metric, labelValues []string) {
+	ch <- prometheus.MustNewConstMetric(rateLimitDesc, prometheus.GaugeValue, l.Limit(), labelValues...)
+	ch <- prometheus.MustNewConstMetric(rateLimitWaitDesc, prometheus.CounterValue, l.Waited().Seconds(), labelValues...)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterRegistry(t *testing.T) {
+	old := cfg
+	defer func() { cfg = old }()
+
+	cfg = config.New()
+	cfg.Devices = []*config.DeviceConfig{{Host: "ex2200", RateLimit: 0.5}}
+
+	r := &rateLimiterRegistry{limiters: make(map[string]*rpc.RateLimiter)}
+	assert.Nil(t, r.get("router1"), "no limit")
+
+	l := r.get("ex2200")
+	assert.Equal(t, 0.5, l.Limit(), "device specific")
+	assert.Same(t, l, r.get("ex2200"), "kept across scrapes")
+
+	*rateLimit = 2
+	defer func() { *rateLimit = 0 }()
+	assert.Equal(t, float64(2), r.get("router1").Limit(), "global default")
+
+	cfg.Devices[0].RateLimit = 1
+	assert.Equal(t, float64(1), r.get("ex2200").Limit(), "changed limit")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const reverseLookupTimeout = 2 * time.Second
+
+type reverseDNSEntry struct {
+	name      string
+	timestamp time.Time
+}
+
+// reverseDNSCache resolves target IPs to names for the target_name label
+type reverseDNSCache struct {
+	refreshInterval time.Duration
+	entries         map[string]*reverseDNSEntry
+	lookup          func(ctx context.Context, addr string) ([]string, error)
+	mu              sync.Mutex
+}
+
+func newReverseDNSCache(refreshInterval time.Duration) *reverseDNSCache {
+	return &reverseDNSCache{
+		refreshInterval: refreshInterval,
+		entries:         make(map[string]*reverseDNSEntry),
+		lookup:          net.DefaultResolver.LookupAddr,
+	}
+}
+
+// name returns the name of the target. Targets specified by name and IPs without PTR record are returned unchanged.
+func (c *reverseDNSCache) name(host string) string {
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		h = strings.Trim(host, "[]")
+	}
+
+	if net.ParseIP(h) == nil {
+		return h
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, found := c.entries[h]
+	if found && time.Since(e.timestamp) < c.refreshInterval {
+		return e.name
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reverseLookupTimeout)
+	defer cancel()
+
+	names, err := c.lookup(ctx, h)
+	if err != nil || len(names) == 0 {
+		log.Debugf("Reverse lookup for %s failed: %v", h, err)
+
+		if !found {
+			e = &reverseDNSEntry{name: h}
+			c.entries[h] = e
+		}
+
+		// retry with next refresh, keep the name resolved before
+		e.timestamp = time.Now()
+		return e.name
+	}
+
+	e = &reverseDNSEntry{name: strings.TrimSuffix(names[0], "."), timestamp: time.Now()}
+	c.entries[h] = e
+
+	return e.name
+}
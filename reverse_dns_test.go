@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseDNSCache(t *testing.T) {
+	c := newReverseDNSCache(time.Hour)
+
+	lookups := 0
+	c.lookup = func(ctx context.Context, addr string) ([]string, error) {
+		lookups++
+
+		if addr == "192.0.2.1" {
+			return []string{"router1.example.com."}, nil
+		}
+
+		return nil, errors.New("no PTR record")
+	}
+
+	assert.Equal(t, "router1.example.com", c.name("192.0.2.1"))
+	assert.Equal(t, "router1.example.com", c.name("192.0.2.1:22"))
+	assert.Equal(t, 1, lookups, "name should be served from cache")
+
+	assert.Equal(t, "192.0.2.2", c.name("192.0.2.2"))
+	assert.Equal(t, "router3.example.com", c.name("router3.example.com"))
+	assert.Equal(t, 2, lookups, "targets specified by name should not be resolved")
+}
@@ -17,7 +17,7 @@ var (
 )
 
 func init() {
-	l := []string{"target", "table"}
+	l := []string{"target", "table", "routing_instance"}
 	totalRoutesDesc = prometheus.NewDesc(prefix+"total_count", "Number of routes in table", l, nil)
 	activeRoutesDesc = prometheus.NewDesc(prefix+"active_count", "Number of active routes in table", l, nil)
 	maxRoutesDesc = prometheus.NewDesc(prefix+"max_count", "Max. number of routes", l, nil)
@@ -65,7 +65,7 @@ func (c *routeCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric
 }
 
 func (c *routeCollector) collectForTable(table RouteTable, ch chan<- prometheus.Metric, labelValues []string) {
-	l := append(labelValues, table.Name)
+	l := append(labelValues, table.Name, collector.RoutingInstanceForTable(table.Name))
 
 	ch <- prometheus.MustNewConstMetric(totalRoutesDesc, prometheus.GaugeValue, float64(table.TotalRoutes), l...)
 	ch <- prometheus.MustNewConstMetric(activeRoutesDesc, prometheus.GaugeValue, float64(table.ActiveRoutes), l...)
@@ -57,6 +57,14 @@ var (
 	memoryDataPlaneUtil    *prometheus.Desc
 	mastershipState        *prometheus.Desc
 	mastershipPriority     *prometheus.Desc
+
+	statusValues = map[string]int{
+		"OK":      1,
+		"Testing": 2,
+		"Failed":  3,
+		"Absent":  4,
+		"Present": 5,
+	}
 )
 
 func init() {
@@ -94,6 +102,7 @@ func init() {
 	loadAverageFifteen = prometheus.NewDesc(prefix+"load_average_fifteen", "Routing Engine load averages for the last 15 minutes", l, nil)
 	uptime = prometheus.NewDesc(prefix+"uptime_seconds", "Seconds since boot", l, nil)
 	reStatus = prometheus.NewDesc(prefix+"status", "Status of routing-engine (1 OK, 2 Testing, 3 Failed, 4 Absent, 5 Present)", l, nil)
+	collector.RegisterStates(prefix+"status", statusValues)
 
 	memorySystemTotal = prometheus.NewDesc(prefix+"memory_system_total_bytes", "Total System memory", l, nil)
 	memorySystemTotalUsed = prometheus.NewDesc(prefix+"memory_system_total_used_bytes", "System memory utilized", l, nil)
@@ -163,6 +172,7 @@ func (*routingEngineCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- memoryDataPlaneUsed
 	ch <- mastershipState
 	ch <- mastershipPriority
+	ch <- collector.ComponentHealthyDesc
 }
 
 // Collect collects metrics from JunOS
@@ -231,14 +241,10 @@ func (c *routingEngineCollector) collectForSlot(re RouteEngine, ch chan<- promet
 	ch <- prometheus.MustNewConstMetric(loadAverageFifteen, prometheus.GaugeValue, re.LoadAverageFifteen, l...)
 	ch <- prometheus.MustNewConstMetric(uptime, prometheus.CounterValue, float64(re.UpTime.Seconds), l...)
 
-	statusValues := map[string]int{
-		"OK":      1,
-		"Testing": 2,
-		"Failed":  3,
-		"Absent":  4,
-		"Present": 5,
-	}
 	ch <- prometheus.MustNewConstMetric(reStatus, prometheus.GaugeValue, float64(statusValues[re.Status]), l...)
+	if re.Status != "Absent" {
+		ch <- collector.ComponentHealthy(labelValues[0], collector.ComponentRoutingEngine, componentName(labelValues[1], re.Slot), re.Status == "OK")
+	}
 
 	if re.MemorySystemTotal > 0 {
 		ch <- prometheus.MustNewConstMetric(memorySystemTotal, prometheus.GaugeValue, float64(re.MemorySystemTotal)*1024*1024, l...)
@@ -291,3 +297,17 @@ func parseXML(b []byte, res *RpcReply) error {
 
 	return nil
 }
+
+// componentName returns the name of the routing engine in slot for the normalized health metric (e.g. re0 or member0 re1)
+func componentName(reName, slot string) string {
+	name := "re" + slot
+	if slot == "N/A" {
+		name = "re"
+	}
+
+	if reName != "N/A" {
+		name = reName + " " + name
+	}
+
+	return name
+}
@@ -0,0 +1,23 @@
+package rpc
+
+import "sync"
+
+// commandLog records the commands run by a client (shared by copies created with WithContext)
+type commandLog struct {
+	commands []string
+	mu       sync.Mutex
+}
+
+func (l *commandLog) add(cmd string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commands = append(l.commands, cmd)
+}
+
+func (l *commandLog) list() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.commands...)
+}
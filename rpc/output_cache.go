@@ -0,0 +1,40 @@
+package rpc
+
+import "sync"
+
+// outputCache shares the outputs of commands run multiple times on the same device (e.g. by different collectors)
+type outputCache struct {
+	outputs map[string]*output
+	mu      sync.Mutex
+}
+
+type output struct {
+	once sync.Once
+	b    []byte
+	err  error
+}
+
+func newOutputCache() *outputCache {
+	return &outputCache{
+		outputs: make(map[string]*output),
+	}
+}
+
+// get returns the output of the command. The command is run only once, callers requesting the same command wait for its result
+func (c *outputCache) get(cmd string, run func(cmd string) ([]byte, error)) (b []byte, cached bool, err error) {
+	c.mu.Lock()
+	o, found := c.outputs[cmd]
+	if !found {
+		o = &output{}
+		c.outputs[cmd] = o
+	}
+	c.mu.Unlock()
+
+	cached = true
+	o.once.Do(func() {
+		cached = false
+		o.b, o.err = run(cmd)
+	})
+
+	return o.b, cached, o.err
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputCache(t *testing.T) {
+	c := newOutputCache()
+
+	calls := 0
+	mu := sync.Mutex{}
+	run := func(cmd string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+		if cmd == "show bgp neighbor" {
+			return nil, errors.New("not supported")
+		}
+
+		return []byte(cmd), nil
+	}
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			b, _, err := c.get("show interfaces terse", run)
+			assert.NoError(t, err)
+			assert.Equal(t, "show interfaces terse", string(b))
+		}()
+	}
+	wg.Wait()
+
+	_, cached, err := c.get("show bgp neighbor", run)
+	assert.Error(t, err)
+	assert.False(t, cached)
+
+	_, cached, err = c.get("show bgp neighbor", run)
+	assert.Error(t, err)
+	assert.True(t, cached)
+
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces the commands run on a device to not exceed a maximum number of commands per second
+type RateLimiter struct {
+	limit    float64
+	interval time.Duration
+	next     time.Time
+	waited   time.Duration
+	mu       sync.Mutex
+}
+
+// NewRateLimiter creates a new rate limiter allowing the given number of commands per second
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	return &RateLimiter{
+		limit:    perSecond,
+		interval: time.Duration(float64(time.Second) / perSecond),
+	}
+}
+
+// Limit returns the max. number of commands per second
+func (l *RateLimiter) Limit() float64 {
+	return l.limit
+}
+
+// Waited returns the total time commands were held back by the limiter
+func (l *RateLimiter) Waited() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waited
+}
+
+// Wait blocks until the next command may be run or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve reserves the next free slot and returns the duration until it is reached
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+
+	d := slot.Sub(now)
+	l.waited += d
+
+	return d
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := NewRateLimiter(2)
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), l.reserve(now), "first command")
+	assert.Equal(t, 500*time.Millisecond, l.reserve(now), "second command")
+	assert.Equal(t, time.Second, l.reserve(now), "third command")
+	assert.Equal(t, 1500*time.Millisecond, l.Waited(), "waited")
+
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(5*time.Second)), "after idle period")
+	assert.Equal(t, float64(2), l.Limit())
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := NewRateLimiter(0.1)
+	assert.NoError(t, l.Wait(context.Background()), "first command")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, l.Wait(ctx), "canceled")
+}
@@ -1,12 +1,17 @@
 package rpc
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 
 	"log"
 
 	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Parser func([]byte) error
@@ -14,16 +19,28 @@ type ClientCfg struct {
 	SatelliteEnabled bool
 }
 
+// Connection runs commands on a device
+type Connection interface {
+	RunCommand(cmd string) ([]byte, error)
+	Host() string
+	Device() *connector.Device
+	Aborts() map[string]uint64
+}
+
 // Client sends commands to JunOS and parses results
 type Client struct {
-	conn      *connector.SSHConnection
+	conn      Connection
 	debug     bool
 	Satellite bool
+	ctx       context.Context
+	outputs   *outputCache
+	commands  *commandLog
+	limiter   *RateLimiter
 }
 
 // NewClient creates a new client to connect to
-func NewClient(ssh *connector.SSHConnection) *Client {
-	rpc := &Client{conn: ssh}
+func NewClient(conn Connection) *Client {
+	rpc := &Client{conn: conn, ctx: context.Background()}
 
 	return rpc
 }
@@ -41,24 +58,150 @@ func (c *Client) RunCommandAndParseWithParser(cmd string, parser Parser) error {
 		log.Printf("Running command on %s: %s\n", c.conn.Host(), cmd)
 	}
 
-	b, err := c.conn.RunCommand(fmt.Sprintf("%s | display xml", cmd))
+	_, span := tracing.Start(c.ctx, "command", attribute.String("command", cmd))
+	defer span.End()
+
+	if c.commands != nil {
+		c.commands.add(cmd)
+	}
+
+	b, err := c.runCommand(fmt.Sprintf("%s | display xml", cmd), span)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(attribute.Int("output_bytes", len(b)))
+
 	if c.debug {
 		log.Printf("Output for %s: %s\n", c.conn.Host(), string(b))
 	}
 
 	err = parser(b)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (c *Client) runCommand(cmd string, span trace.Span) ([]byte, error) {
+	runConn := c.conn.RunCommand
+	if cr, ok := c.conn.(ContextRunner); ok {
+		runConn = func(cmd string) ([]byte, error) {
+			return cr.RunCommandContext(c.Context(), cmd)
+		}
+	}
+
+	run := runConn
+	if c.limiter != nil {
+		run = func(cmd string) ([]byte, error) {
+			err := c.limiter.Wait(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return runConn(cmd)
+		}
+	}
+
+	if c.outputs == nil {
+		return run(cmd)
+	}
+
+	b, cached, err := c.outputs.get(cmd, run)
+	span.SetAttributes(attribute.Bool("cached", cached))
+
+	return b, err
+}
+
+// ContextRunner is implemented by connections able to abort commands when the context of the client is done
+type ContextRunner interface {
+	RunCommandContext(ctx context.Context, cmd string) ([]byte, error)
+}
+
+// ConfigLoader is implemented by connections able to change the configuration of the device
+type ConfigLoader interface {
+	LoadConfiguration(config, comment string) error
+}
+
+// LoadConfiguration loads the configuration (text format) and commits it. Only supported by SSH connections to devices with NETCONF enabled.
+func (c *Client) LoadConfiguration(config, comment string) error {
+	l, ok := c.conn.(ConfigLoader)
+	if !ok {
+		return errors.New("connection does not support loading configuration")
+	}
+
+	_, span := tracing.Start(c.ctx, "load-configuration")
+	defer span.End()
+
+	err := l.LoadConfiguration(config, comment)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+// NetconfRunner is implemented by connections able to send NETCONF RPCs
+type NetconfRunner interface {
+	RunNetconfRPC(rpc string) ([]byte, error)
+}
+
+// RunNetconfRPCAndParse sends the RPC using NETCONF and unmarshals the reply. Only supported by SSH connections to devices with NETCONF enabled.
+func (c *Client) RunNetconfRPCAndParse(rpc string, obj interface{}) error {
+	n, ok := c.conn.(NetconfRunner)
+	if !ok {
+		return errors.New("connection does not support NETCONF")
+	}
+
+	_, span := tracing.Start(c.ctx, "netconf-rpc", attribute.String("rpc", rpc))
+	defer span.End()
+
+	b, err := n.RunNetconfRPC(rpc)
+	if err == nil {
+		err = xml.Unmarshal(b, obj)
+	}
+
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
 	return err
 }
 
+// SetContext sets the context commands are traced in
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// Context returns the context commands are traced in
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// WithContext returns a copy of the client tracing commands in the given context
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cl := *c
+	cl.ctx = ctx
+
+	return &cl
+}
+
 // Device returns device information for the connected device
 func (c *Client) Device() *connector.Device {
 	return c.conn.Device()
 }
 
+// CommandAborts returns the number of commands aborted by the connection guards by reason
+func (c *Client) CommandAborts() map[string]uint64 {
+	return c.conn.Aborts()
+}
+
 // EnableDebug enables the debug mode
 func (c *Client) EnableDebug() {
 	c.debug = true
@@ -69,6 +212,30 @@ func (c *Client) DisableDebug() {
 	c.debug = false
 }
 
+// EnableOutputSharing runs each command only once, subsequent calls of a command get the same output
+func (c *Client) EnableOutputSharing() {
+	c.outputs = newOutputCache()
+}
+
+// EnableCommandLog records the commands run by the client
+func (c *Client) EnableCommandLog() {
+	c.commands = &commandLog{}
+}
+
+// Commands returns the commands run by the client (requires EnableCommandLog)
+func (c *Client) Commands() []string {
+	if c.commands == nil {
+		return nil
+	}
+
+	return c.commands.list()
+}
+
+// SetRateLimiter paces the commands run by the client (commands served from shared outputs are not paced)
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.limiter = l
+}
+
 // EnableSatellite enables satellite device metrics gathering
 func (c *Client) EnableSatellite() {
 	c.Satellite = true
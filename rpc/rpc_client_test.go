@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextConnection struct{}
+
+func (*contextConnection) RunCommand(cmd string) ([]byte, error) {
+	return []byte("<result/>"), nil
+}
+
+func (*contextConnection) RunCommandContext(ctx context.Context, cmd string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return []byte("<result/>"), nil
+}
+
+func (*contextConnection) Host() string {
+	return "router1"
+}
+
+func (*contextConnection) Device() *connector.Device {
+	return &connector.Device{Host: "router1"}
+}
+
+func (*contextConnection) Aborts() map[string]uint64 {
+	return nil
+}
+
+func TestRunCommandCanceled(t *testing.T) {
+	c := NewClient(&contextConnection{})
+
+	var x struct{}
+	assert.NoError(t, c.RunCommandAndParse("show version", &x))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.WithContext(ctx).RunCommandAndParse("show version", &x)
+	assert.ErrorIs(t, err, context.Canceled, "commands are aborted when the context is done")
+}
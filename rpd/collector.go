@@ -0,0 +1,87 @@
+package rpd
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_rpd_"
+
+var (
+	taskMemoryInUseDesc     *prometheus.Desc
+	taskMemoryMaxDesc       *prometheus.Desc
+	krtQueueLengthDesc      *prometheus.Desc
+	krtOperationsQueuedDesc *prometheus.Desc
+	krtRouteOperationsDesc  *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target"}
+	taskMemoryInUseDesc = prometheus.NewDesc(prefix+"task_memory_in_use_bytes", "Memory currently used by the routing protocol daemon", l, nil)
+	taskMemoryMaxDesc = prometheus.NewDesc(prefix+"task_memory_max_bytes", "Max. memory used by the routing protocol daemon", l, nil)
+	krtQueueLengthDesc = prometheus.NewDesc(prefix+"krt_queue_length", "Number of entries in the kernel routing table queue", append(l, "queue"), nil)
+	krtOperationsQueuedDesc = prometheus.NewDesc(prefix+"krt_operations_queued", "Number of operations queued to be sent to the kernel via routing socket", l, nil)
+	krtRouteOperationsDesc = prometheus.NewDesc(prefix+"krt_route_operations_total", "Number of route operations sent to the kernel via routing socket", append(l, "operation"), nil)
+}
+
+type rpdCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &rpdCollector{}
+}
+
+// Name returns the name of the collector
+func (*rpdCollector) Name() string {
+	return "RPD"
+}
+
+// Describe describes the metrics
+func (*rpdCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- taskMemoryInUseDesc
+	ch <- taskMemoryMaxDesc
+	ch <- krtQueueLengthDesc
+	ch <- krtOperationsQueuedDesc
+	ch <- krtRouteOperationsDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *rpdCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var mem taskMemoryRpc
+	err := client.RunCommandAndParse("show task memory", &mem)
+	if err != nil {
+		return err
+	}
+
+	// values are reported in kB
+	ch <- prometheus.MustNewConstMetric(taskMemoryInUseDesc, prometheus.GaugeValue, float64(mem.Information.InUseSize*1024), labelValues...)
+	ch <- prometheus.MustNewConstMetric(taskMemoryMaxDesc, prometheus.GaugeValue, float64(mem.Information.MaxSize*1024), labelValues...)
+
+	var queue krtQueueRpc
+	err = client.RunCommandAndParse("show krt queue", &queue)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range queue.Queues {
+		ch <- prometheus.MustNewConstMetric(krtQueueLengthDesc, prometheus.GaugeValue, float64(q.Length), append(labelValues, strings.TrimSpace(q.Type))...)
+	}
+
+	var state krtStateRpc
+	err = client.RunCommandAndParse("show krt state", &state)
+	if err != nil {
+		return err
+	}
+
+	s := state.State
+	ch <- prometheus.MustNewConstMetric(krtOperationsQueuedDesc, prometheus.GaugeValue, float64(s.OperationsQueued), labelValues...)
+	ch <- prometheus.MustNewConstMetric(krtRouteOperationsDesc, prometheus.CounterValue, float64(s.RouteAdds), append(labelValues, "add")...)
+	ch <- prometheus.MustNewConstMetric(krtRouteOperationsDesc, prometheus.CounterValue, float64(s.RouteChanges), append(labelValues, "change")...)
+	ch <- prometheus.MustNewConstMetric(krtRouteOperationsDesc, prometheus.CounterValue, float64(s.RouteDeletes), append(labelValues, "delete")...)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package rpd
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKrtQueue(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/18.2R3/junos">
+    <krt-queue-information xmlns="http://xml.juniper.net/junos/18.2R3/junos-routing">
+        <krt-queue>
+            <krtq-type>Routing table add queue</krtq-type>
+            <krtq-queue-length>12</krtq-queue-length>
+        </krt-queue>
+        <krt-queue>
+            <krtq-type>Interface add/delete/change queue</krtq-type>
+            <krtq-queue-length>0</krtq-queue-length>
+        </krt-queue>
+    </krt-queue-information>
+</rpc-reply>`
+
+	var x krtQueueRpc
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.Queues, 2)
+	assert.Equal(t, "Routing table add queue", x.Queues[0].Type)
+	assert.Equal(t, uint64(12), x.Queues[0].Length)
+}
+
+func TestParseTaskMemory(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/18.2R3/junos">
+    <task-memory-information>
+        <task-memory-in-use-size>231906</task-memory-in-use-size>
+        <task-memory-in-use-avail>6</task-memory-in-use-avail>
+        <task-memory-max-size>236430</task-memory-max-size>
+        <task-memory-max-avail>6</task-memory-max-avail>
+    </task-memory-information>
+</rpc-reply>`
+
+	var x taskMemoryRpc
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(231906), x.Information.InUseSize)
+	assert.Equal(t, uint64(236430), x.Information.MaxSize)
+}
@@ -0,0 +1,24 @@
+package rpd
+
+type taskMemoryRpc struct {
+	Information struct {
+		InUseSize uint64 `xml:"task-memory-in-use-size"`
+		MaxSize   uint64 `xml:"task-memory-max-size"`
+	} `xml:"task-memory-information"`
+}
+
+type krtQueueRpc struct {
+	Queues []struct {
+		Type   string `xml:"krtq-type"`
+		Length uint64 `xml:"krtq-queue-length"`
+	} `xml:"krt-queue-information>krt-queue"`
+}
+
+type krtStateRpc struct {
+	State struct {
+		OperationsQueued uint64 `xml:"krtq-operations-queued"`
+		RouteAdds        uint64 `xml:"krtq-rt-table-adds"`
+		RouteChanges     uint64 `xml:"krtq-rt-table-changes"`
+		RouteDeletes     uint64 `xml:"krtq-rt-table-deletes"`
+	} `xml:"krt-state-information>krt-state"`
+}
@@ -25,6 +25,7 @@ var (
 func init() {
 	lSession := []string{"target", "ip"}
 	upDesc = prometheus.NewDesc(prefix+"session_state", "Session is (0 = Down, 1 = Up, 2 = Connect, 3 = Ex-Incr, 4 = Ex-Start, 5 = Ex-Full)", lSession, nil)
+	collector.RegisterStates(prefix+"session_state", map[string]int{"Down": 0, "Up": 1, "Connect": 2, "Ex-Start": 3, "Ex-Incr": 4, "Ex-Full": 5})
 	flapsDesc = prometheus.NewDesc(prefix+"session_flap_count", "Number of session flaps", lSession, nil)
 	ipv4PrefixCountDesc = prometheus.NewDesc(prefix+"session_ipv4_prefix_count", "Number of IPv4 route validation records", lSession, nil)
 	ipv6PrefixCountDesc = prometheus.NewDesc(prefix+"session_ipv6_prefix_count", "Number of IPv6 route validation records", lSession, nil)
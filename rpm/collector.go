@@ -1,7 +1,11 @@
 package rpm
 
 import (
+	"log"
+	"time"
+
 	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/config"
 	"github.com/czerwonk/junos_exporter/rpc"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -17,6 +21,8 @@ var (
 	currRTTSumDesc    *prometheus.Desc
 	totalSentDesc     *prometheus.Desc
 	totalReceivedDesc *prometheus.Desc
+	provisionedDesc   *prometheus.Desc
+	provisioningDesc  *prometheus.Desc
 )
 
 func init() {
@@ -29,13 +35,18 @@ func init() {
 	currRTTJitterDesc = prometheus.NewDesc(prefix+"rtt_jitter_current", "Peak-to-peak difference, in microseconds", l, nil)
 	currRTTStddevDesc = prometheus.NewDesc(prefix+"rtt_stddev_current", "Standard deviation, in microseconds", l, nil)
 	currRTTSumDesc = prometheus.NewDesc(prefix+"rtt_sum_current", "Statistical sum", l, nil)
+
+	provisionedDesc = prometheus.NewDesc("junos_rpm_provisioned_tests", "Number of RPM tests provisioned on the device by the exporter (owner "+ProvisioningOwner+")", []string{"target"}, nil)
+	provisioningDesc = prometheus.NewDesc("junos_rpm_provisioning_success", "Last provisioning of the RPM tests succeeded (1 = success, 0 = failed)", []string{"target"}, nil)
 }
 
-type rpmCollector struct{}
+type rpmCollector struct {
+	tests func(host string) []*config.RPMTestConfig
+}
 
-// NewCollector creates a new collector
-func NewCollector() collector.RPCCollector {
-	return &rpmCollector{}
+// NewCollector creates a new collector. If tests is set, the RPM tests returned for a device are provisioned before collecting the results.
+func NewCollector(tests func(host string) []*config.RPMTestConfig) collector.RPCCollector {
+	return &rpmCollector{tests: tests}
 }
 
 // Name returns the name of the collector
@@ -53,10 +64,16 @@ func (*rpmCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- currRTTJitterDesc
 	ch <- currRTTStddevDesc
 	ch <- currRTTSumDesc
+	ch <- provisionedDesc
+	ch <- provisioningDesc
 }
 
 // Collect collects metrics from JunOS
 func (c *rpmCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	if c.tests != nil {
+		c.provision(client, ch, labelValues)
+	}
+
 	err := c.collect(client, ch, labelValues)
 	if err != nil {
 		return err
@@ -80,6 +97,34 @@ func (c *rpmCollector) collect(client *rpc.Client, ch chan<- prometheus.Metric,
 	return nil
 }
 
+func (c *rpmCollector) provision(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) {
+	target := labelValues[0]
+
+	now := time.Now()
+	var st *provisioningState
+	if provisioningAllowed(client.Context()) {
+		st = provisioned.provision(client, target, c.tests(target), now)
+	} else {
+		st = provisioned.state(target)
+	}
+
+	if st == nil {
+		return
+	}
+
+	success := 1
+	if st.err != nil {
+		success = 0
+
+		if st.lastAttempt.Equal(now) {
+			log.Printf("could not provision RPM tests on %s: %s", target, st.err)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(provisionedDesc, prometheus.GaugeValue, float64(st.tests), labelValues...)
+	ch <- prometheus.MustNewConstMetric(provisioningDesc, prometheus.GaugeValue, float64(success), labelValues...)
+}
+
 func (c *rpmCollector) collectForProbe(p RPMProbe, ch chan<- prometheus.Metric, labelValues []string) {
 	l := append(labelValues, []string{p.Owner, p.Name, p.Address, p.Type, p.Interface}...)
 
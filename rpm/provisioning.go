@@ -0,0 +1,154 @@
+package rpm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/rpc"
+)
+
+// ProvisioningOwner is the owner of all RPM tests provisioned by the exporter. Tests of other owners are never changed.
+const ProvisioningOwner = "junos_exporter"
+
+// provisioningRetryInterval is the time to wait after a failed provisioning before trying again
+const provisioningRetryInterval = 10 * time.Minute
+
+// provisioned keeps track of the tests loaded into the configuration of each device.
+// Collectors are created per scrape, so the state has to outlive the collector instance.
+var provisioned = newProvisioningTracker()
+
+type provisioningContextKey struct{}
+
+// WithProvisioning marks collections running in the context as allowed to provision RPM tests.
+// Scrapes (which could be triggered by anyone able to reach the exporter) must not change the configuration of the devices.
+func WithProvisioning(ctx context.Context) context.Context {
+	return context.WithValue(ctx, provisioningContextKey{}, true)
+}
+
+func provisioningAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(provisioningContextKey{}).(bool)
+	return allowed
+}
+
+type provisioningState struct {
+	config      string
+	tests       int
+	lastAttempt time.Time
+	err         error
+}
+
+type provisioningTracker struct {
+	targets map[string]*provisioningState
+	mu      sync.Mutex
+}
+
+func newProvisioningTracker() *provisioningTracker {
+	return &provisioningTracker{
+		targets: make(map[string]*provisioningState),
+	}
+}
+
+// provision loads the tests into the configuration of the device if they differ from the ones provisioned before.
+// Failed attempts are retried after provisioningRetryInterval.
+// The tests are loaded once per process even if there are none, so tests removed from the config while the exporter was not running are deleted as well.
+func (t *provisioningTracker) provision(client *rpc.Client, target string, tests []*config.RPMTestConfig, now time.Time) *provisioningState {
+	prev := t.state(target)
+
+	cfg := provisioningConfig(tests)
+	if prev != nil && prev.config == cfg && (prev.err == nil || now.Sub(prev.lastAttempt) < provisioningRetryInterval) {
+		return prev
+	}
+
+	// the lock is not held while loading the configuration, so devices are provisioned concurrently
+	st := &provisioningState{config: cfg, tests: len(tests), lastAttempt: now}
+	st.err = client.LoadConfiguration(cfg, fmt.Sprintf("RPM tests provisioned by junos_exporter (%d tests)", len(tests)))
+	if st.err != nil {
+		// the device still runs the previously provisioned tests (if any)
+		st.tests = 0
+		if prev != nil {
+			st.tests = prev.tests
+		}
+	}
+
+	t.mu.Lock()
+	t.targets[target] = st
+	t.mu.Unlock()
+
+	return st
+}
+
+// state returns the state of the last provisioning of the target (nil if not provisioned yet)
+func (t *provisioningTracker) state(target string) *provisioningState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.targets[target]
+}
+
+// provisioningConfig returns the configuration (text format) replacing all tests of the owner.
+// Without tests the owner is deleted.
+func provisioningConfig(tests []*config.RPMTestConfig) string {
+	var b strings.Builder
+
+	b.WriteString("services {\n    rpm {\n")
+
+	if len(tests) == 0 {
+		b.WriteString("        delete: probe " + ProvisioningOwner + ";\n")
+	} else {
+		b.WriteString("        replace:\n        probe " + ProvisioningOwner + " {\n")
+		for _, t := range tests {
+			writeTest(&b, t)
+		}
+		b.WriteString("        }\n")
+	}
+
+	b.WriteString("    }\n}\n")
+
+	return b.String()
+}
+
+func writeTest(b *strings.Builder, t *config.RPMTestConfig) {
+	stmt := func(format string, args ...interface{}) {
+		b.WriteString("                " + fmt.Sprintf(format, args...) + ";\n")
+	}
+
+	b.WriteString("            test " + t.Name + " {\n")
+
+	stmt("probe-type %s", t.ProbeType)
+
+	if t.ProbeType == "http-get" {
+		stmt("target url %s", t.Target)
+	} else {
+		stmt("target address %s", t.Target)
+	}
+
+	if t.ProbeCount > 0 {
+		stmt("probe-count %d", t.ProbeCount)
+	}
+
+	if t.ProbeInterval > 0 {
+		stmt("probe-interval %d", int(t.ProbeInterval.Seconds()))
+	}
+
+	if t.TestInterval > 0 {
+		stmt("test-interval %d", int(t.TestInterval.Seconds()))
+	}
+
+	if t.DestinationPort > 0 {
+		stmt("destination-port %d", t.DestinationPort)
+	}
+
+	if t.SourceAddress != "" {
+		stmt("source-address %s", t.SourceAddress)
+	}
+
+	if t.RoutingInstance != "" {
+		stmt("routing-instance %s", t.RoutingInstance)
+	}
+
+	b.WriteString("            }\n")
+}
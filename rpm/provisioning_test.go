@@ -0,0 +1,121 @@
+package rpm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConnection struct {
+	loaded []string
+	err    error
+}
+
+func (c *fakeConnection) RunCommand(cmd string) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *fakeConnection) Host() string {
+	return "router1"
+}
+
+func (c *fakeConnection) Device() *connector.Device {
+	return &connector.Device{Host: "router1"}
+}
+
+func (c *fakeConnection) Aborts() map[string]uint64 {
+	return nil
+}
+
+func (c *fakeConnection) LoadConfiguration(config, comment string) error {
+	c.loaded = append(c.loaded, config)
+	return c.err
+}
+
+func TestProvisioningConfig(t *testing.T) {
+	tests := []*config.RPMTestConfig{
+		{Name: "core1", Target: "192.0.2.1", ProbeType: "icmp-ping", ProbeCount: 5, ProbeInterval: time.Second, TestInterval: time.Minute, RoutingInstance: "mgmt"},
+		{Name: "web", Target: "http://192.0.2.2/", ProbeType: "http-get"},
+	}
+
+	expected := `services {
+    rpm {
+        replace:
+        probe junos_exporter {
+            test core1 {
+                probe-type icmp-ping;
+                target address 192.0.2.1;
+                probe-count 5;
+                probe-interval 1;
+                test-interval 60;
+                routing-instance mgmt;
+            }
+            test web {
+                probe-type http-get;
+                target url http://192.0.2.2/;
+            }
+        }
+    }
+}
+`
+	assert.Equal(t, expected, provisioningConfig(tests))
+	assert.Contains(t, provisioningConfig(nil), "delete: probe junos_exporter;")
+}
+
+func TestProvision(t *testing.T) {
+	tr := newProvisioningTracker()
+	conn := &fakeConnection{}
+	client := rpc.NewClient(conn)
+	now := time.Now()
+	tests := []*config.RPMTestConfig{{Name: "core1", Target: "192.0.2.1", ProbeType: "icmp-ping"}}
+
+	st := tr.provision(client, "router1", nil, now)
+	assert.NoError(t, st.err)
+	assert.Equal(t, 0, st.tests)
+	if assert.Len(t, conn.loaded, 1, "tests removed before a restart are deleted") {
+		assert.Contains(t, conn.loaded[0], "delete: probe junos_exporter;")
+	}
+
+	tr.provision(client, "router1", nil, now.Add(time.Minute))
+	assert.Len(t, conn.loaded, 1, "deleted once per process")
+	conn.loaded = nil
+
+	st = tr.provision(client, "router1", tests, now)
+	assert.NoError(t, st.err)
+	assert.Equal(t, 1, st.tests)
+	assert.Len(t, conn.loaded, 1)
+
+	tr.provision(client, "router1", tests, now.Add(time.Minute))
+	assert.Len(t, conn.loaded, 1, "unchanged tests are not loaded again")
+
+	conn.err = errors.New("configuration database locked")
+	tests = append(tests, &config.RPMTestConfig{Name: "core2", Target: "192.0.2.2", ProbeType: "icmp-ping"})
+	st = tr.provision(client, "router1", tests, now.Add(2*time.Minute))
+	assert.Error(t, st.err)
+	assert.Equal(t, 1, st.tests, "previous tests still active")
+	assert.Len(t, conn.loaded, 2)
+
+	tr.provision(client, "router1", tests, now.Add(3*time.Minute))
+	assert.Len(t, conn.loaded, 2, "no retry within retry interval")
+
+	conn.err = nil
+	st = tr.provision(client, "router1", tests, now.Add(2*time.Minute+provisioningRetryInterval))
+	assert.NoError(t, st.err)
+	assert.Equal(t, 2, st.tests)
+	assert.Len(t, conn.loaded, 3, "retried")
+
+	st = tr.provision(client, "router1", nil, now.Add(time.Hour))
+	assert.Equal(t, 0, st.tests)
+	assert.Contains(t, conn.loaded[3], "delete: probe junos_exporter;", "removed tests")
+}
+
+func TestProvisioningAllowed(t *testing.T) {
+	assert.False(t, provisioningAllowed(context.Background()), "scrape")
+	assert.True(t, provisioningAllowed(WithProvisioning(context.Background())), "background collection")
+}
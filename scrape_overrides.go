@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeOverrides are the settings of an ad-hoc scrape given by query parameters of the metrics path
+type scrapeOverrides struct {
+	// collect limits the collectors to the given ones (names as used in the features section, nil = all enabled collectors)
+	collect map[string]bool
+	// timeout after which no further collectors are started and running commands are aborted (0 = no timeout)
+	timeout time.Duration
+}
+
+// overridesForRequest parses the parameters collect and timeout of the request (nil if none of them is set)
+func overridesForRequest(r *http.Request) (*scrapeOverrides, error) {
+	q := r.URL.Query()
+	if q.Get("collect") == "" && q.Get("timeout") == "" {
+		return nil, nil
+	}
+
+	o := &scrapeOverrides{}
+
+	if s := q.Get("collect"); s != "" {
+		collect, err := parseCollectList(s)
+		if err != nil {
+			return nil, err
+		}
+
+		o.collect = collect
+	}
+
+	if s := q.Get("timeout"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout %s", s)
+		}
+
+		if *scrapeMaxTimeout > 0 && d > *scrapeMaxTimeout {
+			d = *scrapeMaxTimeout
+		}
+
+		o.timeout = d
+	}
+
+	return o, nil
+}
+
+// parseCollectList parses a comma separated list of collectors. Only collectors of the features section and configured custom collectors are accepted.
+func parseCollectList(s string) (map[string]bool, error) {
+	collect := make(map[string]bool)
+	features := make([]string, 0)
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		collect[name] = true
+
+		if strings.HasPrefix(name, "custom_") && isCustomCollector(strings.TrimPrefix(name, "custom_")) {
+			continue
+		}
+
+		features = append(features, name)
+	}
+
+	if len(collect) == 0 {
+		return nil, fmt.Errorf("no collectors given")
+	}
+
+	_, err := config.FeaturesFromList(features)
+	if err != nil {
+		return nil, err
+	}
+
+	return collect, nil
+}
+
+func isCustomCollector(name string) bool {
+	for _, cc := range cfg.CustomCollectors {
+		if cc.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// adHocCollector collects the devices using the overrides of the request. Since the result differs from regular scrapes,
+// it is neither shared with concurrent scrapes nor cached and background collection is bypassed.
+// Logical systems and tenant systems of the devices are not collected (use the ls parameter instead).
+type adHocCollector struct {
+	ctx           context.Context
+	devices       []*connector.Device
+	logicalSystem string
+	overrides     *scrapeOverrides
+}
+
+func newAdHocCollector(ctx context.Context, devices []*connector.Device, logicalSystem string, overrides *scrapeOverrides) *adHocCollector {
+	return &adHocCollector{
+		ctx:           ctx,
+		devices:       devices,
+		logicalSystem: logicalSystem,
+		overrides:     overrides,
+	}
+}
+
+// Describe implements prometheus.Collector interface
+func (c *adHocCollector) Describe(ch chan<- *prometheus.Desc) {
+	// no descriptors are sent since the metrics are collected by separate collectors per device (unchecked collector)
+}
+
+// Collect implements prometheus.Collector interface
+func (c *adHocCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := c.ctx
+	var deadline time.Time
+	if c.overrides.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(c.ctx, c.overrides.timeout)
+		defer cancel()
+
+		deadline, _ = ctx.Deadline()
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(c.devices))
+
+	for _, d := range c.devices {
+		go func(d *connector.Device) {
+			defer wg.Done()
+
+			col := newJunosCollector(ctx, []*connector.Device{d}, connManager, c.logicalSystem)
+			col.deadline = deadline
+			if c.overrides.collect != nil {
+				col.collectors.restrict(c.overrides.collect)
+			}
+
+			for _, m := range collectMetrics(col) {
+				ch <- m
+			}
+		}(d)
+	}
+
+	wg.Wait()
+}
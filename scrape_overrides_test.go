@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverridesForRequest(t *testing.T) {
+	old := cfg
+	defer func() { cfg = old }()
+
+	cfg = config.New()
+	cfg.CustomCollectors = []*config.CustomCollectorConfig{{Name: "ntp"}}
+
+	o, err := overridesForRequest(httptest.NewRequest("GET", "/metrics?target=router1", nil))
+	assert.NoError(t, err)
+	assert.Nil(t, o, "no overrides")
+
+	o, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?target=router1&collect=interfaces,bgp,custom_ntp", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"interfaces": true, "bgp": true, "custom_ntp": true}, o.collect)
+	assert.Equal(t, time.Duration(0), o.timeout)
+
+	_, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?collect=interfaces,chassis", nil))
+	assert.Error(t, err, "unknown collector")
+
+	_, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?collect=custom_ospf", nil))
+	assert.Error(t, err, "unknown custom collector")
+
+	_, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?collect=,", nil))
+	assert.Error(t, err, "no collectors")
+
+	o, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?timeout=10s", nil))
+	assert.NoError(t, err)
+	assert.Nil(t, o.collect)
+	assert.Equal(t, 10*time.Second, o.timeout)
+
+	o, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?timeout=1h", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, *scrapeMaxTimeout, o.timeout, "bounded by max. timeout")
+
+	_, err = overridesForRequest(httptest.NewRequest("GET", "/metrics?timeout=-1s", nil))
+	assert.Error(t, err, "negative timeout")
+}
+
+func TestCollectorsRestrict(t *testing.T) {
+	c := &config.Config{
+		Features: config.FeatureConfig{
+			BGP:           true,
+			Interfaces:    true,
+			RoutingEngine: true,
+		},
+	}
+
+	d := &connector.Device{Host: "router1"}
+	cols := collectorsForDevices([]*connector.Device{d}, c, "", interfacelabels.NewDynamicLabels())
+	cols.restrict(map[string]bool{"interfaces": true, "routing_engine": true, "ospf": true})
+
+	names := make([]string, 0)
+	for _, col := range cols.collectorsForDevice(d) {
+		names = append(names, col.Name())
+	}
+	assert.ElementsMatch(t, []string{"Interfaces", "Routing Engine"}, names)
+	assert.Len(t, cols.collectors, 2)
+}
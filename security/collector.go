@@ -2,6 +2,7 @@ package security
 
 import (
 	"encoding/xml"
+	"strconv"
 	"strings"
 
 	"github.com/czerwonk/junos_exporter/collector"
@@ -20,6 +21,11 @@ var (
 	maxFlowSession     *prometheus.Desc
 	currentCpSession   *prometheus.Desc
 	maxCpSession       *prometheus.Desc
+	spuFlowSessions    *prometheus.Desc
+	spuCpSessions      *prometheus.Desc
+	spuSessionShare    *prometheus.Desc
+	spuCreatedRate     *prometheus.Desc
+	spuClosedRate      *prometheus.Desc
 )
 
 func init() {
@@ -33,6 +39,13 @@ func init() {
 	maxFlowSession = prometheus.NewDesc(prefix+"maximum_flow_session", "Maximum flow of session", l, nil)
 	currentCpSession = prometheus.NewDesc(prefix+"current_cp_session", "Current central point session", l, nil)
 	maxCpSession = prometheus.NewDesc(prefix+"max_cp_session", "Maximum central point session", l, nil)
+
+	ls := append(l, "fpc", "pic")
+	spuFlowSessions = prometheus.NewDesc(prefix+"spu_flow_sessions", "Current flow sessions of the SPU", ls, nil)
+	spuCpSessions = prometheus.NewDesc(prefix+"spu_cp_sessions", "Current central point sessions of the SPU", ls, nil)
+	spuSessionShare = prometheus.NewDesc(prefix+"spu_flow_session_share", "Share of the flow sessions of the routing engine handled by the SPU (0-1)", ls, nil)
+	spuCreatedRate = prometheus.NewDesc(prefix+"spu_sessions_created_per_second", "Sessions created per second on the SPU", ls, nil)
+	spuClosedRate = prometheus.NewDesc(prefix+"spu_sessions_closed_per_second", "Sessions closed per second on the SPU", ls, nil)
 }
 
 type securityCollector struct {
@@ -58,12 +71,19 @@ func (*securityCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- maxFlowSession
 	ch <- currentCpSession
 	ch <- maxCpSession
+	ch <- spuFlowSessions
+	ch <- spuCpSessions
+	ch <- spuSessionShare
+	ch <- spuCreatedRate
+	ch <- spuClosedRate
 }
 
 // Collect collects metrics from JunOS
 func (c *securityCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
 	var x = RpcReply{}
-	err := client.RunCommandAndParse("show security monitoring", &x)
+	err := client.RunCommandAndParseWithParser("show security monitoring", func(b []byte) error {
+		return parseXML(b, &x)
+	})
 	if err != nil {
 		return err
 	}
@@ -80,6 +100,45 @@ func (c *securityCollector) Collect(client *rpc.Client, ch chan<- prometheus.Met
 			ch <- prometheus.MustNewConstMetric(currentCpSession, prometheus.GaugeValue, float64(ps.CurrentCP), ls...)
 			ch <- prometheus.MustNewConstMetric(maxCpSession, prometheus.GaugeValue, float64(ps.MaxCP), ls...)
 		}
+
+		c.collectSessionDistribution(re, ch, ls)
+	}
+
+	return c.collectSessionRates(client, ch, labelValues)
+}
+
+func (c *securityCollector) collectSessionDistribution(re RoutingEngine, ch chan<- prometheus.Metric, labelValues []string) {
+	var total int64
+	for _, ps := range re.PerformanceSummary.PerformanceStatistics {
+		total += ps.CurrentFlow
+	}
+
+	for _, ps := range re.PerformanceSummary.PerformanceStatistics {
+		l := append(labelValues, strconv.FormatInt(ps.FPCNumber, 10), strconv.FormatInt(ps.PICNumber, 10))
+		ch <- prometheus.MustNewConstMetric(spuFlowSessions, prometheus.GaugeValue, float64(ps.CurrentFlow), l...)
+		ch <- prometheus.MustNewConstMetric(spuCpSessions, prometheus.GaugeValue, float64(ps.CurrentCP), l...)
+
+		if total > 0 {
+			ch <- prometheus.MustNewConstMetric(spuSessionShare, prometheus.GaugeValue, float64(ps.CurrentFlow)/float64(total), l...)
+		}
+	}
+}
+
+func (c *securityCollector) collectSessionRates(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = RpcReply{}
+	err := client.RunCommandAndParseWithParser("show security monitoring performance session", func(b []byte) error {
+		return parseXML(b, &x)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, re := range x.MultiRoutingEngineResults.RoutingEngine {
+		for _, s := range re.PerformanceSession.PerformanceStatistics {
+			l := append(labelValues, re.Name, strconv.FormatInt(s.FPCNumber, 10), strconv.FormatInt(s.PICNumber, 10))
+			ch <- prometheus.MustNewConstMetric(spuCreatedRate, prometheus.GaugeValue, float64(s.CreatedRate), l...)
+			ch <- prometheus.MustNewConstMetric(spuClosedRate, prometheus.GaugeValue, float64(s.ClosedRate), l...)
+		}
 	}
 
 	return nil
@@ -101,6 +160,7 @@ func parseXML(b []byte, res *RpcReply) error {
 		{
 			Name:               "N/A",
 			PerformanceSummary: fi.PerformanceSummary,
+			PerformanceSession: fi.PerformanceSession,
 		},
 	}
 	return nil
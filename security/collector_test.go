@@ -0,0 +1,63 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXMLWithoutRoutingEngines(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <performance-session-information>
+        <performance-session-statistics>
+            <fpc-number>1</fpc-number>
+            <pic-number>0</pic-number>
+            <session-creation-rate>150</session-creation-rate>
+            <session-close-rate>140</session-close-rate>
+        </performance-session-statistics>
+    </performance-session-information>
+</rpc-reply>`
+
+	x := RpcReply{}
+	err := parseXML([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.MultiRoutingEngineResults.RoutingEngine, 1)
+
+	re := x.MultiRoutingEngineResults.RoutingEngine[0]
+	assert.Equal(t, "N/A", re.Name)
+	assert.Equal(t, []SecurityPerformanceSessionStatistics{
+		{FPCNumber: 1, PICNumber: 0, CreatedRate: 150, ClosedRate: 140},
+	}, re.PerformanceSession.PerformanceStatistics)
+}
+
+func TestParseXMLWithRoutingEngines(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <multi-routing-engine-results>
+        <multi-routing-engine-item>
+            <re-name>node0</re-name>
+            <performance-summary-information>
+                <performance-summary-statistics>
+                    <fpc-number>1</fpc-number>
+                    <pic-number>0</pic-number>
+                    <spu-current-flow-session>1000</spu-current-flow-session>
+                </performance-summary-statistics>
+            </performance-summary-information>
+        </multi-routing-engine-item>
+    </multi-routing-engine-results>
+</rpc-reply>`
+
+	x := RpcReply{}
+	err := parseXML([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.MultiRoutingEngineResults.RoutingEngine, 1)
+
+	re := x.MultiRoutingEngineResults.RoutingEngine[0]
+	assert.Equal(t, "node0", re.Name)
+	assert.Equal(t, int64(1000), re.PerformanceSummary.PerformanceStatistics[0].CurrentFlow)
+}
@@ -14,6 +14,7 @@ type MultiRoutingEngineResults struct {
 type RoutingEngine struct {
 	Name               string                     `xml:"re-name"`
 	PerformanceSummary SecurityPerformanceSummary `xml:"performance-summary-information"`
+	PerformanceSession SecurityPerformanceSession `xml:"performance-session-information"`
 }
 
 type SecurityPerformanceSummary struct {
@@ -31,7 +32,19 @@ type SecurityPerformanceStatistics struct {
 	MaxCP       int64 `xml:"spu-max-cp-session"`
 }
 
+type SecurityPerformanceSession struct {
+	PerformanceStatistics []SecurityPerformanceSessionStatistics `xml:"performance-session-statistics"`
+}
+
+type SecurityPerformanceSessionStatistics struct {
+	FPCNumber   int64 `xml:"fpc-number"`
+	PICNumber   int64 `xml:"pic-number"`
+	CreatedRate int64 `xml:"session-creation-rate"`
+	ClosedRate  int64 `xml:"session-close-rate"`
+}
+
 type RpcReplyNoRE struct {
 	XMLName            xml.Name                   `xml:"rpc-reply"`
 	PerformanceSummary SecurityPerformanceSummary `xml:"performance-summary-information"`
+	PerformanceSession SecurityPerformanceSession `xml:"performance-session-information"`
 }
@@ -0,0 +1,163 @@
+package securityservices
+
+import (
+	"log"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_security_"
+
+var (
+	idpAttacksDesc         *prometheus.Desc
+	idpPacketsDroppedDesc  *prometheus.Desc
+	idpSessionsDroppedDesc *prometheus.Desc
+	idpPolicyDropsDesc     *prometheus.Desc
+	antiVirusScansDesc     *prometheus.Desc
+	webFilteringDesc       *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target"}
+
+	idpAttacksDesc = prometheus.NewDesc(prefix+"idp_attacks_total", "Number of detected IDP attacks by category (first part of the attack name, e.g. HTTP)", append(l, "category"), nil)
+	idpPacketsDroppedDesc = prometheus.NewDesc(prefix+"idp_packets_dropped_total", "Number of packets dropped by IDP", l, nil)
+	idpSessionsDroppedDesc = prometheus.NewDesc(prefix+"idp_sessions_dropped_total", "Number of sessions dropped by IDP", l, nil)
+	idpPolicyDropsDesc = prometheus.NewDesc(prefix+"idp_policy_drops_total", "Number of drops caused by IDP policy actions", l, nil)
+	antiVirusScansDesc = prometheus.NewDesc(prefix+"utm_antivirus_scans_total", "Number of UTM anti-virus scan requests by result", append(l, "result"), nil)
+	webFilteringDesc = prometheus.NewDesc(prefix+"utm_web_filtering_requests_total", "Number of UTM web filtering requests by category", append(l, "category"), nil)
+}
+
+type securityServicesCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &securityServicesCollector{}
+}
+
+// Name returns the name of the collector
+func (*securityServicesCollector) Name() string {
+	return "Security Services"
+}
+
+// Describe describes the metrics
+func (*securityServicesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- idpAttacksDesc
+	ch <- idpPacketsDroppedDesc
+	ch <- idpSessionsDroppedDesc
+	ch <- idpPolicyDropsDesc
+	ch <- antiVirusScansDesc
+	ch <- webFilteringDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *securityServicesCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	// devices usually only license some of the services, so each service is collected independently
+	sections := []struct {
+		name    string
+		collect func(*rpc.Client, chan<- prometheus.Metric, []string) error
+	}{
+		{name: "IDP", collect: c.collectIDP},
+		{name: "anti-virus", collect: c.collectAntiVirus},
+		{name: "web filtering", collect: c.collectWebFiltering},
+	}
+
+	var err error
+	failed := 0
+	for _, s := range sections {
+		err = s.collect(client, ch, labelValues)
+		if err != nil {
+			log.Printf("could not get %s statistics of %s: %s", s.name, client.Device().Host, err)
+			failed++
+		}
+	}
+
+	if failed == len(sections) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *securityServicesCollector) collectIDP(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var attacks = idpAttackTableRpc{}
+	err := client.RunCommandAndParse("show security idp attack table", &attacks)
+	if err != nil {
+		return err
+	}
+
+	for category, hits := range attacksByCategory(attacks.Information.Attacks) {
+		ch <- prometheus.MustNewConstMetric(idpAttacksDesc, prometheus.CounterValue, float64(hits), append(labelValues, category)...)
+	}
+
+	var status = idpStatusRpc{}
+	err = client.RunCommandAndParse("show security idp status", &status)
+	if err != nil {
+		return err
+	}
+
+	s := status.Information
+	ch <- prometheus.MustNewConstMetric(idpPacketsDroppedDesc, prometheus.CounterValue, float64(s.PacketsDropped), labelValues...)
+	ch <- prometheus.MustNewConstMetric(idpSessionsDroppedDesc, prometheus.CounterValue, float64(s.SessionsDropped), labelValues...)
+	ch <- prometheus.MustNewConstMetric(idpPolicyDropsDesc, prometheus.CounterValue, float64(s.PolicyDrops), labelValues...)
+
+	return nil
+}
+
+func (c *securityServicesCollector) collectAntiVirus(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = antiVirusStatisticsRpc{}
+	err := client.RunCommandAndParse("show security utm anti-virus statistics", &x)
+	if err != nil {
+		return err
+	}
+
+	s := x.Information
+	c.counter(ch, antiVirusScansDesc, s.Clean, labelValues, "clean")
+	c.counter(ch, antiVirusScansDesc, s.ThreatFound, labelValues, "threat_found")
+	c.counter(ch, antiVirusScansDesc, s.Fallback, labelValues, "fallback")
+
+	return nil
+}
+
+func (c *securityServicesCollector) collectWebFiltering(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = webFilteringStatisticsRpc{}
+	err := client.RunCommandAndParse("show security utm web-filtering statistics", &x)
+	if err != nil {
+		return err
+	}
+
+	s := x.Information
+	c.counter(ch, webFilteringDesc, s.WhiteListHit, labelValues, "white_list")
+	c.counter(ch, webFilteringDesc, s.BlackListHit, labelValues, "black_list")
+	c.counter(ch, webFilteringDesc, s.ServerPermit, labelValues, "server_permit")
+	c.counter(ch, webFilteringDesc, s.ServerBlock, labelValues, "server_block")
+	c.counter(ch, webFilteringDesc, s.CustomPermit, labelValues, "custom_permit")
+	c.counter(ch, webFilteringDesc, s.CustomBlock, labelValues, "custom_block")
+	c.counter(ch, webFilteringDesc, s.DefaultPermit, labelValues, "default_permit")
+	c.counter(ch, webFilteringDesc, s.DefaultBlock, labelValues, "default_block")
+
+	return nil
+}
+
+func (c *securityServicesCollector) counter(ch chan<- prometheus.Metric, desc *prometheus.Desc, value int64, labelValues []string, label string) {
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), append(labelValues, label)...)
+}
+
+// attacksByCategory sums the hits of the attacks by the category prefix of the attack name (e.g. HTTP:STC:DIR:TRAVERSAL -> HTTP)
+func attacksByCategory(attacks []idpAttack) map[string]int64 {
+	categories := make(map[string]int64)
+	for _, a := range attacks {
+		category := strings.SplitN(strings.TrimSpace(a.Name), ":", 2)[0]
+		if category == "" {
+			continue
+		}
+
+		categories[category] += a.Hits
+	}
+
+	return categories
+}
@@ -0,0 +1,35 @@
+package securityservices
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttacksByCategory(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/21.4R3/junos">
+    <idp-attack-statistics-information>
+        <idp-attack-statistics>
+            <attack-name>HTTP:STC:DIR:TRAVERSAL</attack-name>
+            <attack-hits>3</attack-hits>
+        </idp-attack-statistics>
+        <idp-attack-statistics>
+            <attack-name>HTTP:SQL:INJ:GENERIC</attack-name>
+            <attack-hits>4</attack-hits>
+        </idp-attack-statistics>
+        <idp-attack-statistics>
+            <attack-name>DNS:OVERFLOW:TXT-LENGTH</attack-name>
+            <attack-hits>1</attack-hits>
+        </idp-attack-statistics>
+    </idp-attack-statistics-information>
+</rpc-reply>`
+
+	x := idpAttackTableRpc{}
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]int64{"HTTP": 7, "DNS": 1}, attacksByCategory(x.Information.Attacks))
+}
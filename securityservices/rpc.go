@@ -0,0 +1,43 @@
+package securityservices
+
+type idpAttackTableRpc struct {
+	Information struct {
+		Attacks []idpAttack `xml:"idp-attack-statistics"`
+	} `xml:"idp-attack-statistics-information"`
+}
+
+type idpAttack struct {
+	Name string `xml:"attack-name"`
+	Hits int64  `xml:"attack-hits"`
+}
+
+type idpStatusRpc struct {
+	Information struct {
+		PacketsDropped  int64 `xml:"idp-packets-dropped"`
+		SessionsDropped int64 `xml:"idp-sessions-dropped"`
+		PolicyDrops     int64 `xml:"idp-policy-drops"`
+	} `xml:"idp-status-information"`
+}
+
+type antiVirusStatisticsRpc struct {
+	Information struct {
+		Total       int64 `xml:"scan-request-total"`
+		Clean       int64 `xml:"scan-request-clean"`
+		ThreatFound int64 `xml:"scan-request-threat-found"`
+		Fallback    int64 `xml:"scan-request-fallback"`
+	} `xml:"utm-anti-virus-statistics"`
+}
+
+type webFilteringStatisticsRpc struct {
+	Information struct {
+		Total         int64 `xml:"total-requests"`
+		WhiteListHit  int64 `xml:"white-list-hit"`
+		BlackListHit  int64 `xml:"black-list-hit"`
+		ServerPermit  int64 `xml:"server-reply-permit"`
+		ServerBlock   int64 `xml:"server-reply-block"`
+		CustomPermit  int64 `xml:"custom-category-permit"`
+		CustomBlock   int64 `xml:"custom-category-block"`
+		DefaultPermit int64 `xml:"default-permit"`
+		DefaultBlock  int64 `xml:"default-block"`
+	} `xml:"utm-web-filtering-statistics"`
+}
@@ -0,0 +1,137 @@
+package sflow
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_sflow_"
+
+var (
+	enabledDesc                   *prometheus.Desc
+	sampleLimitDesc               *prometheus.Desc
+	collectorSamplesDesc          *prometheus.Desc
+	collectorDatagramsSentDesc    *prometheus.Desc
+	collectorDatagramsDroppedDesc *prometheus.Desc
+	interfaceEnabledDesc          *prometheus.Desc
+	interfaceSampleRateDesc       *prometheus.Desc
+	interfacePollingDesc          *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target"}
+	enabledDesc = prometheus.NewDesc(prefix+"enabled", "sFlow status of the device (1 = Enabled)", l, nil)
+	sampleLimitDesc = prometheus.NewDesc(prefix+"sample_limit", "Maximum number of samples per second", l, nil)
+
+	lc := append(l, "collector", "port")
+	collectorSamplesDesc = prometheus.NewDesc(prefix+"collector_samples_total", "Number of samples sent to the collector", lc, nil)
+	collectorDatagramsSentDesc = prometheus.NewDesc(prefix+"collector_datagrams_sent_total", "Number of datagrams sent to the collector", lc, nil)
+	collectorDatagramsDroppedDesc = prometheus.NewDesc(prefix+"collector_datagrams_dropped_total", "Number of datagrams dropped before being sent to the collector", lc, nil)
+
+	li := append(l, "name", "direction")
+	interfaceEnabledDesc = prometheus.NewDesc(prefix+"interface_enabled", "sFlow sampling status of the interface (1 = Enabled)", li, nil)
+	interfaceSampleRateDesc = prometheus.NewDesc(prefix+"interface_sample_rate", "Actual sample rate of the interface (1 out of n packets)", li, nil)
+	interfacePollingDesc = prometheus.NewDesc(prefix+"interface_polling_interval_seconds", "Interval in which interface counters are polled", append(l, "name"), nil)
+}
+
+type sflowCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &sflowCollector{}
+}
+
+// Name returns the name of the collector
+func (*sflowCollector) Name() string {
+	return "sFlow"
+}
+
+// Describe describes the metrics
+func (*sflowCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- enabledDesc
+	ch <- sampleLimitDesc
+	ch <- collectorSamplesDesc
+	ch <- collectorDatagramsSentDesc
+	ch <- collectorDatagramsDroppedDesc
+	ch <- interfaceEnabledDesc
+	ch <- interfaceSampleRateDesc
+	ch <- interfacePollingDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *sflowCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	err := c.collectStatus(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	err = c.collectCollectors(client, ch, labelValues)
+	if err != nil {
+		return err
+	}
+
+	return c.collectInterfaces(client, ch, labelValues)
+}
+
+func (c *sflowCollector) collectStatus(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = sflowRpc{}
+	err := client.RunCommandAndParse("show sflow", &x)
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, boolToFloat(x.Information.Status == "Enabled"), labelValues...)
+	ch <- prometheus.MustNewConstMetric(sampleLimitDesc, prometheus.GaugeValue, float64(x.Information.SampleLimit), labelValues...)
+
+	return nil
+}
+
+func (c *sflowCollector) collectCollectors(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = sflowCollectorRpc{}
+	err := client.RunCommandAndParse("show sflow collector", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range x.Information.Collectors {
+		l := append(labelValues, col.Address, col.Port)
+		ch <- prometheus.MustNewConstMetric(collectorSamplesDesc, prometheus.CounterValue, float64(col.Samples), l...)
+		ch <- prometheus.MustNewConstMetric(collectorDatagramsSentDesc, prometheus.CounterValue, float64(col.DatagramsSent), l...)
+		ch <- prometheus.MustNewConstMetric(collectorDatagramsDroppedDesc, prometheus.CounterValue, float64(col.DatagramsDropped), l...)
+	}
+
+	return nil
+}
+
+func (c *sflowCollector) collectInterfaces(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = sflowInterfaceRpc{}
+	err := client.RunCommandAndParse("show sflow interface", &x)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range x.Information.Interfaces {
+		l := append(labelValues, iface.Name)
+		ch <- prometheus.MustNewConstMetric(interfacePollingDesc, prometheus.GaugeValue, float64(iface.PollingInterval), l...)
+
+		li := append(l, "ingress")
+		ch <- prometheus.MustNewConstMetric(interfaceEnabledDesc, prometheus.GaugeValue, boolToFloat(iface.StatusIngress == "Enabled"), li...)
+		ch <- prometheus.MustNewConstMetric(interfaceSampleRateDesc, prometheus.GaugeValue, float64(iface.SampleRateIngressAct), li...)
+
+		le := append(l, "egress")
+		ch <- prometheus.MustNewConstMetric(interfaceEnabledDesc, prometheus.GaugeValue, boolToFloat(iface.StatusEgress == "Enabled"), le...)
+		ch <- prometheus.MustNewConstMetric(interfaceSampleRateDesc, prometheus.GaugeValue, float64(iface.SampleRateEgressAct), le...)
+	}
+
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,37 @@
+package sflow
+
+type sflowRpc struct {
+	Information struct {
+		Status      string `xml:"sflow-status"`
+		SampleLimit int64  `xml:"sflow-sample-limit"`
+	} `xml:"sflow-information"`
+}
+
+type sflowCollectorRpc struct {
+	Information struct {
+		Collectors []sflowTargetCollector `xml:"sflow-collector"`
+	} `xml:"sflow-collector-information"`
+}
+
+type sflowTargetCollector struct {
+	Address          string `xml:"collector-address"`
+	Port             string `xml:"udp-port"`
+	Samples          int64  `xml:"no-of-samples"`
+	DatagramsSent    int64  `xml:"no-of-datagrams-sent"`
+	DatagramsDropped int64  `xml:"no-of-datagrams-dropped"`
+}
+
+type sflowInterfaceRpc struct {
+	Information struct {
+		Interfaces []sflowInterface `xml:"sflow-interface"`
+	} `xml:"sflow-interface-information"`
+}
+
+type sflowInterface struct {
+	Name                 string `xml:"interface-name"`
+	StatusIngress        string `xml:"status-ingress"`
+	StatusEgress         string `xml:"status-egress"`
+	SampleRateIngressAct int64  `xml:"sample-rate-ingress-act"`
+	SampleRateEgressAct  int64  `xml:"sample-rate-egress-act"`
+	PollingInterval      int64  `xml:"polling-interval"`
+}
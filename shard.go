@@ -0,0 +1,78 @@
+package main
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/pkg/errors"
+)
+
+// shard is the part of the configured targets collected by this instance
+type shard struct {
+	index int
+	count int
+}
+
+// parseShard parses a shard definition in the format index/count (e.g. 2/5), index starting at 1
+func parseShard(s string) (*shard, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid shard %s, expected format index/count (e.g. 2/5)", s)
+	}
+
+	index, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid shard index %s", parts[0])
+	}
+
+	count, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid shard count %s", parts[1])
+	}
+
+	if count < 1 || index < 1 || index > count {
+		return nil, errors.Errorf("invalid shard %s, index has to be between 1 and %d", s, count)
+	}
+
+	return &shard{index: index, count: count}, nil
+}
+
+// contains returns if the host is assigned to the shard. Hosts are assigned using rendezvous hashing,
+// so only the targets of one shard are reassigned when the number of shards changes.
+func (s *shard) contains(host string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(host))
+	key := h.Sum64()
+
+	var max uint64
+	assigned := 0
+
+	for i := 1; i <= s.count; i++ {
+		if w := mix(key ^ uint64(i)*0x9e3779b97f4a7c15); w > max || i == 1 {
+			max = w
+			assigned = i
+		}
+	}
+
+	return assigned == s.index
+}
+
+// mix is the finalizer of splitmix64 to get evenly distributed weights per shard
+func mix(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func (s *shard) filter(devices []*connector.Device) []*connector.Device {
+	devs := make([]*connector.Device, 0)
+	for _, d := range devices {
+		if s.contains(d.Host) {
+			devs = append(devs, d)
+		}
+	}
+
+	return devs
+}
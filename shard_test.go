@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseShard(t *testing.T) {
+	s, err := parseShard("2/5")
+	assert.NoError(t, err)
+	assert.Equal(t, &shard{index: 2, count: 5}, s)
+
+	for _, invalid := range []string{"", "2", "0/5", "6/5", "a/5", "1/0"} {
+		_, err = parseShard(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestShardAssignsEachTargetOnce(t *testing.T) {
+	devs := make([]*connector.Device, 100)
+	for i := range devs {
+		devs[i] = &connector.Device{Host: fmt.Sprintf("router%d", i)}
+	}
+
+	assigned := make(map[string]int)
+	for i := 1; i <= 5; i++ {
+		s := &shard{index: i, count: 5}
+
+		part := s.filter(devs)
+		assert.NotEmpty(t, part, "shard %d", i)
+
+		for _, d := range part {
+			assigned[d.Host]++
+		}
+	}
+
+	assert.Len(t, assigned, len(devs))
+	for host, count := range assigned {
+		assert.Equal(t, 1, count, host)
+	}
+}
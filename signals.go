@@ -0,0 +1,15 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySignals relays SIGHUP to reload and SIGTERM to term
+func notifySignals(reload, term chan<- os.Signal) {
+	signal.Notify(reload, syscall.SIGHUP)
+	signal.Notify(term, syscall.SIGTERM)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows/svc"
+)
+
+// notifySignals relays Ctrl+C and Ctrl+Break to term. If the exporter runs as Windows service,
+// stop and shutdown requests are relayed to term and parameter changes (sc control <service> paramchange) to reload.
+func notifySignals(reload, term chan<- os.Signal) {
+	signal.Notify(term, os.Interrupt)
+
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		log.Errorf("Could not determine if running as Windows service: %v", err)
+		return
+	}
+
+	if !isService {
+		return
+	}
+
+	go func() {
+		err := svc.Run("junos_exporter", &windowsService{reload: reload, term: term})
+		if err != nil {
+			log.Fatalf("Could not run as Windows service: %v", err)
+		}
+	}()
+}
+
+// windowsService handles the requests of the Windows service control manager
+type windowsService struct {
+	reload chan<- os.Signal
+	term   chan<- os.Signal
+}
+
+// Execute implements svc.Handler
+func (s *windowsService) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	const accepts = svc.AcceptStop | svc.AcceptShutdown | svc.AcceptParamChange
+
+	changes <- svc.Status{State: svc.Running, Accepts: accepts}
+
+	for r := range requests {
+		switch r.Cmd {
+		case svc.Interrogate:
+			changes <- r.CurrentStatus
+		case svc.ParamChange:
+			s.reload <- syscall.SIGHUP
+		case svc.Stop, svc.Shutdown:
+			changes <- svc.Status{State: svc.StopPending}
+			s.term <- syscall.SIGTERM
+			return false, 0
+		}
+	}
+
+	return false, 0
+}
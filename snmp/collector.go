@@ -0,0 +1,99 @@
+package snmp
+
+import (
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix string = "junos_snmp_"
+
+var (
+	inputPacketsDesc  *prometheus.Desc
+	outputPacketsDesc *prometheus.Desc
+	inputErrorsDesc   *prometheus.Desc
+	inputDropsDesc    *prometheus.Desc
+	v3InputErrorsDesc *prometheus.Desc
+)
+
+func init() {
+	l := []string{"target"}
+	inputPacketsDesc = prometheus.NewDesc(prefix+"input_packets_total", "Number of SNMP packets received by the agent of the device", l, nil)
+	outputPacketsDesc = prometheus.NewDesc(prefix+"output_packets_total", "Number of SNMP packets sent by the agent of the device", l, nil)
+	inputErrorsDesc = prometheus.NewDesc(prefix+"input_errors_total", "Number of received SNMP packets rejected by the agent of the device", append(l, "type"), nil)
+	inputDropsDesc = prometheus.NewDesc(prefix+"input_drops_total", "Number of received SNMP packets dropped by the agent of the device", append(l, "reason"), nil)
+	v3InputErrorsDesc = prometheus.NewDesc(prefix+"v3_input_errors_total", "Number of received SNMPv3 packets rejected by the agent of the device", append(l, "type"), nil)
+}
+
+type snmpCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &snmpCollector{}
+}
+
+// Name returns the name of the collector
+func (*snmpCollector) Name() string {
+	return "SNMP"
+}
+
+// Describe describes the metrics
+func (*snmpCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- inputPacketsDesc
+	ch <- outputPacketsDesc
+	ch <- inputErrorsDesc
+	ch <- inputDropsDesc
+	ch <- v3InputErrorsDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *snmpCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = statisticsRpc{}
+	err := client.RunCommandAndParse("show snmp statistics", &x)
+	if err != nil {
+		return err
+	}
+
+	in := x.Statistics.Input
+	ch <- prometheus.MustNewConstMetric(inputPacketsDesc, prometheus.CounterValue, float64(in.Packets), labelValues...)
+	ch <- prometheus.MustNewConstMetric(outputPacketsDesc, prometheus.CounterValue, float64(x.Statistics.Output.Packets), labelValues...)
+
+	c.collectValues(ch, inputErrorsDesc, labelValues, map[string]uint64{
+		"bad_versions":        in.BadVersions,
+		"bad_community_names": in.BadCommunityNames,
+		"bad_community_uses":  in.BadCommunityUses,
+		"asn_parse_errors":    in.AsnParseErrors,
+	})
+
+	c.collectValues(ch, inputDropsDesc, labelValues, map[string]uint64{
+		"silent":            in.SilentDrops,
+		"proxy":             in.ProxyDrops,
+		"commit_pending":    in.CommitPendingDrops,
+		"throttle":          in.ThrottleDrops,
+		"duplicate_request": in.DuplicateRequestDrops,
+	})
+
+	v3 := x.Statistics.V3Input
+	c.collectValues(ch, v3InputErrorsDesc, labelValues, map[string]uint64{
+		"unknown_security_models":     v3.UnknownSecurityModels,
+		"invalid_messages":            v3.InvalidMessages,
+		"unknown_pdu_handlers":        v3.UnknownPduHandlers,
+		"unavailable_contexts":        v3.UnavailableContexts,
+		"unknown_contexts":            v3.UnknownContexts,
+		"unsupported_security_levels": v3.UnsupportedSecurityLevels,
+		"not_in_time_windows":         v3.NotInTimeWindows,
+		"unknown_user_names":          v3.UnknownUserNames,
+		"unknown_engine_ids":          v3.UnknownEngineIDs,
+		"wrong_digests":               v3.WrongDigests,
+		"decryption_errors":           v3.DecryptionErrors,
+	})
+
+	return nil
+}
+
+func (c *snmpCollector) collectValues(ch chan<- prometheus.Metric, desc *prometheus.Desc, labelValues []string, values map[string]uint64) {
+	for k, v := range values {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v), append(labelValues, k)...)
+	}
+}
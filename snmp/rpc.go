@@ -0,0 +1,34 @@
+package snmp
+
+type statisticsRpc struct {
+	Statistics struct {
+		Input struct {
+			Packets               uint64 `xml:"packets"`
+			BadVersions           uint64 `xml:"bad-versions"`
+			BadCommunityNames     uint64 `xml:"bad-community-names"`
+			BadCommunityUses      uint64 `xml:"bad-community-uses"`
+			AsnParseErrors        uint64 `xml:"asn-parse-errors"`
+			SilentDrops           uint64 `xml:"silent-drops"`
+			ProxyDrops            uint64 `xml:"proxy-drops"`
+			CommitPendingDrops    uint64 `xml:"commit-pending-drops"`
+			ThrottleDrops         uint64 `xml:"throttle-drops"`
+			DuplicateRequestDrops uint64 `xml:"duplicate-request-drops"`
+		} `xml:"snmp-input-statistics"`
+		V3Input struct {
+			UnknownSecurityModels     uint64 `xml:"unknown-secmodels"`
+			InvalidMessages           uint64 `xml:"invalid-msgs"`
+			UnknownPduHandlers        uint64 `xml:"unknown-pduhandlers"`
+			UnavailableContexts       uint64 `xml:"unavail-contexts"`
+			UnknownContexts           uint64 `xml:"unknown-contexts"`
+			UnsupportedSecurityLevels uint64 `xml:"unsupported-seclevels"`
+			NotInTimeWindows          uint64 `xml:"not-in-timewindows"`
+			UnknownUserNames          uint64 `xml:"unknown-usernames"`
+			UnknownEngineIDs          uint64 `xml:"unknown-eids"`
+			WrongDigests              uint64 `xml:"wrong-digests"`
+			DecryptionErrors          uint64 `xml:"decrypt-errors"`
+		} `xml:"snmp-v3-input-statistics"`
+		Output struct {
+			Packets uint64 `xml:"packets"`
+		} `xml:"snmp-output-statistics"`
+	} `xml:"snmp-statistics"`
+}
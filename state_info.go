@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var (
+	stateInfoDescs   = make(map[string]*prometheus.Desc)
+	stateInfoDescsMu sync.Mutex
+)
+
+// stateInfo returns an info metric (<name>_info) labeled with the name of the current state for metrics with registered states (see collector.RegisterStates).
+// Returns nil for other metrics or values not mapped to a state.
+func stateInfo(m prometheus.Metric, name string) prometheus.Metric {
+	if !collector.HasStates(name) {
+		return nil
+	}
+
+	pb := &dto.Metric{}
+	err := m.Write(pb)
+	if err != nil || pb.Gauge == nil {
+		return nil
+	}
+
+	state, found := collector.StateName(name, pb.Gauge.GetValue())
+	if !found {
+		return nil
+	}
+
+	labelNames := make([]string, 0, len(pb.Label)+1)
+	labelValues := make([]string, 0, len(pb.Label)+1)
+	for _, l := range pb.Label {
+		labelNames = append(labelNames, l.GetName())
+		labelValues = append(labelValues, l.GetValue())
+	}
+
+	desc := stateInfoDesc(name, append(labelNames, "state"))
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, append(labelValues, state)...)
+}
+
+// stateInfoDesc returns the desc of the info metric. Descs are cached by name and label names,
+// since label names of the same metric differ between targets (e.g. dynamic interface labels).
+func stateInfoDesc(name string, labelNames []string) *prometheus.Desc {
+	key := name + "\xff" + strings.Join(labelNames, "\xff")
+
+	stateInfoDescsMu.Lock()
+	defer stateInfoDescsMu.Unlock()
+
+	desc, found := stateInfoDescs[key]
+	if !found {
+		desc = prometheus.NewDesc(name+"_info", "Name of the current state of "+name, labelNames, nil)
+		stateInfoDescs[key] = desc
+	}
+
+	return desc
+}
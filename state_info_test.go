@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateInfo(t *testing.T) {
+	collector.RegisterStates("junos_test_session_state", map[string]int{"Down": 0, "Up": 1})
+	desc := prometheus.NewDesc("junos_test_session_state", "Session state", []string{"target", "peer"}, nil)
+
+	m := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "router1", "192.168.1.2")
+	info := stateInfo(m, "junos_test_session_state")
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "junos_test_session_state_info", metricName(info))
+
+		pb := &dto.Metric{}
+		assert.NoError(t, info.Write(pb))
+		assert.Equal(t, 1.0, pb.Gauge.GetValue())
+
+		labels := make(map[string]string)
+		for _, l := range pb.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Equal(t, map[string]string{"target": "router1", "peer": "192.168.1.2", "state": "Up"}, labels)
+	}
+
+	m = prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 7, "router1", "192.168.1.2")
+	assert.Nil(t, stateInfo(m, "junos_test_session_state"), "unknown state")
+
+	withLabels := prometheus.NewDesc("junos_test_session_state", "Session state", []string{"target", "peer", "site"}, nil)
+	m = prometheus.MustNewConstMetric(withLabels, prometheus.GaugeValue, 0, "router2", "192.168.1.3", "fra")
+	assert.NotPanics(t, func() {
+		info = stateInfo(m, "junos_test_session_state")
+	}, "different label names of the same metric")
+	if assert.NotNil(t, info) {
+		pb := &dto.Metric{}
+		assert.NoError(t, info.Write(pb))
+		assert.Len(t, pb.Label, 4)
+	}
+
+	other := prometheus.NewDesc("junos_test_other", "Other", []string{"target"}, nil)
+	m = prometheus.MustNewConstMetric(other, prometheus.GaugeValue, 1, "router1")
+	assert.Nil(t, stateInfo(m, "junos_test_other"), "no states registered")
+}
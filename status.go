@@ -0,0 +1,243 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/czerwonk/junos_exporter/interfacelabels"
+	"github.com/czerwonk/junos_exporter/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxStatusErrors = 10
+
+var (
+	status         = newStatusTracker()
+	statusTemplate = template.Must(template.New("status").Parse(`<html>
+<head><title>JunOS Exporter - Status</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.down { color: #c00; }
+</style>
+</head>
+<body>
+<h1>JunOS Exporter - Status</h1>
+<table>
+<tr><th>Target</th><th>Platform</th><th>Last scrape</th><th>Series</th><th>Collectors</th><th>Recent errors</th></tr>
+{{range .Targets}}
+<tr>
+<td><a href="{{$.MetricsPath}}?target={{.Host}}">{{.Host}}</a></td>
+<td>{{.Platform}}{{if .DuplicateOf}}<br/>duplicate of {{.DuplicateOf}}{{end}}</td>
+<td{{if not .Up}} class="down"{{end}}>{{if .LastScrape.IsZero}}never{{else}}{{.LastScrape.Format "2006-01-02 15:04:05"}} ({{if .Up}}up{{else}}down{{end}}){{end}}{{if and (not .Up) (not .LastSuccess.IsZero)}}<br/>last successful: {{.LastSuccess.Format "2006-01-02 15:04:05"}}{{end}}</td>
+<td>{{.Series}}</td>
+<td>{{range .Collectors}}{{.}} {{end}}</td>
+<td>{{range .Errors}}{{.Time.Format "15:04:05"}} {{if .Collector}}[{{.Collector}}] {{end}}{{.Message}}<br/>{{end}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+)
+
+type targetStatus struct {
+	Host     string
+	Platform string
+	// Identity is the serial number (or host name if not available) used to detect targets listed multiple times
+	Identity    string
+	DuplicateOf string
+	Collectors  []string
+	Series      int
+	Up          bool
+	LastScrape  time.Time
+	LastSuccess time.Time
+	Errors      []statusError
+}
+
+type statusError struct {
+	Time      time.Time
+	Collector string
+	Message   string
+}
+
+type systemInformation struct {
+	SysInfo struct {
+		Model     string `xml:"hardware-model"`
+		OS        string `xml:"os-name"`
+		OSVersion string `xml:"os-version"`
+		Serial    string `xml:"serial-number"`
+		Hostname  string `xml:"host-name"`
+	} `xml:"system-information"`
+}
+
+// statusTracker keeps track of the last scrape of each target to be shown on the status page
+type statusTracker struct {
+	targets map[string]*targetStatus
+	mu      sync.Mutex
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{
+		targets: make(map[string]*targetStatus),
+	}
+}
+
+func (s *statusTracker) target(host string) *targetStatus {
+	t, found := s.targets[host]
+	if !found {
+		t = &targetStatus{Host: host}
+		s.targets[host] = t
+	}
+
+	return t
+}
+
+func (s *statusTracker) recordScrape(host string, up bool, series int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.target(host)
+	t.Up = up
+	t.Series = series
+	t.LastScrape = time.Now()
+
+	if up {
+		t.LastSuccess = t.LastScrape
+	}
+}
+
+// lastSuccess returns the time of the last scrape the target was reachable
+func (s *statusTracker) lastSuccess(host string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, found := s.targets[host]
+	if !found || t.LastSuccess.IsZero() {
+		return time.Time{}, false
+	}
+
+	return t.LastSuccess, true
+}
+
+func (s *statusTracker) recordError(host, collector string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.target(host)
+	t.Errors = append(t.Errors, statusError{
+		Time:      time.Now(),
+		Collector: collector,
+		Message:   err.Error(),
+	})
+
+	if len(t.Errors) > maxStatusErrors {
+		t.Errors = t.Errors[len(t.Errors)-maxStatusErrors:]
+	}
+}
+
+// detectPlatform retrieves the platform of a target once
+func (s *statusTracker) detectPlatform(host string, client *rpc.Client) {
+	s.mu.Lock()
+	known := s.target(host).Platform != ""
+	s.mu.Unlock()
+
+	if known {
+		return
+	}
+
+	var x systemInformation
+	err := client.RunCommandAndParse("show system information", &x)
+	if err != nil {
+		log.Debugf("Could not detect platform of %s: %v", host, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.target(host)
+	t.Platform = x.SysInfo.Model + " (" + x.SysInfo.OS + " " + x.SysInfo.OSVersion + ")"
+
+	t.Identity = x.SysInfo.Serial
+	if t.Identity == "" {
+		t.Identity = x.SysInfo.Hostname
+	}
+}
+
+// duplicateOf returns the device reporting the same identity as host. Of all devices sharing an identity the one with the lowest host is considered the original.
+func (s *statusTracker) duplicateOf(host string, devices []*connector.Device) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.target(host).Identity
+	if id == "" {
+		return ""
+	}
+
+	dup := ""
+	for _, d := range devices {
+		if d.Host >= host || (dup != "" && d.Host >= dup) {
+			continue
+		}
+
+		if t, found := s.targets[d.Host]; found && t.Identity == id {
+			dup = d.Host
+		}
+	}
+
+	return dup
+}
+
+func (s *statusTracker) snapshot(host string) targetStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := *s.target(host)
+	t.Errors = append([]statusError(nil), t.Errors...)
+
+	return t
+}
+
+func handleStatusRequest(w http.ResponseWriter, r *http.Request) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	tenant, code := tenantForRequest(r)
+	if code != http.StatusOK {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+
+	devs := devicesForTenant(tenant, devices)
+
+	cols := collectorsForDevices(devs, cfg, "", interfacelabels.NewDynamicLabels())
+
+	targets := make([]targetStatus, len(devs))
+	for i, d := range devs {
+		targets[i] = status.snapshot(d.Host)
+		targets[i].DuplicateOf = status.duplicateOf(d.Host, devices)
+
+		targets[i].Collectors = make([]string, 0)
+		for _, col := range cols.collectorsForDevice(d) {
+			targets[i].Collectors = append(targets[i].Collectors, col.Name())
+		}
+		sort.Strings(targets[i].Collectors)
+	}
+
+	err := statusTemplate.Execute(w, struct {
+		MetricsPath string
+		Targets     []targetStatus
+	}{
+		MetricsPath: *metricsPath,
+		Targets:     targets,
+	})
+	if err != nil {
+		log.Errorf("Could not render status page: %v", err)
+	}
+}
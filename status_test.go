@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusTracker(t *testing.T) {
+	s := newStatusTracker()
+
+	for i := 0; i < maxStatusErrors+5; i++ {
+		s.recordError("router1", "bgp", fmt.Errorf("error %d", i))
+	}
+	s.recordScrape("router1", true, 42)
+
+	st := s.snapshot("router1")
+	assert.True(t, st.Up, "up")
+	assert.Equal(t, 42, st.Series, "series")
+	assert.Len(t, st.Errors, maxStatusErrors, "errors")
+	assert.Equal(t, "error 5", st.Errors[0].Message, "oldest error")
+	assert.Equal(t, "bgp", st.Errors[0].Collector, "collector")
+
+	assert.True(t, s.snapshot("router2").LastScrape.IsZero(), "unknown target")
+}
+
+func TestStatusTrackerDuplicateOf(t *testing.T) {
+	s := newStatusTracker()
+	s.target("192.0.2.1").Identity = "JN1234"
+	s.target("router1").Identity = "JN1234"
+	s.target("router2").Identity = "JN5678"
+
+	devs := []*connector.Device{{Host: "router1"}, {Host: "192.0.2.1"}, {Host: "router2"}, {Host: "router3"}}
+
+	assert.Equal(t, "", s.duplicateOf("192.0.2.1", devs), "original")
+	assert.Equal(t, "192.0.2.1", s.duplicateOf("router1", devs), "duplicate")
+	assert.Equal(t, "", s.duplicateOf("router2", devs), "unique")
+	assert.Equal(t, "", s.duplicateOf("router3", devs), "unknown identity")
+	assert.Equal(t, "", s.duplicateOf("router1", devs[:1]), "original not configured anymore")
+}
+
+func TestStatusTrackerLastSuccess(t *testing.T) {
+	s := newStatusTracker()
+
+	_, found := s.lastSuccess("router1")
+	assert.False(t, found, "never scraped")
+
+	s.recordScrape("router1", false, 0)
+	_, found = s.lastSuccess("router1")
+	assert.False(t, found, "never successful")
+
+	s.recordScrape("router1", true, 42)
+	ts, found := s.lastSuccess("router1")
+	assert.True(t, found, "successful")
+
+	s.recordScrape("router1", false, 0)
+	ts2, found := s.lastSuccess("router1")
+	assert.True(t, found, "kept while down")
+	assert.Equal(t, ts, ts2, "time of successful scrape")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/syslogevents"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// syslogStore holds the event counters of all senders (kept over config reloads)
+var syslogStore = syslogevents.NewStore()
+
+func init() {
+	exporterRegistry.MustRegister(syslogevents.Messages)
+}
+
+func startSyslogListener(address string) error {
+	l, err := syslogevents.Listen(address, currentSyslogEvents, isSyslogTarget, syslogStore)
+	if err != nil {
+		return errors.Wrapf(err, "could not listen for syslog messages on %s", address)
+	}
+
+	log.Infof("Listening for syslog messages on %s", address)
+	go l.Run()
+
+	return nil
+}
+
+func currentSyslogEvents() []*config.SyslogEventConfig {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return cfg.SyslogEvents
+}
+
+// isSyslogTarget returns whether the sender of a syslog message is one of the devices (configured or discovered)
+func isSyslogTarget(name string) bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	for _, d := range devices {
+		if strings.EqualFold(d.Host, name) {
+			return true
+		}
+	}
+
+	return false
+}
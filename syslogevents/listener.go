@@ -0,0 +1,101 @@
+package syslogevents
+
+import (
+	"net"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxMessageSize = 8192
+
+// Messages counts the received messages by result (matched, unmatched, invalid, unknown_sender)
+var Messages = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "junos_exporter_syslog_messages_total",
+	Help: "Number of syslog messages received by result (matched, unmatched, invalid, unknown_sender)",
+}, []string{"result"})
+
+// EventsFunc returns the currently configured events
+type EventsFunc func() []*config.SyslogEventConfig
+
+// TargetFunc returns whether the name (IP address or host name) is a target of the exporter
+type TargetFunc func(name string) bool
+
+// Listener receives syslog messages via UDP and counts the configured events
+type Listener struct {
+	conn    net.PacketConn
+	events  EventsFunc
+	targets TargetFunc
+	store   *Store
+}
+
+// Listen creates a new listener on the address (e.g. :5514). Only messages of senders known as target are counted.
+func Listen(address string, events EventsFunc, targets TargetFunc, store *Store) (*Listener, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Listener{conn: conn, events: events, targets: targets, store: store}, nil
+}
+
+// Run receives messages until the listener is closed
+func (l *Listener) Run() {
+	buf := make([]byte, maxMessageSize)
+
+	for {
+		n, addr, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			log.Debugf("Syslog listener stopped: %v", err)
+			return
+		}
+
+		l.handle(buf[:n], addr)
+	}
+}
+
+// Close stops the listener
+func (l *Listener) Close() error {
+	return l.conn.Close()
+}
+
+func (l *Listener) handle(b []byte, addr net.Addr) {
+	m, err := Parse(b)
+	if err != nil {
+		Messages.WithLabelValues("invalid").Inc()
+		return
+	}
+
+	sender := addr.String()
+	if host, _, err := net.SplitHostPort(sender); err == nil {
+		sender = host
+	}
+
+	// anyone able to reach the listener can send messages, counters are only kept for targets
+	senders := make([]string, 0, 2)
+	for _, s := range []string{sender, m.Hostname} {
+		if s != "" && l.targets(s) {
+			senders = append(senders, s)
+		}
+	}
+
+	if len(senders) == 0 {
+		Messages.WithLabelValues("unknown_sender").Inc()
+		return
+	}
+
+	matched := false
+	for _, e := range l.events() {
+		if e.Matches(m.Text) {
+			l.store.Inc(e.Name, senders...)
+			matched = true
+		}
+	}
+
+	if matched {
+		Messages.WithLabelValues("matched").Inc()
+	} else {
+		Messages.WithLabelValues("unmatched").Inc()
+	}
+}
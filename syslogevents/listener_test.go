@@ -0,0 +1,44 @@
+package syslogevents
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleUnknownSender(t *testing.T) {
+	c, err := config.Load(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStore()
+	l := &Listener{
+		events: func() []*config.SyslogEventConfig {
+			return c.SyslogEvents
+		},
+		targets: func(name string) bool {
+			return name == "192.168.1.1" || name == "router1"
+		},
+		store: s,
+	}
+
+	msg := func(host string) []byte {
+		return []byte("<28>Mar  3 10:15:01 " + host + " mib2d[1234]: SNMP_TRAP_LINK_DOWN: ifIndex 512, ifName xe-0/0/1")
+	}
+
+	l.handle(msg("router1"), &net.UDPAddr{IP: net.ParseIP("192.168.1.1"), Port: 514})
+	l.handle(msg("router1"), &net.UDPAddr{IP: net.ParseIP("192.168.1.2"), Port: 514})
+	l.handle(msg("spoofed"), &net.UDPAddr{IP: net.ParseIP("192.168.1.1"), Port: 514})
+	l.handle(msg("other"), &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 514})
+
+	assert.Equal(t, map[string]float64{"link_down": 2}, collect(s, "192.168.1.1"))
+	assert.Equal(t, map[string]float64{"link_down": 2}, collect(s, "router1"))
+	assert.Empty(t, collect(s, "192.168.1.2"), "sender not a target")
+	assert.Empty(t, collect(s, "spoofed"), "host name not a target")
+	assert.Empty(t, s.counts["10.0.0.1"], "messages of unknown senders are dropped")
+	assert.Empty(t, s.counts["other"], "messages of unknown senders are dropped")
+}
@@ -0,0 +1,75 @@
+package syslogevents
+
+import (
+	"errors"
+	"strings"
+)
+
+// Message is a received syslog message
+type Message struct {
+	Hostname string
+	// Text is the message following the priority (including header fields like the tag or MSGID of RFC 5424)
+	Text string
+}
+
+// Parse parses a message in the format of RFC 3164 or RFC 5424
+func Parse(b []byte) (*Message, error) {
+	s := strings.TrimRight(string(b), "\r\n\x00")
+	if !strings.HasPrefix(s, "<") {
+		return nil, errors.New("missing priority")
+	}
+
+	end := strings.Index(s, ">")
+	if end < 2 || end > 4 {
+		return nil, errors.New("invalid priority")
+	}
+	s = s[end+1:]
+
+	if strings.HasPrefix(s, "1 ") {
+		return parseRFC5424(s[2:]), nil
+	}
+
+	return parseRFC3164(s), nil
+}
+
+// parseRFC5424 parses TIMESTAMP HOSTNAME APP-NAME PROCID MSGID ...
+func parseRFC5424(s string) *Message {
+	fields := strings.SplitN(s, " ", 3)
+	m := &Message{Text: s}
+	if len(fields) >= 2 && fields[1] != "-" {
+		m.Hostname = fields[1]
+	}
+
+	return m
+}
+
+// parseRFC3164 parses Mmm dd hh:mm:ss HOSTNAME TAG: MSG (Junos may prefix the timestamp with the year)
+func parseRFC3164(s string) *Message {
+	m := &Message{Text: s}
+
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return m
+	}
+
+	i := 3
+	if len(fields[0]) == 4 && isDigits(fields[0]) {
+		i = 4
+	}
+
+	if i < len(fields) && strings.Count(fields[i-1], ":") == 2 {
+		m.Hostname = fields[i]
+	}
+
+	return m
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+package syslogevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		hostname string
+		text     string
+		wantErr  bool
+	}{
+		{
+			name:     "RFC 3164",
+			msg:      "<28>Mar  3 10:15:01 router1 mib2d[1234]: SNMP_TRAP_LINK_DOWN: ifIndex 512, ifAdminStatus up(1), ifOperStatus down(2), ifName xe-0/0/1\n",
+			hostname: "router1",
+			text:     "Mar  3 10:15:01 router1 mib2d[1234]: SNMP_TRAP_LINK_DOWN: ifIndex 512, ifAdminStatus up(1), ifOperStatus down(2), ifName xe-0/0/1",
+		},
+		{
+			name:     "RFC 3164 with year",
+			msg:      "<28>2024 Mar  3 10:15:01 router1 chassisd[1234]: CHASSISD_FRU_OFFLINE_NOTICE: Taking FPC 1 offline",
+			hostname: "router1",
+			text:     "2024 Mar  3 10:15:01 router1 chassisd[1234]: CHASSISD_FRU_OFFLINE_NOTICE: Taking FPC 1 offline",
+		},
+		{
+			name:     "RFC 5424",
+			msg:      "<28>1 2024-03-03T10:15:01.123Z router1.example.com mib2d 1234 SNMP_TRAP_LINK_UP [junos@2636.1.1.1.2.21 snmp-interface-index=\"512\"] ifName xe-0/0/1",
+			hostname: "router1.example.com",
+			text:     "2024-03-03T10:15:01.123Z router1.example.com mib2d 1234 SNMP_TRAP_LINK_UP [junos@2636.1.1.1.2.21 snmp-interface-index=\"512\"] ifName xe-0/0/1",
+		},
+		{
+			name: "RFC 5424 without hostname",
+			msg:  "<28>1 2024-03-03T10:15:01.123Z - mib2d 1234 SNMP_TRAP_LINK_UP - ifName xe-0/0/1",
+			text: "2024-03-03T10:15:01.123Z - mib2d 1234 SNMP_TRAP_LINK_UP - ifName xe-0/0/1",
+		},
+		{
+			name:    "missing priority",
+			msg:     "Mar  3 10:15:01 router1 mib2d[1234]: test",
+			wantErr: true,
+		},
+		{
+			name:    "invalid priority",
+			msg:     "<28 Mar  3 10:15:01 router1 mib2d[1234]: test",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := Parse([]byte(test.msg))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.hostname, m.Hostname)
+			assert.Equal(t, test.text, m.Text)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("<28>Mar  3 10:15:01 router1 mib2d[1234]: SNMP_TRAP_LINK_DOWN: ifIndex 512"))
+	f.Add([]byte("<28>2024 Mar  3 10:15:01 router1 chassisd[1234]: CHASSISD_FRU_OFFLINE_NOTICE: Taking FPC 1 offline"))
+	f.Add([]byte("<28>1 2024-03-03T10:15:01.123Z router1 mib2d 1234 SNMP_TRAP_LINK_UP - ifName xe-0/0/1"))
+	f.Add([]byte("<>"))
+	f.Add([]byte("<2"))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m, err := Parse(b)
+		if err != nil {
+			return
+		}
+
+		assert.NotContains(t, m.Hostname, " ")
+	})
+}
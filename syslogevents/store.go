@@ -0,0 +1,59 @@
+package syslogevents
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var eventsDesc *prometheus.Desc
+
+func init() {
+	eventsDesc = prometheus.NewDesc("junos_syslog_events_total", "Number of syslog messages received from the target by event type", []string{"target", "event"}, nil)
+}
+
+// Store counts the events per sender
+type Store struct {
+	counts map[string]map[string]uint64
+	mu     sync.RWMutex
+}
+
+// NewStore creates a new store
+func NewStore() *Store {
+	return &Store{
+		counts: make(map[string]map[string]uint64),
+	}
+}
+
+// Inc increments the counter of the event for all names the sender is known by (e.g. IP address and host name)
+func (s *Store) Inc(event string, senders ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, sender := range senders {
+		sender = strings.ToLower(sender)
+		if sender == "" || seen[sender] {
+			continue
+		}
+		seen[sender] = true
+
+		c, found := s.counts[sender]
+		if !found {
+			c = make(map[string]uint64)
+			s.counts[sender] = c
+		}
+		c[event]++
+	}
+}
+
+// Collect sends the counters of the target to the channel
+func (s *Store) Collect(host string, ch chan<- prometheus.Metric, labelValues []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for event, count := range s.counts[strings.ToLower(host)] {
+		ch <- prometheus.MustNewConstMetric(eventsDesc, prometheus.CounterValue, float64(count), append(append([]string{}, labelValues...), event)...)
+	}
+}
@@ -0,0 +1,41 @@
+package syslogevents
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore()
+	s.Inc("link_down", "192.168.1.1", "Router1")
+	s.Inc("link_down", "192.168.1.1", "router1")
+	s.Inc("link_up", "192.168.1.1", "")
+	s.Inc("link_up", "192.168.1.1", "192.168.1.1")
+
+	assert.Equal(t, map[string]float64{"link_down": 2, "link_up": 2}, collect(s, "192.168.1.1"))
+	assert.Equal(t, map[string]float64{"link_down": 2}, collect(s, "ROUTER1"))
+	assert.Empty(t, collect(s, "router2"))
+}
+
+func collect(s *Store, host string) map[string]float64 {
+	ch := make(chan prometheus.Metric, 10)
+	s.Collect(host, ch, []string{host})
+	close(ch)
+
+	res := make(map[string]float64)
+	for m := range ch {
+		pb := &dto.Metric{}
+		m.Write(pb)
+
+		for _, l := range pb.Label {
+			if l.GetName() == "event" {
+				res[l.GetValue()] = pb.Counter.GetValue()
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,60 @@
+package systemd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Ready tells the service manager the service finished starting up
+	Ready = "READY=1"
+
+	// Reloading tells the service manager the service is reloading its configuration
+	Reloading = "RELOADING=1"
+
+	// Stopping tells the service manager the service is shutting down
+	Stopping = "STOPPING=1"
+
+	// Watchdog keeps the service alive if the watchdog is enabled
+	Watchdog = "WATCHDOG=1"
+)
+
+// Notify sends a state to the service manager (sd_notify). Returns false if the service was not started by systemd (no NOTIFY_SOCKET set)
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+
+	addr := &net.UnixAddr{Name: socket, Net: "unixgram"}
+	conn, err := net.DialUnix(addr.Net, nil, addr)
+	if err != nil {
+		return false, errors.Wrap(err, "could not connect to notify socket")
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	if err != nil {
+		return false, errors.Wrap(err, "could not send state")
+	}
+
+	return true, nil
+}
+
+// WatchdogInterval returns the interval the service manager expects watchdog notifications in (0 if the watchdog is disabled)
+func WatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond
+}
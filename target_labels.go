@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// targetLabels returns the labels added to all metrics of a device (labels configured for the device and target_name)
+func targetLabels(device *connector.Device) prometheus.Labels {
+	labels := prometheus.Labels{}
+	for k, v := range cfg.LabelsForDevice(device.Host) {
+		labels[k] = v
+	}
+
+	if *reverseDNSEnabled {
+		labels["target_name"] = reverseNames.name(device.Host)
+	}
+
+	return labels
+}
+
+// registerWithTargetLabels registers one collector per device adding the target labels to all metrics of the device
+func registerWithTargetLabels(reg prometheus.Registerer, devs []*connector.Device, collectorForDevices func([]*connector.Device) prometheus.Collector) {
+	for _, d := range devs {
+		r := prometheus.WrapRegistererWith(targetLabels(d), reg)
+		r.MustRegister(collectorForDevices([]*connector.Device{d}))
+	}
+}
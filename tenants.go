@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+)
+
+// tenantForRequest identifies the tenant by the bearer token of the request. If no tenants are configured, nil is returned
+func tenantForRequest(r *http.Request) (*config.TenantConfig, int) {
+	if len(cfg.Tenants) == 0 {
+		return nil, http.StatusOK
+	}
+
+	token, found := bearerToken(r)
+	if !found {
+		return nil, http.StatusUnauthorized
+	}
+
+	t := cfg.TenantForToken(token)
+	if t == nil {
+		return nil, http.StatusUnauthorized
+	}
+
+	return t, http.StatusOK
+}
+
+// bearerToken returns the token of the Authorization header. Tokens without the Bearer scheme are rejected.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+// devicesForTenant limits the devices to those the tenant is allowed to scrape
+func devicesForTenant(t *config.TenantConfig, devs []*connector.Device) []*connector.Device {
+	if t == nil {
+		return devs
+	}
+
+	allowed := make([]*connector.Device, 0, len(devs))
+	for _, d := range devs {
+		if t.Allowed(d.Host) {
+			allowed = append(allowed, d)
+		}
+	}
+
+	return allowed
+}
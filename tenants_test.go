@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		token  string
+		found  bool
+	}{
+		"bearer":           {header: "Bearer secret", token: "secret", found: true},
+		"case insensitive": {header: "bearer secret", token: "secret", found: true},
+		"raw token":        {header: "secret"},
+		"other scheme":     {header: "Basic secret"},
+		"empty token":      {header: "Bearer "},
+		"missing":          {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/metrics", nil)
+			if test.header != "" {
+				r.Header.Set("Authorization", test.header)
+			}
+
+			token, found := bearerToken(r)
+			assert.Equal(t, test.token, token)
+			assert.Equal(t, test.found, found)
+		})
+	}
+}
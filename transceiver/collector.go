@@ -0,0 +1,130 @@
+package transceiver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_transceiver_"
+
+var (
+	presentDesc *prometheus.Desc
+	infoDesc    *prometheus.Desc
+
+	// ports of interfaces which may use pluggable optics (channelized interfaces share the port)
+	portInterfaceRe = regexp.MustCompile(`^(?:ge|xe|et|xle|fte)-(\d+)/(\d+)/(\d+)(?::\d+)?$`)
+)
+
+func init() {
+	l := []string{"target", "fpc", "pic", "port"}
+	presentDesc = prometheus.NewDesc(prefix+"present", "Transceiver is present in the port (1 = present)", append(l, "name"), nil)
+	infoDesc = prometheus.NewDesc(prefix+"info", "Information about the transceiver installed in the port", append(l, "vendor", "part_number", "wavelength", "cable_type", "fiber_mode"), nil)
+}
+
+type transceiverCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &transceiverCollector{}
+}
+
+// Name returns the name of the collector
+func (*transceiverCollector) Name() string {
+	return "Transceiver"
+}
+
+// Describe describes the metrics
+func (*transceiverCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- presentDesc
+	ch <- infoDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *transceiverCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	ports, err := c.installedPorts(client)
+	if err != nil {
+		return err
+	}
+
+	for key, p := range ports {
+		l := append(labelValues, strings.Split(key, "/")...)
+		ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, append(l, p.Vendor, p.PartNumber, p.Wavelength, p.CableType, p.FiberMode)...)
+	}
+
+	var x interfaceTerseRpc
+	err = client.RunCommandAndParse("show interfaces terse", &x)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, ifd := range x.Interfaces {
+		name := strings.TrimSpace(ifd.Name)
+		m := portInterfaceRe.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+
+		key := strings.Join(m[1:4], "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		present := 0.0
+		if _, found := ports[key]; found {
+			present = 1
+		}
+
+		l := append(labelValues, m[1], m[2], m[3], strings.SplitN(name, ":", 2)[0])
+		ch <- prometheus.MustNewConstMetric(presentDesc, prometheus.GaugeValue, present, l...)
+	}
+
+	return nil
+}
+
+// installedPorts returns the ports with transceivers installed by fpc/pic/port
+func (c *transceiverCollector) installedPorts(client *rpc.Client) (map[string]*port, error) {
+	var status picStatusRpc
+	err := client.RunCommandAndParse("show chassis fpc pic-status", &status)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make(map[string]*port)
+	queried := make(map[string]bool)
+	for _, fpc := range append(status.FPCs, status.MultiREFPCs...) {
+		for _, pic := range fpc.PICs {
+			if pic.State != "Online" {
+				continue
+			}
+
+			key := fpc.Slot + "/" + pic.Slot
+			if queried[key] {
+				continue
+			}
+			queried[key] = true
+
+			var detail picDetailRpc
+			err = client.RunCommandAndParse(fmt.Sprintf("show chassis pic fpc-slot %s pic-slot %s", fpc.Slot, pic.Slot), &detail)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range append(detail.PICs, detail.MultiREPICs...) {
+				for i := range d.Ports {
+					p := &d.Ports[i]
+					ports[key+"/"+p.Number] = p
+				}
+			}
+		}
+	}
+
+	return ports, nil
+}
@@ -0,0 +1,69 @@
+package transceiver
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePicDetail(t *testing.T) {
+	body := `<rpc-reply xmlns:junos="http://xml.juniper.net/junos/18.2R3/junos">
+    <fpc-information xmlns="http://xml.juniper.net/junos/18.2R3/junos-chassis" junos:style="pic-detail">
+        <fpc>
+            <slot>0</slot>
+            <pic-detail>
+                <slot>0</slot>
+                <pic-slot>1</pic-slot>
+                <pic-type>4x 10GE(LAN) SFP+</pic-type>
+                <state>Online</state>
+                <port-information>
+                    <port>
+                        <port-number>0</port-number>
+                        <cable-type>10GBASE LR</cable-type>
+                        <fiber-mode>SM</fiber-mode>
+                        <sfp-vendor-name>FINISAR CORP.</sfp-vendor-name>
+                        <sfp-vendor-pno>FTLX1471D3BCL-J1</sfp-vendor-pno>
+                        <wavelength>1310 nm</wavelength>
+                        <sfp-vendor-fw-ver>0.0</sfp-vendor-fw-ver>
+                    </port>
+                    <port>
+                        <port-number>2</port-number>
+                        <cable-type>10GBASE SR</cable-type>
+                        <fiber-mode>MM</fiber-mode>
+                        <sfp-vendor-name>JUNIPER-AVAGO</sfp-vendor-name>
+                        <sfp-vendor-pno>AFBR-709SMZ-JU1</sfp-vendor-pno>
+                        <wavelength>850 nm</wavelength>
+                    </port>
+                </port-information>
+            </pic-detail>
+        </fpc>
+    </fpc-information>
+</rpc-reply>`
+
+	var x picDetailRpc
+	err := xml.Unmarshal([]byte(body), &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, x.PICs, 1)
+	ports := x.PICs[0].Ports
+	assert.Len(t, ports, 2)
+	assert.Equal(t, port{
+		Number:     "0",
+		CableType:  "10GBASE LR",
+		FiberMode:  "SM",
+		Vendor:     "FINISAR CORP.",
+		PartNumber: "FTLX1471D3BCL-J1",
+		Wavelength: "1310 nm",
+	}, ports[0])
+	assert.Equal(t, "2", ports[1].Number)
+}
+
+func TestPortInterfaceRegex(t *testing.T) {
+	assert.Equal(t, []string{"et-1/0/4:2", "1", "0", "4"}, portInterfaceRe.FindStringSubmatch("et-1/0/4:2"))
+	assert.Equal(t, []string{"xe-0/1/3", "0", "1", "3"}, portInterfaceRe.FindStringSubmatch("xe-0/1/3"))
+	assert.Nil(t, portInterfaceRe.FindStringSubmatch("xe-0/1/3.0"))
+	assert.Nil(t, portInterfaceRe.FindStringSubmatch("lo0"))
+}
@@ -0,0 +1,38 @@
+package transceiver
+
+type picStatusRpc struct {
+	FPCs        []picStatusFPC `xml:"fpc-information>fpc"`
+	MultiREFPCs []picStatusFPC `xml:"multi-routing-engine-results>multi-routing-engine-item>fpc-information>fpc"`
+}
+
+type picStatusFPC struct {
+	Slot string `xml:"slot"`
+	PICs []struct {
+		Slot  string `xml:"pic-slot"`
+		State string `xml:"pic-state"`
+	} `xml:"pic"`
+}
+
+type picDetailRpc struct {
+	PICs        []picDetail `xml:"fpc-information>fpc>pic-detail"`
+	MultiREPICs []picDetail `xml:"multi-routing-engine-results>multi-routing-engine-item>fpc-information>fpc>pic-detail"`
+}
+
+type picDetail struct {
+	Ports []port `xml:"port-information>port"`
+}
+
+type port struct {
+	Number     string `xml:"port-number"`
+	CableType  string `xml:"cable-type"`
+	FiberMode  string `xml:"fiber-mode"`
+	Vendor     string `xml:"sfp-vendor-name"`
+	PartNumber string `xml:"sfp-vendor-pno"`
+	Wavelength string `xml:"wavelength"`
+}
+
+type interfaceTerseRpc struct {
+	Interfaces []struct {
+		Name string `xml:"name"`
+	} `xml:"interface-information>physical-interface"`
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// withTransformations applies the configured transformations to the values of the gathered metrics.
+// Samples dropped by an enum mapping are removed, as well as metric families without remaining samples.
+func withTransformations(g prometheus.Gatherer) prometheus.Gatherer {
+	c := cfg
+	if len(c.Transformations) == 0 {
+		return g
+	}
+
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := g.Gather()
+		res := mfs[:0]
+		for _, mf := range mfs {
+			t := c.TransformationFor(mf.GetName())
+			if t == nil {
+				res = append(res, mf)
+				continue
+			}
+
+			metrics := mf.Metric[:0]
+			for _, m := range mf.Metric {
+				if transformMetric(m, t.Apply) {
+					metrics = append(metrics, m)
+				}
+			}
+			mf.Metric = metrics
+
+			// families without metrics are invalid in the exposition format
+			if len(metrics) > 0 {
+				res = append(res, mf)
+			}
+		}
+
+		return res, err
+	})
+}
+
+// transformMetric transforms the value of a gauge, counter or untyped metric (other types are not modified)
+func transformMetric(m *dto.Metric, apply func(float64) (float64, bool)) bool {
+	var v *float64
+	switch {
+	case m.Gauge != nil:
+		v = m.Gauge.Value
+	case m.Counter != nil:
+		v = m.Counter.Value
+	case m.Untyped != nil:
+		v = m.Untyped.Value
+	default:
+		return true
+	}
+
+	res, ok := apply(*v)
+	if !ok {
+		return false
+	}
+
+	switch {
+	case m.Gauge != nil:
+		m.Gauge.Value = proto.Float64(res)
+	case m.Counter != nil:
+		m.Counter.Value = proto.Float64(res)
+	default:
+		m.Untyped.Value = proto.Float64(res)
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTransformations(t *testing.T) {
+	old := cfg
+	defer func() { cfg = old }()
+
+	c, err := config.Load(bytes.NewReader([]byte(`transformations:
+  - metric: junos_test_rate
+    conversion: bits_to_bytes
+  - metric: junos_test_temp.*
+    scale: 0.1
+    offset: -1
+  - metric: junos_test_state
+    enum:
+      "1": 1
+      "2": 0
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = c
+
+	reg := prometheus.NewRegistry()
+	rate := prometheus.NewGauge(prometheus.GaugeOpts{Name: "junos_test_rate"})
+	rate.Set(800)
+	temp := prometheus.NewCounter(prometheus.CounterOpts{Name: "junos_test_temperature"})
+	temp.Add(420)
+	state := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "junos_test_state"}, []string{"name"})
+	state.WithLabelValues("a").Set(1)
+	state.WithLabelValues("b").Set(2)
+	state.WithLabelValues("c").Set(3)
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "junos_test_other"})
+	other.Set(5)
+	reg.MustRegister(rate, temp, state, other)
+
+	mfs, err := withTransformations(reg).Gather()
+	assert.NoError(t, err)
+
+	values := make(map[string][]float64)
+	for _, mf := range mfs {
+		for _, m := range mf.Metric {
+			switch {
+			case m.Gauge != nil:
+				values[mf.GetName()] = append(values[mf.GetName()], m.Gauge.GetValue())
+			case m.Counter != nil:
+				values[mf.GetName()] = append(values[mf.GetName()], m.Counter.GetValue())
+			}
+		}
+	}
+
+	assert.Equal(t, map[string][]float64{
+		"junos_test_rate":        {100},
+		"junos_test_temperature": {41},
+		"junos_test_state":       {1, 0},
+		"junos_test_other":       {5},
+	}, values)
+}
+
+func TestWithTransformationsDropsEmptyFamilies(t *testing.T) {
+	old := cfg
+	defer func() { cfg = old }()
+
+	c, err := config.Load(bytes.NewReader([]byte(`transformations:
+  - metric: junos_test_state
+    enum:
+      "1": 1
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = c
+
+	reg := prometheus.NewRegistry()
+	state := prometheus.NewGauge(prometheus.GaugeOpts{Name: "junos_test_state"})
+	state.Set(3)
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "junos_test_other"})
+	reg.MustRegister(state, other)
+
+	mfs, err := withTransformations(reg).Gather()
+	assert.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, mf := range mfs {
+		names = append(names, mf.GetName())
+	}
+	assert.Equal(t, []string{"junos_test_other"}, names, "family without remaining samples is removed")
+}
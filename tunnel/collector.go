@@ -0,0 +1,112 @@
+package tunnel
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_tunnel_"
+
+var (
+	upDesc               *prometheus.Desc
+	encapPacketsDesc     *prometheus.Desc
+	encapBytesDesc       *prometheus.Desc
+	decapPacketsDesc     *prometheus.Desc
+	decapBytesDesc       *prometheus.Desc
+	keepaliveEnabledDesc *prometheus.Desc
+	keepaliveUpDesc      *prometheus.Desc
+
+	// tunnel interface types: GRE, IP-IP and logical tunnels
+	tunnelTypes = []string{"gr", "ip", "lt"}
+)
+
+func init() {
+	l := []string{"target", "name", "description", "type"}
+	upDesc = prometheus.NewDesc(prefix+"up", "Tunnel interface is up (1 = up)", l, nil)
+	encapPacketsDesc = prometheus.NewDesc(prefix+"encapsulated_packets_total", "Number of packets encapsulated (sent into the tunnel)", l, nil)
+	encapBytesDesc = prometheus.NewDesc(prefix+"encapsulated_bytes_total", "Number of bytes encapsulated (sent into the tunnel)", l, nil)
+	decapPacketsDesc = prometheus.NewDesc(prefix+"decapsulated_packets_total", "Number of packets decapsulated (received from the tunnel)", l, nil)
+	decapBytesDesc = prometheus.NewDesc(prefix+"decapsulated_bytes_total", "Number of bytes decapsulated (received from the tunnel)", l, nil)
+	keepaliveEnabledDesc = prometheus.NewDesc(prefix+"keepalive_enabled", "GRE keepalives are configured (1 = configured)", l, nil)
+	keepaliveUpDesc = prometheus.NewDesc(prefix+"keepalive_up", "GRE keepalive adjacency state (1 = up)", l, nil)
+}
+
+type tunnelCollector struct {
+}
+
+// NewCollector creates a new collector
+func NewCollector() collector.RPCCollector {
+	return &tunnelCollector{}
+}
+
+// Name returns the name of the collector
+func (*tunnelCollector) Name() string {
+	return "Tunnel"
+}
+
+// Describe describes the metrics
+func (*tunnelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- upDesc
+	ch <- encapPacketsDesc
+	ch <- encapBytesDesc
+	ch <- decapPacketsDesc
+	ch <- decapBytesDesc
+	ch <- keepaliveEnabledDesc
+	ch <- keepaliveUpDesc
+}
+
+// Collect collects metrics from JunOS
+func (c *tunnelCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	for _, t := range tunnelTypes {
+		var x interfaceRpc
+		err := client.RunCommandAndParse("show interfaces "+t+"-* extensive", &x)
+		if err != nil {
+			return err
+		}
+
+		for _, phy := range x.Interfaces {
+			for _, ifl := range phy.LogicalInterfaces {
+				c.collectForInterface(ch, labelValues, t, &phy, &ifl)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *tunnelCollector) collectForInterface(ch chan<- prometheus.Metric, labelValues []string, tunnelType string, phy *physicalInterface, ifl *logicalInterface) {
+	l := append(labelValues, strings.TrimSpace(ifl.Name), strings.TrimSpace(ifl.Description), tunnelType)
+
+	up := 0.0
+	if strings.TrimSpace(phy.OperStatus) == "up" && ifl.Flags.Down == nil {
+		up = 1
+	}
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, l...)
+
+	ch <- prometheus.MustNewConstMetric(encapPacketsDesc, prometheus.CounterValue, float64(ifl.Stats.OutputPackets), l...)
+	ch <- prometheus.MustNewConstMetric(encapBytesDesc, prometheus.CounterValue, float64(ifl.Stats.OutputBytes), l...)
+	ch <- prometheus.MustNewConstMetric(decapPacketsDesc, prometheus.CounterValue, float64(ifl.Stats.InputPackets), l...)
+	ch <- prometheus.MustNewConstMetric(decapBytesDesc, prometheus.CounterValue, float64(ifl.Stats.InputBytes), l...)
+
+	if tunnelType != "gr" {
+		return
+	}
+
+	enabled := strings.EqualFold(strings.TrimSpace(ifl.KeepalivesConfigured), "on")
+	ch <- prometheus.MustNewConstMetric(keepaliveEnabledDesc, prometheus.GaugeValue, boolToFloat(enabled), l...)
+
+	if enabled {
+		ch <- prometheus.MustNewConstMetric(keepaliveUpDesc, prometheus.GaugeValue, boolToFloat(strings.EqualFold(strings.TrimSpace(ifl.KeepalivesState), "up")), l...)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,28 @@
+package tunnel
+
+type interfaceRpc struct {
+	Interfaces []physicalInterface `xml:"interface-information>physical-interface"`
+}
+
+type physicalInterface struct {
+	Name              string             `xml:"name"`
+	OperStatus        string             `xml:"oper-status"`
+	LogicalInterfaces []logicalInterface `xml:"logical-interface"`
+}
+
+type logicalInterface struct {
+	Name        string `xml:"name"`
+	Description string `xml:"description"`
+	Flags       struct {
+		Up   *struct{} `xml:"iff-up"`
+		Down *struct{} `xml:"iff-down"`
+	} `xml:"if-config-flags"`
+	Stats struct {
+		InputBytes    uint64 `xml:"input-bytes"`
+		InputPackets  uint64 `xml:"input-packets"`
+		OutputBytes   uint64 `xml:"output-bytes"`
+		OutputPackets uint64 `xml:"output-packets"`
+	} `xml:"traffic-statistics"`
+	KeepalivesConfigured string `xml:"gre-keepalives-configured"`
+	KeepalivesState      string `xml:"gre-keepalives-adjacency-state"`
+}
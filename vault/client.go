@@ -0,0 +1,202 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/czerwonk/junos_exporter/connector"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const hostPlaceholder = "{host}"
+
+// Client retrieves device credentials from the KV secrets engine (version 2) of HashiCorp Vault
+type Client struct {
+	address    string
+	token      string
+	mount      string
+	path       string
+	cacheTTL   time.Duration
+	httpClient *http.Client
+	cache      map[string]*cacheEntry
+	mu         sync.Mutex
+	done       chan struct{}
+}
+
+type cacheEntry struct {
+	credentials *connector.Credentials
+	expires     time.Time
+}
+
+type secretResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type tokenResponse struct {
+	Data struct {
+		TTL       int64 `json:"ttl"`
+		Renewable bool  `json:"renewable"`
+	} `json:"data"`
+}
+
+// NewClient creates a new client and starts renewing the token in background if it is renewable
+func NewClient(cfg *config.VaultConfig) *Client {
+	c := &Client{
+		address:    strings.TrimRight(cfg.Address, "/"),
+		token:      cfg.Token,
+		mount:      strings.Trim(cfg.Mount, "/"),
+		path:       strings.Trim(cfg.Path, "/"),
+		cacheTTL:   cfg.CacheTTL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]*cacheEntry),
+		done:       make(chan struct{}),
+	}
+
+	go c.renewToken()
+
+	return c
+}
+
+// PathForHost returns the secret path for a host (device specific path takes precedence over global path)
+func (c *Client) PathForHost(host, devicePath string) string {
+	if devicePath != "" {
+		return strings.Trim(devicePath, "/")
+	}
+
+	return strings.Replace(c.path, hostPlaceholder, host, -1)
+}
+
+// CredentialsProvider returns a provider retrieving the credentials stored at path
+func (c *Client) CredentialsProvider(path, defaultUsername string) connector.CredentialsProvider {
+	return func() (*connector.Credentials, error) {
+		creds, err := c.Credentials(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if creds.Username == "" {
+			creds.Username = defaultUsername
+		}
+
+		return creds, nil
+	}
+}
+
+// Credentials retrieves the credentials stored at path. Results are cached for the configured TTL.
+func (c *Client) Credentials(path string) (*connector.Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, found := c.cache[path]; found && time.Now().Before(e.expires) {
+		creds := *e.credentials
+		return &creds, nil
+	}
+
+	creds, err := c.readSecret(path)
+	if err != nil {
+		if e, found := c.cache[path]; found {
+			log.Warnf("Could not refresh credentials from vault (path: %s), using cached credentials: %v", path, err)
+			creds := *e.credentials
+			return &creds, nil
+		}
+
+		return nil, err
+	}
+
+	c.cache[path] = &cacheEntry{
+		credentials: creds,
+		expires:     time.Now().Add(c.cacheTTL),
+	}
+
+	res := *creds
+	return &res, nil
+}
+
+func (c *Client) readSecret(path string) (*connector.Credentials, error) {
+	var res secretResponse
+	err := c.request(http.MethodGet, fmt.Sprintf("/v1/%s/data/%s", c.mount, path), &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read secret %s from vault", path)
+	}
+
+	d := res.Data.Data
+	creds := &connector.Credentials{
+		Username: d["username"],
+		Password: d["password"],
+	}
+
+	if d["private_key"] != "" {
+		creds.PrivateKey = []byte(d["private_key"])
+	}
+
+	if creds.Password == "" && creds.PrivateKey == nil {
+		return nil, errors.Errorf("secret %s contains neither password nor private_key", path)
+	}
+
+	return creds, nil
+}
+
+func (c *Client) renewToken() {
+	var res tokenResponse
+	err := c.request(http.MethodGet, "/v1/auth/token/lookup-self", &res)
+	if err != nil {
+		log.Errorf("Could not lookup vault token: %v", err)
+		return
+	}
+
+	for res.Data.Renewable && res.Data.TTL > 0 {
+		select {
+		case <-time.After(time.Duration(res.Data.TTL) * time.Second / 2):
+		case <-c.done:
+			return
+		}
+
+		err = c.request(http.MethodPost, "/v1/auth/token/renew-self", nil)
+		if err != nil {
+			log.Errorf("Could not renew vault token: %v", err)
+		}
+
+		err = c.request(http.MethodGet, "/v1/auth/token/lookup-self", &res)
+		if err != nil {
+			log.Errorf("Could not lookup vault token: %v", err)
+			return
+		}
+	}
+}
+
+func (c *Client) request(method, path string, v interface{}) error {
+	req, err := http.NewRequest(method, c.address+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Close stops the token renewal
+func (c *Client) Close() {
+	close(c.done)
+}
@@ -0,0 +1,59 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/czerwonk/junos_exporter/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentials(t *testing.T) {
+	reads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/auth/token/lookup-self":
+			fmt.Fprint(w, `{"data": {"ttl": 0, "renewable": false}}`)
+		case "/v1/kv/data/junos/router1":
+			reads++
+			fmt.Fprint(w, `{"data": {"data": {"password": "secret"}, "metadata": {"version": 1}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(&config.VaultConfig{
+		Address:  srv.URL,
+		Token:    "s.token",
+		Mount:    "kv",
+		Path:     "junos/{host}",
+		CacheTTL: time.Minute,
+	})
+	defer c.Close()
+
+	p := c.CredentialsProvider(c.PathForHost("router1", ""), "junos_exporter")
+	for i := 0; i < 2; i++ {
+		creds, err := p()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "junos_exporter", creds.Username, "username")
+		assert.Equal(t, "secret", creds.Password, "password")
+	}
+	assert.Equal(t, 1, reads, "reads")
+
+	_, err := c.Credentials(c.PathForHost("router2", ""))
+	assert.Error(t, err)
+
+	assert.Equal(t, "other/path", c.PathForHost("router1", "/other/path/"), "device path")
+}
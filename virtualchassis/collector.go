@@ -43,16 +43,9 @@ func (c *virtualchassisCollector) Collect(client *rpc.Client, ch chan<- promethe
 	}
 
 	var x = virtualChassisRpc{}
-	if client.Netconf {
-		err := client.RunCommandAndParse("<get-virtual-chassis-information/>", &x)
-		if err != nil {
-			return nil
-		}
-	} else {
-		err := client.RunCommandAndParse("show virtual-chassis", &x)
-		if err != nil {
-			return err
-		}
+	err := client.RunCommandAndParse("show virtual-chassis", &x)
+	if err != nil {
+		return err
 	}
 
 	for _, m := range x.VirtualChassisInformation.MemberList.Member {
@@ -0,0 +1,134 @@
+package vpn
+
+import (
+	"strings"
+
+	"github.com/czerwonk/junos_exporter/collector"
+	"github.com/czerwonk/junos_exporter/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const prefix = "junos_vpn_"
+
+var (
+	instancesDesc         *prometheus.Desc
+	instanceUpDesc        *prometheus.Desc
+	instanceInterfaces    *prometheus.Desc
+	instanceInterfacesUp  *prometheus.Desc
+	instanceInterfaceDown *prometheus.Desc
+)
+
+func init() {
+	instancesDesc = prometheus.NewDesc(prefix+"instances_count", "Number of routing instances by type (e.g. vrf, l2vpn, evpn, vpls)", []string{"target", "type"}, nil)
+
+	l := []string{"target", "routing_instance", "type"}
+	instanceUpDesc = prometheus.NewDesc(prefix+"instance_up", "Routing instance is active (1 = active)", l, nil)
+	instanceInterfaces = prometheus.NewDesc(prefix+"instance_interfaces_count", "Number of interfaces assigned to the routing instance", l, nil)
+	instanceInterfacesUp = prometheus.NewDesc(prefix+"instance_interfaces_up_count", "Number of interfaces assigned to the routing instance which are up", l, nil)
+	instanceInterfaceDown = prometheus.NewDesc(prefix+"instance_interfaces_down_count", "Number of interfaces assigned to the routing instance which are down", l, nil)
+}
+
+type vpnCollector struct {
+	LogicalSystem string
+}
+
+// NewCollector creates a new collector
+func NewCollector(logicalSystem string) collector.RPCCollector {
+	return &vpnCollector{LogicalSystem: logicalSystem}
+}
+
+// Name returns the name of the collector
+func (*vpnCollector) Name() string {
+	return "VPN"
+}
+
+// Describe describes the metrics
+func (*vpnCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instancesDesc
+	ch <- instanceUpDesc
+	ch <- instanceInterfaces
+	ch <- instanceInterfacesUp
+	ch <- instanceInterfaceDown
+}
+
+// Collect collects metrics from JunOS
+func (c *vpnCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
+	var x = instanceRpc{}
+	var cmd strings.Builder
+	cmd.WriteString("show route instance detail")
+	if c.LogicalSystem != "" {
+		cmd.WriteString(" logical-system " + c.LogicalSystem)
+	}
+
+	err := client.RunCommandAndParse(cmd.String(), &x)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.interfaceStatus(client)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, i := range x.Instances {
+		if !isServiceInstance(i) {
+			continue
+		}
+
+		counts[i.Type]++
+		c.collectForInstance(i, status, ch, labelValues)
+	}
+
+	for t, count := range counts {
+		ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, float64(count), append(labelValues, t)...)
+	}
+
+	return nil
+}
+
+// isServiceInstance filters the master instance and instances used internally by JunOS (e.g. __juniper_private1__)
+func isServiceInstance(i routingInstance) bool {
+	return i.Name != collector.MasterInstance && !strings.HasPrefix(i.Name, "__")
+}
+
+// interfaceStatus returns the operational status of all physical and logical interfaces by name
+func (c *vpnCollector) interfaceStatus(client *rpc.Client) (map[string]bool, error) {
+	var x = interfaceTerseRpc{}
+	err := client.RunCommandAndParse("show interfaces terse", &x)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]bool)
+	for _, phy := range x.Interfaces {
+		status[phy.Name] = strings.TrimSpace(phy.OperStatus) == "up"
+
+		for _, ifl := range phy.LogicalInterfaces {
+			status[ifl.Name] = strings.TrimSpace(ifl.OperStatus) == "up"
+		}
+	}
+
+	return status, nil
+}
+
+func (c *vpnCollector) collectForInstance(i routingInstance, status map[string]bool, ch chan<- prometheus.Metric, labelValues []string) {
+	l := append(labelValues, i.Name, i.Type)
+
+	up := 0
+	if i.State == "Active" {
+		up = 1
+	}
+
+	interfacesUp := 0
+	for _, ifd := range i.Interfaces {
+		if status[ifd.Name] {
+			interfacesUp++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(instanceUpDesc, prometheus.GaugeValue, float64(up), l...)
+	ch <- prometheus.MustNewConstMetric(instanceInterfaces, prometheus.GaugeValue, float64(len(i.Interfaces)), l...)
+	ch <- prometheus.MustNewConstMetric(instanceInterfacesUp, prometheus.GaugeValue, float64(interfacesUp), l...)
+	ch <- prometheus.MustNewConstMetric(instanceInterfaceDown, prometheus.GaugeValue, float64(len(i.Interfaces)-interfacesUp), l...)
+}
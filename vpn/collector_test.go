@@ -0,0 +1,52 @@
+package vpn
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInstances(t *testing.T) {
+	body := `<rpc-reply>
+<instance-information>
+<instance-core>
+<instance-name>master</instance-name>
+<instance-type>forwarding</instance-type>
+<instance-state>Active</instance-state>
+</instance-core>
+<instance-core>
+<instance-name>__juniper_private1__</instance-name>
+<instance-type>forwarding</instance-type>
+<instance-state>Active</instance-state>
+</instance-core>
+<instance-core>
+<instance-name>customer</instance-name>
+<instance-type>vrf</instance-type>
+<instance-state>Active</instance-state>
+<instance-interface>
+<interface-name>ge-0/0/1.100</interface-name>
+</instance-interface>
+<instance-interface>
+<interface-name>ge-0/0/2.100</interface-name>
+</instance-interface>
+</instance-core>
+</instance-information>
+</rpc-reply>`
+
+	rpc := instanceRpc{}
+	err := xml.Unmarshal([]byte(body), &rpc)
+	assert.NoError(t, err)
+
+	assert.Len(t, rpc.Instances, 3)
+	assert.False(t, isServiceInstance(rpc.Instances[0]))
+	assert.False(t, isServiceInstance(rpc.Instances[1]))
+
+	i := rpc.Instances[2]
+	assert.True(t, isServiceInstance(i))
+	assert.Equal(t, "customer", i.Name)
+	assert.Equal(t, "vrf", i.Type)
+	assert.Equal(t, "Active", i.State)
+	assert.Len(t, i.Interfaces, 2)
+	assert.Equal(t, "ge-0/0/2.100", i.Interfaces[1].Name)
+}
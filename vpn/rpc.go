@@ -0,0 +1,25 @@
+package vpn
+
+type instanceRpc struct {
+	Instances []routingInstance `xml:"instance-information>instance-core"`
+}
+
+type routingInstance struct {
+	Name       string `xml:"instance-name"`
+	Type       string `xml:"instance-type"`
+	State      string `xml:"instance-state"`
+	Interfaces []struct {
+		Name string `xml:"interface-name"`
+	} `xml:"instance-interface"`
+}
+
+type interfaceTerseRpc struct {
+	Interfaces []struct {
+		Name              string `xml:"name"`
+		OperStatus        string `xml:"oper-status"`
+		LogicalInterfaces []struct {
+			Name       string `xml:"name"`
+			OperStatus string `xml:"oper-status"`
+		} `xml:"logical-interface"`
+	} `xml:"interface-information>physical-interface"`
+}
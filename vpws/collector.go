@@ -26,9 +26,11 @@ var (
 func init() {
 	l := []string{"target", "vpwsinstance", "rd", "interface", "esi", "mode", "role"}
 	vpwsStatus = prometheus.NewDesc(prefix+"status", "vpws status (0: down, 1:up)", l, nil)
+	collector.RegisterStates(prefix+"status", vpwsStatusMap)
 
 	ls := []string{"target", "vpwsinstance", "rd", "interface", "sidorigin", "sid", "ip", "esi", "mode", "role"}
 	vpwsSid = prometheus.NewDesc(prefix+"sid", "vpws sid (0: Unresolved, 1:Resolved)", ls, nil)
+	collector.RegisterStates(prefix+"sid", vpwsSidMap)
 }
 
 type vpwsCollector struct {
@@ -9,12 +9,18 @@ import (
 const prefix = "junos_vrrp_"
 
 var (
-	vrrpState *prometheus.Desc
+	vrrpState    *prometheus.Desc
+	vrrpStateMap = map[string]int{
+		"init":   1,
+		"backup": 2,
+		"master": 3,
+	}
 )
 
 func init() {
 	l := []string{"target", "interface", "group", "local_interface_address", "virtual_ip_address"}
 	vrrpState = prometheus.NewDesc(prefix+"state", "VRRP state (1: init, 2: backup, 3: master)", l, nil)
+	collector.RegisterStates(prefix+"state", vrrpStateMap)
 }
 
 type vrrpCollector struct {
@@ -37,12 +43,6 @@ func (*vrrpCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect collects metrics from JunOS
 func (c *vrrpCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric, labelValues []string) error {
-	statusValues := map[string]int{
-		"init":   1,
-		"backup": 2,
-		"master": 3,
-	}
-
 	var x = VrrpRpc{}
 	err := client.RunCommandAndParse("show vrrp summary", &x)
 	if err != nil {
@@ -52,7 +52,7 @@ func (c *vrrpCollector) Collect(client *rpc.Client, ch chan<- prometheus.Metric,
 	for _, iface := range x.Information.Interfaces {
 		l := labelValues
 		l = append(l, iface.Interface, iface.Group, iface.LocalInterfaceAddress, iface.VirtualIpAddress)
-		ch <- prometheus.MustNewConstMetric(vrrpState, prometheus.GaugeValue, float64(statusValues[iface.VrrpState]), l...)
+		ch <- prometheus.MustNewConstMetric(vrrpState, prometheus.GaugeValue, float64(vrrpStateMap[iface.VrrpState]), l...)
 	}
 
 	return nil